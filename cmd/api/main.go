@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"pomodoro-rpg-api/cmd/api/router"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/infra/cache"
+	"pomodoro-rpg-api/infra/datafile"
 	"pomodoro-rpg-api/infra/db"
 	"pomodoro-rpg-api/infra/persistence"
+	"pomodoro-rpg-api/infra/realtime"
 	"pomodoro-rpg-api/infra/service"
 	"pomodoro-rpg-api/pkg/config"
 	"pomodoro-rpg-api/pkg/logger"
 	"pomodoro-rpg-api/presentation/handler"
 	"pomodoro-rpg-api/presentation/middleware"
+	"pomodoro-rpg-api/presentation/worker"
 	"pomodoro-rpg-api/usecase"
 )
 
@@ -27,32 +33,192 @@ func main() {
 
 	logger.Init()
 
-	accRepo := persistence.NewaccountPersistence(gorm)
-	accUsecase := usecase.NewAccountUsecase(accRepo)
+	tx := persistence.NewTransaction(gorm)
+	accRepo := persistence.NewaccountPersistence(gorm, conf.DB.Timeout)
+	outboxRepo := persistence.NewOutboxPersistence(gorm, conf.DB.Timeout)
+	charRepo := persistence.NewCharacterPersistence(gorm, conf.DB.Timeout)
+	rewardLedgerRepo := persistence.NewRewardLedgerPersistence(gorm, conf.DB.Timeout)
+	settingsRepo := persistence.NewSettingsPersistence(gorm, conf.DB.Timeout)
+	statisticsRepo := persistence.NewStatisticsPersistence(gorm, conf.DB.Timeout)
+	outboxUsecase := usecase.NewOutboxUsecase(outboxRepo)
+
+	auditRepo := persistence.NewAuditEventPersistence(gorm, conf.DB.Timeout)
+	auditUsecase := usecase.NewAuditUsecase(accRepo, auditRepo)
+	auditHandler := handler.NewAuditHandler(auditUsecase)
+
+	lootTableRepo := datafile.NewLootTableFile(conf.Loot.TablesDir)
+	lootTableVersionRepo := persistence.NewLootTableVersionPersistence(gorm, conf.DB.Timeout)
+	lootPityRepo := persistence.NewLootPityPersistence(gorm, conf.DB.Timeout)
+	lootDropRepo := persistence.NewLootDropPersistence(gorm, conf.DB.Timeout)
+	lootUsecase := usecase.NewLootUsecase(accRepo, lootTableRepo, lootTableVersionRepo, lootPityRepo, lootDropRepo, outboxRepo, auditUsecase)
+	if err := lootUsecase.Load(context.Background()); err != nil {
+		log.Fatalf("loot tables load failed: %v", err)
+	}
+	lootHandler := handler.NewLootHandler(lootUsecase)
+	companionRepo := persistence.NewCompanionPersistence(gorm, conf.DB.Timeout)
+	rewarder := usecase.NewRewarder(charRepo, outboxRepo, rewardLedgerRepo, settingsRepo, statisticsRepo, companionRepo, lootUsecase)
+
+	accUsecase := usecase.NewAccountUsecase(accRepo, auditUsecase)
 	accHandler := handler.NewAccountHandler(accUsecase)
 
-	tr := persistence.NewTimePersistence(gorm)
-	tu := usecase.NewTimeUsecase(accRepo, tr)
+	settingsUsecase := usecase.NewSettingsUsecase(accRepo, settingsRepo)
+	settingsHandler := handler.NewSettingsHandler(settingsUsecase)
+
+	projectRepo := persistence.NewProjectPersistence(gorm, conf.DB.Timeout)
+	taskRepo := persistence.NewTaskPersistence(gorm, conf.DB.Timeout)
+	projectUsecase := usecase.NewProjectUsecase(accRepo, projectRepo)
+	projectHandler := handler.NewProjectHandler(projectUsecase)
+	taskUsecase := usecase.NewTaskUsecase(accRepo, projectRepo, taskRepo)
+	taskHandler := handler.NewTaskHandler(taskUsecase)
+
+	tr := persistence.NewTimePersistence(gorm, conf.DB.Timeout)
+	tagRepo := persistence.NewTagPersistence(gorm, conf.DB.Timeout)
+	breakRepo := persistence.NewBreakPersistence(gorm, conf.DB.Timeout)
+	cycleRepo := persistence.NewCyclePersistence(gorm, conf.DB.Timeout)
+	sessionRecorder := usecase.NewSessionRecorder(tr, breakRepo, cycleRepo, outboxRepo, rewarder)
+
+	goalRepo := persistence.NewGoalPersistence(gorm, conf.DB.Timeout)
+	goalAchievementRepo := persistence.NewGoalAchievementPersistence(gorm, conf.DB.Timeout)
+	goalMissRepo := persistence.NewGoalMissPersistence(gorm, conf.DB.Timeout)
+	goalUsecase := usecase.NewGoalUsecase(accRepo, settingsRepo, goalRepo, goalAchievementRepo, goalMissRepo, statisticsRepo, outboxRepo, tx, rewarder)
+	goalHandler := handler.NewGoalHandler(goalUsecase)
+	outboxUsecase.Subscribe(model.EventFocusSessionCompleted, goalUsecase.Evaluate)
+
+	seasonRepo := datafile.NewSeasonFile(conf.Season.Dir)
+	seasonActivityRepo := persistence.NewSeasonActivityPersistence(gorm, conf.DB.Timeout)
+	seasonPassRepo := persistence.NewSeasonPassPersistence(gorm, conf.DB.Timeout)
+	seasonClaimRepo := persistence.NewSeasonClaimPersistence(gorm, conf.DB.Timeout)
+	seasonUsecase := usecase.NewSeasonUsecase(accRepo, seasonRepo, seasonActivityRepo, seasonPassRepo, seasonClaimRepo, charRepo, tx, rewarder, auditUsecase)
+	if err := seasonUsecase.Load(context.Background()); err != nil {
+		log.Fatalf("seasons load failed: %v", err)
+	}
+	seasonHandler := handler.NewSeasonHandler(seasonUsecase)
+	outboxUsecase.Subscribe(model.EventFocusSessionCompleted, seasonUsecase.Record)
+	outboxUsecase.Subscribe(model.EventGoalAchieved, seasonUsecase.Record)
+
+	guildRepo := persistence.NewGuildPersistence(gorm, conf.DB.Timeout)
+	guildMembershipRepo := persistence.NewGuildMembershipPersistence(gorm, conf.DB.Timeout)
+	raidRepo := persistence.NewRaidPersistence(gorm, conf.DB.Timeout)
+	raidContributionRepo := persistence.NewRaidContributionPersistence(gorm, conf.DB.Timeout)
+	guildUsecase := usecase.NewGuildUsecase(accRepo, guildRepo, guildMembershipRepo, tx)
+	guildHandler := handler.NewGuildHandler(guildUsecase)
+	raidUsecase := usecase.NewRaidUsecase(accRepo, guildRepo, guildMembershipRepo, raidRepo, raidContributionRepo, tr, tx, rewarder, seasonUsecase)
+	raidHandler := handler.NewRaidHandler(raidUsecase)
+	outboxUsecase.Subscribe(model.EventFocusSessionCompleted, raidUsecase.Attack)
+
+	companionTrainingRepo := persistence.NewCompanionTrainingPersistence(gorm, conf.DB.Timeout)
+	companionUsecase := usecase.NewCompanionUsecase(accRepo, companionRepo, companionTrainingRepo, charRepo, statisticsRepo, tr, outboxRepo, tx)
+	companionHandler := handler.NewCompanionHandler(companionUsecase)
+	outboxUsecase.Subscribe(model.EventFocusSessionCompleted, companionUsecase.Train)
+	outboxUsecase.Subscribe(model.EventLootDropped, companionUsecase.Hatch)
+
+	tu := usecase.NewTimeUsecase(accRepo, settingsRepo, taskRepo, tr, tagRepo, tx, sessionRecorder, rewarder, goalUsecase, raidUsecase, companionUsecase, lootUsecase)
 	th := handler.NewTimeHandler(tu)
+	breakUsecase := usecase.NewBreakUsecase(accRepo, settingsRepo, tx, sessionRecorder)
+	breakHandler := handler.NewBreakHandler(breakUsecase)
+	syncUsecase := usecase.NewSyncUsecase(accRepo, settingsRepo, taskRepo, tr, breakRepo, tx, sessionRecorder)
+	syncHandler := handler.NewSyncHandler(syncUsecase)
 
-	cognitoService, err := service.NewCognitoService(conf.AWS.ClientID, conf.AWS.ClientSecret, conf.AWS.UserPoolID)
+	calendarFeedRepo := persistence.NewCalendarFeedPersistence(gorm, conf.DB.Timeout)
+	calendarUsecase := usecase.NewCalendarUsecase(accRepo, calendarFeedRepo, tr, taskRepo, auditUsecase)
+	calendarHandler := handler.NewCalendarHandler(calendarUsecase)
+
+	statisticsUsecase := usecase.NewStatisticsUsecase(accRepo, settingsRepo, cycleRepo, statisticsRepo, tr, cache.NewHeatmap())
+	statisticsHandler := handler.NewStatisticsHandler(statisticsUsecase)
+
+	friendRequestRepo := persistence.NewFriendRequestPersistence(gorm, conf.DB.Timeout)
+	friendshipRepo := persistence.NewFriendshipPersistence(gorm, conf.DB.Timeout)
+	blockRepo := persistence.NewBlockPersistence(gorm, conf.DB.Timeout)
+	privacyRepo := persistence.NewPrivacyPersistence(gorm, conf.DB.Timeout)
+	friendUsecase := usecase.NewFriendUsecase(accRepo, settingsRepo, charRepo, friendRequestRepo, friendshipRepo, blockRepo, privacyRepo, statisticsRepo, tx)
+	friendHandler := handler.NewFriendHandler(friendUsecase)
+	outboxUsecase.Subscribe(model.EventAccountCreated, friendUsecase.Link)
+
+	leaderboardRepo := persistence.NewLeaderboardPersistence(gorm, conf.DB.Timeout)
+	leaderboardUsecase := usecase.NewLeaderboardUsecase(accRepo, friendshipRepo, leaderboardRepo)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardUsecase)
+
+	characterUsecase := usecase.NewCharacterUsecase(accRepo, charRepo, tx)
+	characterHandler := handler.NewCharacterHandler(characterUsecase)
+
+	focusRoomRepo := persistence.NewFocusRoomPersistence(gorm, conf.DB.Timeout)
+	focusRoomParticipantRepo := persistence.NewFocusRoomParticipantPersistence(gorm, conf.DB.Timeout)
+
+	hub := realtime.NewHub()
+	timerSessionRepo := persistence.NewTimerSessionPersistence(gorm, conf.DB.Timeout)
+	timerUsecase := usecase.NewTimerUsecase(accRepo, settingsRepo, timerSessionRepo, focusRoomRepo, focusRoomParticipantRepo, tx, sessionRecorder, rewarder, hub)
+	timerHandler := handler.NewTimerHandler(timerUsecase)
+
+	roomHub := realtime.NewRoomHub()
+	focusRoomUsecase := usecase.NewFocusRoomUsecase(accRepo, settingsRepo, focusRoomRepo, focusRoomParticipantRepo, timerSessionRepo, tx, sessionRecorder, rewarder, roomHub)
+	focusRoomHandler := handler.NewFocusRoomHandler(focusRoomUsecase)
+
+	cognitoService, err := service.NewCognitoService(conf.AWS.ClientID, conf.AWS.ClientSecret, conf.AWS.UserPoolID, conf.AWS.Timeout)
 	if err != nil {
 		log.Fatalf("cognito initialize failed: %v", err)
 	}
 
-	authUsecase := usecase.NewAuthUsecase(cognitoService, accRepo)
+	webhookRepo := persistence.NewWebhookPersistence(gorm, conf.DB.Timeout)
+	webhookDeliveryRepo := persistence.NewWebhookDeliveryPersistence(gorm, conf.DB.Timeout)
+	webhookSender := service.NewWebhookSender(conf.Worker.WebhookTimeout)
+	webhookUsecase := usecase.NewWebhookUsecase(accRepo, webhookRepo, webhookDeliveryRepo, webhookSender)
+	webhookHandler := handler.NewWebhookHandler(webhookUsecase)
+	for _, ev := range model.WebhookEvents {
+		outboxUsecase.Subscribe(ev, webhookUsecase.Enqueue)
+	}
+
+	authUsecase := usecase.NewAuthUsecase(cognitoService, accRepo, outboxRepo, tx, auditUsecase)
 	authHandler := handler.NewAuthHandler(authUsecase)
 
+	clientIP := middleware.NewClientIPResolver(conf.Proxy.TrustedHops)
 	// TODO: middlewareがcognitoServiceに依存するのはイマイチなのでリファクタする
 	authenticator := middleware.NewAuthenticator(conf.AWS.UserPoolID, conf.AWS.ClientID, cognitoService)
+	adminAuthorizer := middleware.NewAdminAuthorizer(conf.Admin.Emails)
+	idempotencyRepo := persistence.NewIdempotencyPersistence(gorm, conf.DB.Timeout)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyRepo, conf.Idempotency.TTL)
+	idempotency := middleware.NewIdempotency(idempotencyUsecase)
 
 	deps := router.HandlerDependencies{
-		AuthHandler:    authHandler,
-		AccountHandler: accHandler,
-		TimeHandler:    th,
+		AuthHandler:        authHandler,
+		AccountHandler:     accHandler,
+		TimeHandler:        th,
+		AuditHandler:       auditHandler,
+		WebhookHandler:     webhookHandler,
+		TimerHandler:       timerHandler,
+		SettingsHandler:    settingsHandler,
+		BreakHandler:       breakHandler,
+		StatisticsHandler:  statisticsHandler,
+		ProjectHandler:     projectHandler,
+		TaskHandler:        taskHandler,
+		SyncHandler:        syncHandler,
+		CalendarHandler:    calendarHandler,
+		GoalHandler:        goalHandler,
+		FriendHandler:      friendHandler,
+		LeaderboardHandler: leaderboardHandler,
+		GuildHandler:       guildHandler,
+		RaidHandler:        raidHandler,
+		FocusRoomHandler:   focusRoomHandler,
+		CharacterHandler:   characterHandler,
+		CompanionHandler:   companionHandler,
+		LootHandler:        lootHandler,
+		SeasonHandler:      seasonHandler,
 	}
 
-	r := router.New(deps, authenticator)
+	r := router.New(deps, clientIP, authenticator, adminAuthorizer, idempotency)
+
+	ctx := context.Background()
+	go worker.NewPoller(outboxUsecase.Dispatch, conf.Worker.OutboxInterval).Run(ctx)
+	go worker.NewPoller(webhookUsecase.Deliver, conf.Worker.WebhookInterval).Run(ctx)
+	go worker.NewPoller(idempotencyUsecase.Purge, conf.Idempotency.PurgeInterval).Run(ctx)
+	// ランキングは毎回全件を集計し直すため、処理件数を返さず次の間隔まで待つ
+	go worker.NewPoller(func(ctx context.Context) (int, error) {
+		return 0, leaderboardUsecase.Refresh(ctx)
+	}, conf.Worker.LeaderboardInterval).Run(ctx)
+	// 日付の変わる時刻は利用者のタイムゾーンごとに異なるため、短い間隔で前日分を判定する
+	go worker.NewPoller(func(ctx context.Context) (int, error) {
+		return 0, goalUsecase.PenalizeMisses(ctx)
+	}, conf.Worker.GoalPenaltyInterval).Run(ctx)
+	go realtime.NewListener(db.DSN(conf.DB), hub, roomHub).Run(ctx)
 
 	log.Println("🚀 Server is running!")
 	http.ListenAndServe(":8080", r)
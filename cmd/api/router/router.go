@@ -10,20 +10,42 @@ import (
 )
 
 type HandlerDependencies struct {
-	AuthHandler    handler.AuthHandler
-	AccountHandler handler.AccountHandler
-	TimeHandler    handler.TimeHandler
+	AuthHandler        handler.AuthHandler
+	AccountHandler     handler.AccountHandler
+	TimeHandler        handler.TimeHandler
+	AuditHandler       handler.AuditHandler
+	WebhookHandler     handler.WebhookHandler
+	TimerHandler       handler.TimerHandler
+	SettingsHandler    handler.SettingsHandler
+	BreakHandler       handler.BreakHandler
+	StatisticsHandler  handler.StatisticsHandler
+	ProjectHandler     handler.ProjectHandler
+	TaskHandler        handler.TaskHandler
+	SyncHandler        handler.SyncHandler
+	CalendarHandler    handler.CalendarHandler
+	GoalHandler        handler.GoalHandler
+	FriendHandler      handler.FriendHandler
+	LeaderboardHandler handler.LeaderboardHandler
+	GuildHandler       handler.GuildHandler
+	RaidHandler        handler.RaidHandler
+	FocusRoomHandler   handler.FocusRoomHandler
+	CharacterHandler   handler.CharacterHandler
+	CompanionHandler   handler.CompanionHandler
+	LootHandler        handler.LootHandler
+	SeasonHandler      handler.SeasonHandler
 }
 
-func New(deps HandlerDependencies, authenticator *middleware.Authenticator) *chi.Mux {
+func New(deps HandlerDependencies, clientIP *middleware.ClientIPResolver, authenticator *middleware.Authenticator, adminAuthorizer *middleware.AdminAuthorizer, idempotency *middleware.Idempotency) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
+	r.Use(clientIP.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", middleware.IdempotencyKeyHeader},
+		ExposedHeaders:   []string{middleware.IdempotentReplayedHeader},
 		AllowCredentials: true,
 	}))
 
@@ -42,6 +64,8 @@ func New(deps HandlerDependencies, authenticator *middleware.Authenticator) *chi
 	r.Post("/signin", deps.AuthHandler.SignIn)
 	r.Post("/forgot-password", deps.AuthHandler.ForgotPassword)
 	r.Post("/forgot-password/confirm", deps.AuthHandler.ConfirmForgotPassword)
+	// カレンダーアプリから購読するため、ログインではなくURLに含まれるトークンで認証する
+	r.Get("/calendar/{file}", deps.CalendarHandler.Feed)
 
 	r.Group(func(r chi.Router) {
 		r.Use(authenticator.Middleware)
@@ -52,8 +76,165 @@ func New(deps HandlerDependencies, authenticator *middleware.Authenticator) *chi
 		})
 
 		r.Post("/signout", deps.AuthHandler.SignOut)
-		r.Post("/times", deps.TimeHandler.Create)
+		// 報酬の付与・調整を伴うエンドポイントは再送による二重付与を防ぐためIdempotency-Keyに対応する
+		r.Route("/times", func(r chi.Router) {
+			r.Get("/", deps.TimeHandler.List)
+			r.Get("/heatmap", deps.StatisticsHandler.Heatmap)
+			r.With(idempotency.Middleware).Post("/", deps.TimeHandler.Create)
+			r.With(idempotency.Middleware).Put("/{id}", deps.TimeHandler.Update)
+			r.With(idempotency.Middleware).Delete("/{id}", deps.TimeHandler.Delete)
+		})
+		r.Get("/tags", deps.TimeHandler.SuggestTags)
+		r.With(idempotency.Middleware).Post("/breaks", deps.BreakHandler.Create)
+		// セッションごとに端末で生成したIDで重複を判定するため、Idempotency-Keyは不要
+		r.Post("/sync", deps.SyncHandler.Sync)
+
+		r.Route("/stats", func(r chi.Router) {
+			r.Get("/daily", deps.StatisticsHandler.Daily)
+			r.Get("/cycle", deps.StatisticsHandler.Cycle)
+			r.Get("/tasks", deps.StatisticsHandler.Tasks)
+			r.Get("/projects", deps.StatisticsHandler.Projects)
+			r.Get("/tags", deps.StatisticsHandler.Tags)
+		})
+
+		r.Route("/projects", func(r chi.Router) {
+			r.Get("/", deps.ProjectHandler.List)
+			r.Post("/", deps.ProjectHandler.Create)
+			r.Put("/{id}", deps.ProjectHandler.Update)
+			r.Delete("/{id}", deps.ProjectHandler.Delete)
+		})
+
+		r.Route("/tasks", func(r chi.Router) {
+			r.Get("/", deps.TaskHandler.List)
+			r.Post("/", deps.TaskHandler.Create)
+			r.Put("/{id}", deps.TaskHandler.Update)
+			r.Delete("/{id}", deps.TaskHandler.Delete)
+		})
+
+		r.Route("/goals", func(r chi.Router) {
+			r.Get("/", deps.GoalHandler.List)
+			r.Get("/history", deps.GoalHandler.History)
+			r.Put("/{period}", deps.GoalHandler.Set)
+			r.Delete("/{period}", deps.GoalHandler.Delete)
+		})
+
+		r.Route("/friends", func(r chi.Router) {
+			r.Get("/", deps.FriendHandler.List)
+			r.Delete("/{id}", deps.FriendHandler.Remove)
+			r.Get("/requests", deps.FriendHandler.Requests)
+			r.Post("/requests", deps.FriendHandler.SendRequest)
+			r.Post("/requests/{id}/accept", deps.FriendHandler.Accept)
+			r.Post("/requests/{id}/decline", deps.FriendHandler.Decline)
+			r.Delete("/requests/{id}", deps.FriendHandler.Cancel)
+			r.Get("/blocks", deps.FriendHandler.Blocks)
+			r.Post("/blocks", deps.FriendHandler.Block)
+			r.Delete("/blocks/{id}", deps.FriendHandler.Unblock)
+		})
+
+		r.Get("/leaderboards", deps.LeaderboardHandler.Get)
+
+		r.Route("/guilds", func(r chi.Router) {
+			r.Get("/", deps.GuildHandler.Search)
+			r.Post("/", deps.GuildHandler.Create)
+			r.Post("/{id}/join", deps.GuildHandler.Join)
+		})
+
+		// アカウントは1つのギルドにのみ所属するため、所属するギルドの操作はIDを指定しない
+		r.Route("/guild", func(r chi.Router) {
+			r.Get("/", deps.GuildHandler.Mine)
+			r.Put("/", deps.GuildHandler.Update)
+			r.Delete("/", deps.GuildHandler.Disband)
+			r.Post("/leave", deps.GuildHandler.Leave)
+			r.Put("/members/{id}/role", deps.GuildHandler.ChangeRole)
+			r.Delete("/members/{id}", deps.GuildHandler.Kick)
+			r.Get("/raids", deps.RaidHandler.History)
+			r.Post("/raids", deps.RaidHandler.Start)
+			r.Get("/raids/current", deps.RaidHandler.Current)
+			r.Get("/raids/{id}", deps.RaidHandler.Get)
+		})
+		r.Get("/raid-bosses", deps.RaidHandler.Bosses)
+
+		r.Route("/character", func(r chi.Router) {
+			r.Get("/", deps.CharacterHandler.Get)
+			r.Put("/class", deps.CharacterHandler.ChooseClass)
+			r.Get("/skills", deps.CharacterHandler.Skills)
+			r.Post("/skills/{key}", deps.CharacterHandler.LearnSkill)
+		})
+		r.Get("/classes", deps.CharacterHandler.Classes)
+
+		r.Route("/companions", func(r chi.Router) {
+			r.Get("/", deps.CompanionHandler.List)
+			r.Post("/{id}/activate", deps.CompanionHandler.Activate)
+			r.Put("/{id}/name", deps.CompanionHandler.Rename)
+		})
+		r.Get("/companion-species", deps.CompanionHandler.Species)
+
+		r.Route("/loot", func(r chi.Router) {
+			r.Get("/tables", deps.LootHandler.Tables)
+			r.Get("/drops", deps.LootHandler.Drops)
+			r.Get("/inventory", deps.LootHandler.Inventory)
+		})
+
+		r.Route("/seasons", func(r chi.Router) {
+			r.Get("/", deps.SeasonHandler.List)
+			r.Get("/{key}", deps.SeasonHandler.Get)
+			r.With(idempotency.Middleware).Post("/{key}/premium", deps.SeasonHandler.PurchasePremium)
+			r.With(idempotency.Middleware).Post("/{key}/claim", deps.SeasonHandler.Claim)
+		})
+
+		r.Route("/privacy", func(r chi.Router) {
+			r.Get("/", deps.FriendHandler.GetPrivacy)
+			r.Put("/", deps.FriendHandler.UpdatePrivacy)
+		})
+
+		r.Route("/calendar-feed", func(r chi.Router) {
+			r.Post("/", deps.CalendarHandler.Issue)
+			r.Delete("/", deps.CalendarHandler.Revoke)
+		})
+
+		r.Route("/rooms", func(r chi.Router) {
+			r.Post("/", deps.FocusRoomHandler.Create)
+			r.Post("/join", deps.FocusRoomHandler.Join)
+			r.Get("/{id}", deps.FocusRoomHandler.Get)
+			r.Get("/{id}/stream", deps.FocusRoomHandler.Stream)
+			r.Post("/{id}/start", deps.FocusRoomHandler.Start)
+			r.Post("/{id}/pause", deps.FocusRoomHandler.Pause)
+			r.Post("/{id}/resume", deps.FocusRoomHandler.Resume)
+			r.With(idempotency.Middleware).Post("/{id}/complete", deps.FocusRoomHandler.Complete)
+			r.Post("/{id}/leave", deps.FocusRoomHandler.Leave)
+		})
+
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", deps.SettingsHandler.Get)
+			r.Put("/", deps.SettingsHandler.Update)
+		})
+
+		r.Route("/timer", func(r chi.Router) {
+			r.Get("/", deps.TimerHandler.Get)
+			r.Get("/stream", deps.TimerHandler.Stream)
+			r.Post("/start", deps.TimerHandler.Start)
+			r.Post("/pause", deps.TimerHandler.Pause)
+			r.Post("/resume", deps.TimerHandler.Resume)
+			r.With(idempotency.Middleware).Post("/complete", deps.TimerHandler.Complete)
+			r.Post("/abandon", deps.TimerHandler.Abandon)
+		})
 		r.Post("/change-password", deps.AuthHandler.ChangePassword)
+
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Get("/", deps.WebhookHandler.List)
+			r.Post("/", deps.WebhookHandler.Create)
+			r.Delete("/{id}", deps.WebhookHandler.Delete)
+			r.Get("/{id}/deliveries", deps.WebhookHandler.Deliveries)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(adminAuthorizer.Middleware)
+
+			r.Get("/audit-events", deps.AuditHandler.Search)
+			r.Post("/loot-tables/reload", deps.LootHandler.Reload)
+			r.Get("/loot-drops/{id}/verify", deps.LootHandler.Verify)
+			r.Post("/seasons/reload", deps.SeasonHandler.Reload)
+		})
 	})
 
 	return r
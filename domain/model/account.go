@@ -1,6 +1,10 @@
 package model
 
-import "github.com/cockroachdb/errors"
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
 
 type Account struct {
 	ID         AccountID
@@ -8,6 +12,7 @@ type Account struct {
 	Email      string
 	Name       string
 	Image      string
+	eventRecorder
 }
 
 func NewAccount(id AccountID, cognitoUID, email, name, image string) (Account, error) {
@@ -23,13 +28,20 @@ func NewAccount(id AccountID, cognitoUID, email, name, image string) (Account, e
 		return Account{}, errors.New("name is required")
 	}
 
-	return Account{
+	acc := Account{
 		ID:         id,
 		CognitoUID: cognitoUID,
 		Email:      email,
 		Name:       name,
 		Image:      image,
-	}, nil
+	}
+	acc.record(AccountCreated{
+		AccountID: id,
+		Email:     email,
+		At:        time.Now(),
+	})
+
+	return acc, nil
 }
 
 func RecreateAccount(id AccountID, cognitoUID, email, name, image string) Account {
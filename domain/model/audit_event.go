@@ -0,0 +1,97 @@
+package model
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type AuditAction string
+
+const (
+	AuditActionSignIn             AuditAction = "sign_in"
+	AuditActionSignOut            AuditAction = "sign_out"
+	AuditActionChangePassword     AuditAction = "change_password"
+	AuditActionResetPassword      AuditAction = "reset_password"
+	AuditActionUpdateAccount      AuditAction = "update_account"
+	AuditActionSearchAuditEvents  AuditAction = "search_audit_events"
+	AuditActionIssueCalendarFeed  AuditAction = "issue_calendar_feed"
+	AuditActionRevokeCalendarFeed AuditAction = "revoke_calendar_feed"
+	AuditActionReloadLootTables   AuditAction = "reload_loot_tables"
+	AuditActionReloadSeasons      AuditAction = "reload_seasons"
+	AuditActionPurchaseSeasonPass AuditAction = "purchase_season_pass"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetAccount      AuditTargetType = "account"
+	AuditTargetAuditEvent   AuditTargetType = "audit_event"
+	AuditTargetCalendarFeed AuditTargetType = "calendar_feed"
+	AuditTargetLootTable    AuditTargetType = "loot_table"
+	AuditTargetSeason       AuditTargetType = "season"
+)
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditDiff はフィールド名ごとの変更前後の値を保持する
+type AuditDiff map[string]AuditChange
+
+func (d AuditDiff) Add(field string, before, after any) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	d[field] = AuditChange{Before: before, After: after}
+}
+
+type AuditEvent struct {
+	ID         AuditEventID
+	ActorID    AccountID
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   string
+	RequestID  string
+	IP         string
+	Diff       AuditDiff
+	CreatedAt  time.Time
+}
+
+func NewAuditEvent(id AuditEventID, actorID AccountID, action AuditAction, targetType AuditTargetType, targetID, requestID, ip string, diff AuditDiff) (AuditEvent, error) {
+	if action == "" {
+		return AuditEvent{}, errors.New("action is required")
+	}
+
+	if targetType == "" {
+		return AuditEvent{}, errors.New("target type is required")
+	}
+
+	return AuditEvent{
+		ID:         id,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		RequestID:  requestID,
+		IP:         ip,
+		Diff:       diff,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func RecreateAuditEvent(id AuditEventID, actorID AccountID, action AuditAction, targetType AuditTargetType, targetID, requestID, ip string, diff AuditDiff, createdAt time.Time) AuditEvent {
+	return AuditEvent{
+		ID:         id,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		RequestID:  requestID,
+		IP:         ip,
+		Diff:       diff,
+		CreatedAt:  createdAt,
+	}
+}
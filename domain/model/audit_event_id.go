@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type AuditEventID string
+
+func NewAuditEventID(s string) (AuditEventID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid audit event id")
+	}
+
+	return AuditEventID(id.String()), nil
+}
+
+func GenerateAuditEventID() AuditEventID {
+	return AuditEventID(uuid.NewString())
+}
+
+func (a AuditEventID) String() string {
+	return string(a)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type Break struct {
+	ID            BreakID
+	AccountID     AccountID
+	Kind          SessionKind
+	Minutes       float64
+	ExecutionDate time.Time
+	CreatedAt     time.Time
+	eventRecorder
+}
+
+func NewBreak(id BreakID, accID AccountID, kind SessionKind, minutes float64) (Break, error) {
+	return NewBreakAt(id, accID, kind, minutes, time.Now())
+}
+
+// NewBreakAt はオフライン中に取った休憩など、実施日時を指定して記録する
+func NewBreakAt(id BreakID, accID AccountID, kind SessionKind, minutes float64, executionDate time.Time) (Break, error) {
+	if !kind.IsBreak() {
+		return Break{}, errors.Newf("%s is not a break", kind)
+	}
+
+	if minutes <= 0 {
+		return Break{}, errors.New("break time must be greater than 0")
+	}
+
+	b := Break{
+		ID:            id,
+		AccountID:     accID,
+		Kind:          kind,
+		Minutes:       minutes,
+		ExecutionDate: executionDate,
+	}
+	b.record(BreakCompleted{
+		BreakID:   id,
+		AccountID: accID,
+		Kind:      kind,
+		Minutes:   minutes,
+		At:        time.Now(),
+	})
+
+	return b, nil
+}
+
+func RecreateBreak(id BreakID, accID AccountID, kind SessionKind, minutes float64, executionDate, createdAt time.Time) Break {
+	return Break{
+		ID:            id,
+		AccountID:     accID,
+		Kind:          kind,
+		Minutes:       minutes,
+		ExecutionDate: executionDate,
+		CreatedAt:     createdAt,
+	}
+}
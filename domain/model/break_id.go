@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type BreakID string
+
+func NewBreakID(s string) (BreakID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid break id")
+	}
+
+	return BreakID(id.String()), nil
+}
+
+func GenerateBreakID() BreakID {
+	return BreakID(uuid.NewString())
+}
+
+func (b BreakID) String() string {
+	return string(b)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CalendarFeed はカレンダーアプリから認証なしで購読するためのフィードURLのトークン。
+// トークン自体は発行時にのみ返し、漏洩に備えてハッシュのみを保存する。アカウントごとに1つまで
+type CalendarFeed struct {
+	AccountID AccountID
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// NewCalendarFeed は新しいトークンを発行する。保存すると既存のトークンは無効になる
+func NewCalendarFeed(accID AccountID) (CalendarFeed, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return CalendarFeed{}, "", errors.WithStack(err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return CalendarFeed{
+		AccountID: accID,
+		TokenHash: HashCalendarFeedToken(token),
+		CreatedAt: time.Now(),
+	}, token, nil
+}
+
+func RecreateCalendarFeed(accID AccountID, tokenHash string, createdAt time.Time) CalendarFeed {
+	return CalendarFeed{
+		AccountID: accID,
+		TokenHash: tokenHash,
+		CreatedAt: createdAt,
+	}
+}
+
+func HashCalendarFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CalendarEvent はフィードに載せる1回分の集中セッション
+type CalendarEvent struct {
+	ID        TimeID
+	Start     time.Time
+	End       time.Time
+	TaskTitle string
+}
+
+// NewCalendarEvent は記録日時を終了日時とし、集中時間から開始日時を求める
+func NewCalendarEvent(t Time, taskTitle string) CalendarEvent {
+	return CalendarEvent{
+		ID:        t.ID,
+		Start:     t.ExecutionDate.Add(-time.Duration(t.FocusTime * float64(time.Minute))),
+		End:       t.ExecutionDate,
+		TaskTitle: taskTitle,
+	}
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	initialLevel = 1
+
+	baseMaxHP        = 50
+	maxHPPerStrength = 10
+	// reviveHPPercent は気絶から回復するのに必要な最大HPに対するHPの割合
+	reviveHPPercent = 50
+)
+
+type Character struct {
+	AccountID AccountID
+	Level     int
+	Exp       int
+	Gold      int
+	// Class は未選択の間は空。最初の報酬付与時にキャラクターが作られるため、作成後に一度だけ選択できる
+	Class CharacterClass
+	// Skills は習得済みのスキルのキー
+	Skills []string
+	HP     int
+	// KnockedOut はHPが0になってから回復するまでの間、報酬を受け取れない状態
+	KnockedOut bool
+	eventRecorder
+}
+
+func NewCharacter(accID AccountID) Character {
+	c := Character{
+		AccountID: accID,
+		Level:     initialLevel,
+	}
+	c.HP = c.MaxHP()
+	return c
+}
+
+// RecreateCharacter はhpがnilの場合(HPの導入前に作られたキャラクター)は最大HPとする
+func RecreateCharacter(accID AccountID, level, exp, gold int, class CharacterClass, skills []string, hp *int, knockedOut bool) Character {
+	c := Character{
+		AccountID:  accID,
+		Level:      level,
+		Exp:        exp,
+		Gold:       gold,
+		Class:      class,
+		Skills:     skills,
+		KnockedOut: knockedOut,
+	}
+	c.HP = c.MaxHP()
+	if hp != nil {
+		c.HP = min(*hp, c.HP)
+	}
+	return c
+}
+
+// RequiredExp はlevelに到達するのに必要な累計経験値を返す
+func RequiredExp(level int) int {
+	return 50 * level * (level - 1)
+}
+
+func (c *Character) GainExp(exp int) {
+	if exp <= 0 {
+		return
+	}
+
+	c.Exp += exp
+
+	from := c.Level
+	for c.Exp >= RequiredExp(c.Level+1) {
+		c.Level++
+	}
+
+	if c.Level > from {
+		// レベルアップでHPを全回復する
+		c.HP = c.MaxHP()
+		c.record(LevelUp{
+			AccountID: c.AccountID,
+			From:      from,
+			To:        c.Level,
+			At:        time.Now(),
+		})
+	}
+}
+
+func (c *Character) GainGold(gold int) {
+	if gold <= 0 {
+		return
+	}
+	c.Gold += gold
+}
+
+// LoseExp は記録の取り消しなどで経験値を減らし、必要であればレベルも下げる
+func (c *Character) LoseExp(exp int) {
+	if exp <= 0 {
+		return
+	}
+
+	c.Exp = max(c.Exp-exp, 0)
+	for c.Level > initialLevel && c.Exp < RequiredExp(c.Level) {
+		c.Level--
+	}
+	c.HP = min(c.HP, c.MaxHP())
+}
+
+// LoseGold は既に使われた分までは取り戻せないため、0未満にはしない
+func (c *Character) LoseGold(gold int) {
+	if gold <= 0 {
+		return
+	}
+	c.Gold = max(c.Gold-gold, 0)
+}
+
+// SpendGold は所持しているゴールドが足りない場合はエラーを返し、何も減らさない
+func (c *Character) SpendGold(gold int) error {
+	if gold > c.Gold {
+		return errors.New("insufficient gold")
+	}
+	c.LoseGold(gold)
+	return nil
+}
+
+func (c *Character) ChooseClass(class CharacterClass) error {
+	if c.Class != "" {
+		return errors.New("class has already been chosen")
+	}
+	if _, err := FindClass(class); err != nil {
+		return err
+	}
+
+	c.Class = class
+	return nil
+}
+
+// Stats はクラス未選択の間は初期値のまま成長しない
+func (c Character) Stats() Stats {
+	stats := Stats{Strength: baseStat, Intellect: baseStat, Wisdom: baseStat}
+	def, err := FindClass(c.Class)
+	if err != nil {
+		return stats
+	}
+
+	growth := c.Level - initialLevel
+	stats.Strength += def.Growth.Strength * growth
+	stats.Intellect += def.Growth.Intellect * growth
+	stats.Wisdom += def.Growth.Wisdom * growth
+	return stats
+}
+
+// SkillPoints はレベルアップごとに1ずつ得たポイントから習得済みのスキルの分を引いた残り。
+// レベルが下がった場合は負になり、新たに習得できなくなる
+func (c Character) SkillPoints() int {
+	spent := 0
+	for _, key := range c.Skills {
+		if s, err := FindSkill(key); err == nil {
+			spent += s.Cost
+		}
+	}
+	return c.Level - initialLevel - spent
+}
+
+func (c Character) HasSkill(key string) bool {
+	return slices.Contains(c.Skills, key)
+}
+
+// CanLearn はスキルを習得できない場合にその理由を返す
+func (c Character) CanLearn(s Skill) error {
+	if c.Class == "" {
+		return errors.New("class must be chosen before learning skills")
+	}
+	if s.Class != "" && s.Class != c.Class {
+		return errors.New("skill is not available for the class")
+	}
+	if c.HasSkill(s.Key) {
+		return errors.New("skill has already been learned")
+	}
+	for _, req := range s.Requires {
+		if !c.HasSkill(req) {
+			return errors.Newf("skill requires %s", req)
+		}
+	}
+	if c.SkillPoints() < s.Cost {
+		return errors.New("not enough skill points")
+	}
+	return nil
+}
+
+func (c *Character) Learn(key string) (Skill, error) {
+	s, err := FindSkill(key)
+	if err != nil {
+		return Skill{}, err
+	}
+	if err := c.CanLearn(s); err != nil {
+		return Skill{}, err
+	}
+
+	c.Skills = append(c.Skills, s.Key)
+	return s, nil
+}
+
+// RewardRules は習得済みのスキルの効果をまとめて返す
+func (c Character) RewardRules() []RewardRule {
+	var rules []RewardRule
+	for _, key := range c.Skills {
+		if s, err := FindSkill(key); err == nil {
+			rules = append(rules, s.Rules...)
+		}
+	}
+	return rules
+}
+
+// MaxHP はレベルとともに伸びる力に応じて増える
+func (c Character) MaxHP() int {
+	return baseMaxHP + c.Stats().Strength*maxHPPerStrength
+}
+
+// TakeDamage はHPを減らし、0になった場合は気絶させる
+func (c *Character) TakeDamage(damage int, now time.Time) {
+	if damage <= 0 {
+		return
+	}
+
+	c.HP = max(c.HP-damage, 0)
+	if c.HP == 0 && !c.KnockedOut {
+		c.KnockedOut = true
+		c.record(KnockedOut{AccountID: c.AccountID, At: now})
+	}
+}
+
+// Heal はHPを最大HPまで回復し、気絶中であれば回復に必要なHPに達した時点で復帰させる
+func (c *Character) Heal(hp int, now time.Time) {
+	if hp <= 0 {
+		return
+	}
+
+	c.HP = min(c.HP+hp, c.MaxHP())
+	if c.KnockedOut && c.HP*100 >= c.MaxHP()*reviveHPPercent {
+		c.KnockedOut = false
+		c.record(Revived{AccountID: c.AccountID, At: now})
+	}
+}
@@ -0,0 +1,247 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	expPerCompanionFocusMinute = 10
+	maxCompanionNicknameLength = 30
+)
+
+// CompanionStage は進化の段階。MinExpに達すると進化し、段階ごとのボーナスに切り替わる
+type CompanionStage struct {
+	Name        string
+	MinExp      int
+	Description string
+	Rules       []RewardRule
+}
+
+// CompanionSpecies はドロップしたEggを孵すか、累計の集中時間がMilestoneMinutesに達すると仲間になる
+type CompanionSpecies struct {
+	Key              string
+	Name             string
+	Egg              string
+	MilestoneMinutes int
+	// Stages は進化の順に並べ、最初の段階のMinExpは0とする
+	Stages []CompanionStage
+}
+
+func companionBonus(source RewardSource, effect RewardEffect) []RewardRule {
+	return []RewardRule{{When: RewardCondition{Sources: []RewardSource{source}}, Effect: effect}}
+}
+
+// CompanionSpeciesList は仲間にできる種族の一覧。表示順に並べる
+var CompanionSpeciesList = []CompanionSpecies{
+	{
+		Key: "sprout", Name: "芽吹きの精", MilestoneMinutes: 60,
+		Stages: []CompanionStage{
+			{Name: "芽", MinExp: 0, Description: "集中の獲得経験値+2%", Rules: companionBonus(RewardSourceFocus, RewardEffect{ExpPercent: 2})},
+			{Name: "若木", MinExp: 3000, Description: "集中の獲得経験値+4%", Rules: companionBonus(RewardSourceFocus, RewardEffect{ExpPercent: 4})},
+			{Name: "大樹", MinExp: 15000, Description: "集中の獲得経験値+6%", Rules: companionBonus(RewardSourceFocus, RewardEffect{ExpPercent: 6})},
+		},
+	},
+	{
+		Key: "owl", Name: "フクロウ", MilestoneMinutes: 1500,
+		Stages: []CompanionStage{
+			{Name: "ひな", MinExp: 0, Description: "目標達成の獲得経験値+5%", Rules: companionBonus(RewardSourceGoal, RewardEffect{ExpPercent: 5})},
+			{Name: "若鳥", MinExp: 3000, Description: "目標達成の獲得経験値+10%", Rules: companionBonus(RewardSourceGoal, RewardEffect{ExpPercent: 10})},
+			{Name: "賢者の梟", MinExp: 15000, Description: "目標達成の獲得経験値+15%", Rules: companionBonus(RewardSourceGoal, RewardEffect{ExpPercent: 15})},
+		},
+	},
+	{
+		Key: "phoenix", Name: "不死鳥", Egg: "phoenix_egg",
+		Stages: []CompanionStage{
+			{Name: "火の雛", MinExp: 0, Description: "集中の獲得ゴールド+5%", Rules: companionBonus(RewardSourceFocus, RewardEffect{GoldPercent: 5})},
+			{Name: "炎の鳥", MinExp: 3000, Description: "集中の獲得ゴールド+10%", Rules: companionBonus(RewardSourceFocus, RewardEffect{GoldPercent: 10})},
+			{Name: "不死鳥", MinExp: 15000, Description: "集中の獲得ゴールド+15%", Rules: companionBonus(RewardSourceFocus, RewardEffect{GoldPercent: 15})},
+		},
+	},
+	{
+		Key: "dragon", Name: "ドラゴン", Egg: "dragon_egg",
+		Stages: []CompanionStage{
+			{Name: "幼竜", MinExp: 0, Description: "レイド勝利の獲得経験値・ゴールド+5%", Rules: companionBonus(RewardSourceRaid, RewardEffect{ExpPercent: 5, GoldPercent: 5})},
+			{Name: "飛竜", MinExp: 3000, Description: "レイド勝利の獲得経験値・ゴールド+10%", Rules: companionBonus(RewardSourceRaid, RewardEffect{ExpPercent: 10, GoldPercent: 10})},
+			{Name: "古竜", MinExp: 15000, Description: "レイド勝利の獲得経験値・ゴールド+15%", Rules: companionBonus(RewardSourceRaid, RewardEffect{ExpPercent: 15, GoldPercent: 15})},
+		},
+	},
+}
+
+func FindCompanionSpecies(key string) (CompanionSpecies, error) {
+	for _, s := range CompanionSpeciesList {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+
+	return CompanionSpecies{}, errors.Newf("unknown companion species: %s", key)
+}
+
+// FindCompanionSpeciesByEgg はitemから孵る種族を返す
+func FindCompanionSpeciesByEgg(item string) (CompanionSpecies, bool) {
+	for _, s := range CompanionSpeciesList {
+		if s.Egg != "" && s.Egg == item {
+			return s, true
+		}
+	}
+
+	return CompanionSpecies{}, false
+}
+
+// StageAt はexpで到達している段階の添字を返す
+func (s CompanionSpecies) StageAt(exp int) int {
+	stage := 0
+	for i, st := range s.Stages {
+		if exp >= st.MinExp {
+			stage = i
+		}
+	}
+	return stage
+}
+
+// Companion は種族ごとに1体まで仲間にでき、同時に連れて歩けるのは1体のみ
+type Companion struct {
+	ID         CompanionID
+	AccountID  AccountID
+	Species    string
+	Nickname   string
+	Exp        int
+	Stage      int
+	Active     bool
+	ObtainedAt time.Time
+	eventRecorder
+}
+
+// NewCompanion は連れて歩いていない状態で作る
+func NewCompanion(id CompanionID, accID AccountID, species CompanionSpecies, now time.Time) Companion {
+	c := Companion{
+		ID:         id,
+		AccountID:  accID,
+		Species:    species.Key,
+		Nickname:   species.Name,
+		ObtainedAt: now,
+	}
+	c.record(CompanionObtained{
+		CompanionID: id,
+		AccountID:   accID,
+		Species:     species.Key,
+		At:          now,
+	})
+	return c
+}
+
+func RecreateCompanion(id CompanionID, accID AccountID, species, nickname string, exp, stage int, active bool, obtainedAt time.Time) Companion {
+	return Companion{
+		ID:         id,
+		AccountID:  accID,
+		Species:    species,
+		Nickname:   nickname,
+		Exp:        exp,
+		Stage:      stage,
+		Active:     active,
+		ObtainedAt: obtainedAt,
+	}
+}
+
+func (c *Companion) Rename(nickname string) error {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" || utf8.RuneCountInString(nickname) > maxCompanionNicknameLength {
+		return errors.New("invalid companion nickname")
+	}
+
+	c.Nickname = nickname
+	return nil
+}
+
+// Train は持ち主の集中時間に応じて経験値を与え、しきい値に達していれば進化させる
+func (c *Companion) Train(minutes float64, now time.Time) error {
+	species, err := FindCompanionSpecies(c.Species)
+	if err != nil {
+		return err
+	}
+
+	c.gain(species, int(minutes)*expPerCompanionFocusMinute, now)
+	return nil
+}
+
+// Retrain は記録の修正にあわせてtの集中時間をminutesに改め、その差だけ経験値を増減させる。
+// 経験値が減った場合は段階も戻す
+func (c *Companion) Retrain(t *CompanionTraining, minutes float64, now time.Time) error {
+	if t.CompanionID != c.ID {
+		return errors.New("training is not for the companion")
+	}
+	species, err := FindCompanionSpecies(c.Species)
+	if err != nil {
+		return err
+	}
+
+	exp := (int(minutes) - int(t.Minutes)) * expPerCompanionFocusMinute
+	t.Minutes = minutes
+	if exp >= 0 {
+		c.gain(species, exp, now)
+		return nil
+	}
+
+	c.Exp = max(c.Exp+exp, 0)
+	c.Stage = min(c.Stage, species.StageAt(c.Exp))
+	return nil
+}
+
+func (c *Companion) gain(species CompanionSpecies, exp int, now time.Time) {
+	if exp <= 0 {
+		return
+	}
+	c.Exp += exp
+
+	from := c.Stage
+	c.Stage = max(species.StageAt(c.Exp), from)
+	if c.Stage > from {
+		c.record(CompanionEvolved{
+			CompanionID: c.ID,
+			AccountID:   c.AccountID,
+			Species:     c.Species,
+			From:        from,
+			To:          c.Stage,
+			At:          now,
+		})
+	}
+}
+
+// RewardRules は現在の段階のボーナスを返す
+func (c Companion) RewardRules() []RewardRule {
+	species, err := FindCompanionSpecies(c.Species)
+	if err != nil || c.Stage >= len(species.Stages) {
+		return nil
+	}
+	return species.Stages[c.Stage].Rules
+}
+
+// CompanionTraining は1回の集中記録で与えた経験値。同じ記録では連れて歩く仲間を替えても1度だけ育てる
+type CompanionTraining struct {
+	CompanionID CompanionID
+	TimeID      TimeID
+	Minutes     float64
+	CreatedAt   time.Time
+}
+
+func NewCompanionTraining(companionID CompanionID, timeID TimeID, minutes float64) CompanionTraining {
+	return CompanionTraining{
+		CompanionID: companionID,
+		TimeID:      timeID,
+		Minutes:     minutes,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func RecreateCompanionTraining(companionID CompanionID, timeID TimeID, minutes float64, createdAt time.Time) CompanionTraining {
+	return CompanionTraining{
+		CompanionID: companionID,
+		TimeID:      timeID,
+		Minutes:     minutes,
+		CreatedAt:   createdAt,
+	}
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type CompanionID string
+
+func NewCompanionID(s string) (CompanionID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid companion id")
+	}
+
+	return CompanionID(id.String()), nil
+}
+
+func GenerateCompanionID() CompanionID {
+	return CompanionID(uuid.NewString())
+}
+
+func (r CompanionID) String() string {
+	return string(r)
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// Cycle はポモドーロのサイクル(集中と短い休憩をinterval回繰り返した後の長い休憩)の進捗
+type Cycle struct {
+	AccountID       AccountID
+	FocusCount      int
+	CompletedCycles int
+	LastKind        SessionKind
+	eventRecorder
+}
+
+func NewCycle(accID AccountID) Cycle {
+	return Cycle{AccountID: accID}
+}
+
+func RecreateCycle(accID AccountID, focusCount, completedCycles int, lastKind SessionKind) Cycle {
+	return Cycle{
+		AccountID:       accID,
+		FocusCount:      focusCount,
+		CompletedCycles: completedCycles,
+		LastKind:        lastKind,
+	}
+}
+
+func (c *Cycle) RecordFocus() {
+	c.FocusCount++
+	c.LastKind = SessionFocus
+}
+
+// RecordBreak は休憩の完了を記録し、サイクルが完了した場合はtrueを返す。
+// 長い休憩でサイクルは区切られ、interval回の集中を終えていればサイクル完了とする
+func (c *Cycle) RecordBreak(kind SessionKind, interval int) bool {
+	c.LastKind = kind
+	if kind != SessionLongBreak {
+		return false
+	}
+
+	completed := c.FocusCount >= interval
+	c.FocusCount = 0
+	if completed {
+		c.CompletedCycles++
+		c.record(CycleCompleted{
+			AccountID:       c.AccountID,
+			CompletedCycles: c.CompletedCycles,
+			At:              time.Now(),
+		})
+	}
+	return completed
+}
+
+// NextKind は次に行うべきセッションの種類を返す
+func (c Cycle) NextKind(interval int) SessionKind {
+	if c.LastKind != SessionFocus {
+		return SessionFocus
+	}
+	if c.FocusCount >= interval {
+		return SessionLongBreak
+	}
+	return SessionShortBreak
+}
@@ -0,0 +1,240 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type EventName string
+
+const (
+	EventAccountCreated        EventName = "account.created"
+	EventFocusSessionCompleted EventName = "focus_session.completed"
+	EventLevelUp               EventName = "character.level_up"
+	EventBreakCompleted        EventName = "break.completed"
+	EventCycleCompleted        EventName = "cycle.completed"
+	EventGoalAchieved          EventName = "goal.achieved"
+	EventGoalRevoked           EventName = "goal.revoked"
+	EventKnockedOut            EventName = "character.knocked_out"
+	EventRevived               EventName = "character.revived"
+	EventLootDropped           EventName = "loot.dropped"
+	EventCompanionObtained     EventName = "companion.obtained"
+	EventCompanionEvolved      EventName = "companion.evolved"
+)
+
+type DomainEvent interface {
+	EventName() EventName
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+// AccountEvent は特定のアカウントに紐づくイベント
+type AccountEvent interface {
+	DomainEvent
+	OwnerID() AccountID
+}
+
+// eventRecorder は集約に埋め込み、状態変更に伴って発生したイベントを保持する
+type eventRecorder struct {
+	events []DomainEvent
+}
+
+func (r *eventRecorder) record(ev DomainEvent) {
+	r.events = append(r.events, ev)
+}
+
+// PullEvents は保持しているイベントを返し、保持分をクリアする
+func (r *eventRecorder) PullEvents() []DomainEvent {
+	events := r.events
+	r.events = nil
+	return events
+}
+
+type AccountCreated struct {
+	AccountID AccountID `json:"accountId"`
+	Email     string    `json:"email"`
+	At        time.Time `json:"occurredAt"`
+}
+
+func (e AccountCreated) EventName() EventName  { return EventAccountCreated }
+func (e AccountCreated) AggregateID() string   { return e.AccountID.String() }
+func (e AccountCreated) OccurredAt() time.Time { return e.At }
+func (e AccountCreated) OwnerID() AccountID    { return e.AccountID }
+
+type FocusSessionCompleted struct {
+	TimeID        TimeID    `json:"timeId"`
+	AccountID     AccountID `json:"accountId"`
+	TaskID        *TaskID   `json:"taskId,omitempty"`
+	FocusTime     float64   `json:"focusTime"`
+	ExecutionDate time.Time `json:"executionDate"`
+	At            time.Time `json:"occurredAt"`
+}
+
+func (e FocusSessionCompleted) EventName() EventName  { return EventFocusSessionCompleted }
+func (e FocusSessionCompleted) AggregateID() string   { return e.TimeID.String() }
+func (e FocusSessionCompleted) OccurredAt() time.Time { return e.At }
+func (e FocusSessionCompleted) OwnerID() AccountID    { return e.AccountID }
+
+type LevelUp struct {
+	AccountID AccountID `json:"accountId"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	At        time.Time `json:"occurredAt"`
+}
+
+func (e LevelUp) EventName() EventName  { return EventLevelUp }
+func (e LevelUp) AggregateID() string   { return e.AccountID.String() }
+func (e LevelUp) OccurredAt() time.Time { return e.At }
+func (e LevelUp) OwnerID() AccountID    { return e.AccountID }
+
+type BreakCompleted struct {
+	BreakID   BreakID     `json:"breakId"`
+	AccountID AccountID   `json:"accountId"`
+	Kind      SessionKind `json:"kind"`
+	Minutes   float64     `json:"minutes"`
+	At        time.Time   `json:"occurredAt"`
+}
+
+func (e BreakCompleted) EventName() EventName  { return EventBreakCompleted }
+func (e BreakCompleted) AggregateID() string   { return e.BreakID.String() }
+func (e BreakCompleted) OccurredAt() time.Time { return e.At }
+func (e BreakCompleted) OwnerID() AccountID    { return e.AccountID }
+
+type CycleCompleted struct {
+	AccountID       AccountID `json:"accountId"`
+	CompletedCycles int       `json:"completedCycles"`
+	At              time.Time `json:"occurredAt"`
+}
+
+func (e CycleCompleted) EventName() EventName  { return EventCycleCompleted }
+func (e CycleCompleted) AggregateID() string   { return e.AccountID.String() }
+func (e CycleCompleted) OccurredAt() time.Time { return e.At }
+func (e CycleCompleted) OwnerID() AccountID    { return e.AccountID }
+
+type GoalAchieved struct {
+	AccountID     AccountID  `json:"accountId"`
+	Period        GoalPeriod `json:"period"`
+	PeriodStart   time.Time  `json:"periodStart"`
+	TargetMinutes int        `json:"targetMinutes"`
+	At            time.Time  `json:"occurredAt"`
+}
+
+func (e GoalAchieved) EventName() EventName  { return EventGoalAchieved }
+func (e GoalAchieved) AggregateID() string   { return e.AccountID.String() }
+func (e GoalAchieved) OccurredAt() time.Time { return e.At }
+func (e GoalAchieved) OwnerID() AccountID    { return e.AccountID }
+
+// GoalRevoked は記録の修正で集中時間が目標を下回り、達成が取り消されたときに発生する
+type GoalRevoked struct {
+	AccountID   AccountID  `json:"accountId"`
+	Period      GoalPeriod `json:"period"`
+	PeriodStart time.Time  `json:"periodStart"`
+	At          time.Time  `json:"occurredAt"`
+}
+
+func (e GoalRevoked) EventName() EventName  { return EventGoalRevoked }
+func (e GoalRevoked) AggregateID() string   { return e.AccountID.String() }
+func (e GoalRevoked) OccurredAt() time.Time { return e.At }
+func (e GoalRevoked) OwnerID() AccountID    { return e.AccountID }
+
+type KnockedOut struct {
+	AccountID AccountID `json:"accountId"`
+	At        time.Time `json:"occurredAt"`
+}
+
+func (e KnockedOut) EventName() EventName  { return EventKnockedOut }
+func (e KnockedOut) AggregateID() string   { return e.AccountID.String() }
+func (e KnockedOut) OccurredAt() time.Time { return e.At }
+func (e KnockedOut) OwnerID() AccountID    { return e.AccountID }
+
+type Revived struct {
+	AccountID AccountID `json:"accountId"`
+	At        time.Time `json:"occurredAt"`
+}
+
+func (e Revived) EventName() EventName  { return EventRevived }
+func (e Revived) AggregateID() string   { return e.AccountID.String() }
+func (e Revived) OccurredAt() time.Time { return e.At }
+func (e Revived) OwnerID() AccountID    { return e.AccountID }
+
+type LootDropped struct {
+	DropID    LootDropID `json:"dropId"`
+	AccountID AccountID  `json:"accountId"`
+	TableKey  string     `json:"tableKey"`
+	Item      string     `json:"item"`
+	Rarity    Rarity     `json:"rarity"`
+	At        time.Time  `json:"occurredAt"`
+}
+
+func (e LootDropped) EventName() EventName  { return EventLootDropped }
+func (e LootDropped) AggregateID() string   { return e.DropID.String() }
+func (e LootDropped) OccurredAt() time.Time { return e.At }
+func (e LootDropped) OwnerID() AccountID    { return e.AccountID }
+
+type CompanionObtained struct {
+	CompanionID CompanionID `json:"companionId"`
+	AccountID   AccountID   `json:"accountId"`
+	Species     string      `json:"species"`
+	At          time.Time   `json:"occurredAt"`
+}
+
+func (e CompanionObtained) EventName() EventName  { return EventCompanionObtained }
+func (e CompanionObtained) AggregateID() string   { return e.CompanionID.String() }
+func (e CompanionObtained) OccurredAt() time.Time { return e.At }
+func (e CompanionObtained) OwnerID() AccountID    { return e.AccountID }
+
+type CompanionEvolved struct {
+	CompanionID CompanionID `json:"companionId"`
+	AccountID   AccountID   `json:"accountId"`
+	Species     string      `json:"species"`
+	From        int         `json:"from"`
+	To          int         `json:"to"`
+	At          time.Time   `json:"occurredAt"`
+}
+
+func (e CompanionEvolved) EventName() EventName  { return EventCompanionEvolved }
+func (e CompanionEvolved) AggregateID() string   { return e.CompanionID.String() }
+func (e CompanionEvolved) OccurredAt() time.Time { return e.At }
+func (e CompanionEvolved) OwnerID() AccountID    { return e.AccountID }
+
+// DecodeEvent はoutboxに保存されたペイロードをイベントに復元する
+func DecodeEvent(name EventName, payload []byte) (DomainEvent, error) {
+	switch name {
+	case EventAccountCreated:
+		return decodeEvent[AccountCreated](payload)
+	case EventFocusSessionCompleted:
+		return decodeEvent[FocusSessionCompleted](payload)
+	case EventLevelUp:
+		return decodeEvent[LevelUp](payload)
+	case EventBreakCompleted:
+		return decodeEvent[BreakCompleted](payload)
+	case EventCycleCompleted:
+		return decodeEvent[CycleCompleted](payload)
+	case EventGoalAchieved:
+		return decodeEvent[GoalAchieved](payload)
+	case EventGoalRevoked:
+		return decodeEvent[GoalRevoked](payload)
+	case EventKnockedOut:
+		return decodeEvent[KnockedOut](payload)
+	case EventRevived:
+		return decodeEvent[Revived](payload)
+	case EventLootDropped:
+		return decodeEvent[LootDropped](payload)
+	case EventCompanionObtained:
+		return decodeEvent[CompanionObtained](payload)
+	case EventCompanionEvolved:
+		return decodeEvent[CompanionEvolved](payload)
+	default:
+		return nil, errors.Newf("unknown event: %s", name)
+	}
+}
+
+func decodeEvent[T DomainEvent](payload []byte) (DomainEvent, error) {
+	var ev T
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ev, nil
+}
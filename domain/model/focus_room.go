@@ -0,0 +1,245 @@
+package model
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	maxFocusRoomParticipants = 10
+	// minFocusRoomSeconds に満たない部屋は作れない。短い部屋を繰り返して完了の報酬を稼げないようにする
+	minFocusRoomSeconds = MinFocusSessionMinutes * 60
+	focusRoomCodeLength = 6
+	// focusRoomCodeAlphabet は読み間違えやすい0/O、1/Iを除く
+	focusRoomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type FocusRoomStatus string
+
+const (
+	FocusRoomWaiting FocusRoomStatus = "waiting"
+	FocusRoomRunning FocusRoomStatus = "running"
+	FocusRoomPaused  FocusRoomStatus = "paused"
+	// FocusRoomFinished は保存せず、実行中のまま予定時間を過ぎた部屋の表示に使う
+	FocusRoomFinished FocusRoomStatus = "finished"
+	FocusRoomClosed   FocusRoomStatus = "closed"
+)
+
+// FocusRoom は複数人で同じ集中タイマーを共有する部屋。
+// ホストがタイマーを操作し、参加者はコードを入力して開始前に参加する
+type FocusRoom struct {
+	ID             FocusRoomID
+	Code           string
+	HostID         AccountID
+	Status         FocusRoomStatus
+	PlannedSeconds int
+	ElapsedSeconds int
+	StartedAt      *time.Time
+	ResumedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewFocusRoom(id FocusRoomID, hostID AccountID, plannedSeconds int, now time.Time) (FocusRoom, error) {
+	if plannedSeconds < minFocusRoomSeconds {
+		return FocusRoom{}, errors.Newf("planned seconds must be %d or more", minFocusRoomSeconds)
+	}
+
+	code, err := generateFocusRoomCode()
+	if err != nil {
+		return FocusRoom{}, err
+	}
+
+	return FocusRoom{
+		ID:             id,
+		Code:           code,
+		HostID:         hostID,
+		Status:         FocusRoomWaiting,
+		PlannedSeconds: plannedSeconds,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func RecreateFocusRoom(id FocusRoomID, code string, hostID AccountID, status FocusRoomStatus, plannedSeconds, elapsedSeconds int, startedAt, resumedAt *time.Time, createdAt, updatedAt time.Time) FocusRoom {
+	return FocusRoom{
+		ID:             id,
+		Code:           code,
+		HostID:         hostID,
+		Status:         status,
+		PlannedSeconds: plannedSeconds,
+		ElapsedSeconds: elapsedSeconds,
+		StartedAt:      startedAt,
+		ResumedAt:      resumedAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+func generateFocusRoomCode() (string, error) {
+	b := make([]byte, focusRoomCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	for i := range b {
+		b[i] = focusRoomCodeAlphabet[int(b[i])%len(focusRoomCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// NormalizeFocusRoomCode は入力されたコードの大文字小文字や前後の空白の違いを吸収する
+func NormalizeFocusRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (r FocusRoom) Elapsed(now time.Time) time.Duration {
+	elapsed := time.Duration(r.ElapsedSeconds) * time.Second
+	if r.Status == FocusRoomRunning && r.ResumedAt != nil {
+		elapsed += now.Sub(*r.ResumedAt)
+	}
+	return min(elapsed, time.Duration(r.PlannedSeconds)*time.Second)
+}
+
+func (r FocusRoom) Remaining(now time.Time) time.Duration {
+	return time.Duration(r.PlannedSeconds)*time.Second - r.Elapsed(now)
+}
+
+// StatusAt は予定時間を過ぎた実行中の部屋を終了として返す
+func (r FocusRoom) StatusAt(now time.Time) FocusRoomStatus {
+	if r.Status == FocusRoomRunning && r.Remaining(now) <= 0 {
+		return FocusRoomFinished
+	}
+	return r.Status
+}
+
+func (r *FocusRoom) Start(accID AccountID, now time.Time) error {
+	if r.HostID != accID {
+		return errors.New("only the host can operate the timer")
+	}
+	if r.Status != FocusRoomWaiting {
+		return errors.New("room has already started")
+	}
+
+	r.Status = FocusRoomRunning
+	r.StartedAt = &now
+	r.ResumedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *FocusRoom) Pause(accID AccountID, now time.Time) error {
+	if r.HostID != accID {
+		return errors.New("only the host can operate the timer")
+	}
+	if r.StatusAt(now) != FocusRoomRunning {
+		return errors.New("room is not running")
+	}
+
+	r.ElapsedSeconds = int(r.Elapsed(now).Seconds())
+	r.Status = FocusRoomPaused
+	r.ResumedAt = nil
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *FocusRoom) Resume(accID AccountID, now time.Time) error {
+	if r.HostID != accID {
+		return errors.New("only the host can operate the timer")
+	}
+	if r.Status != FocusRoomPaused {
+		return errors.New("room is not paused")
+	}
+
+	r.Status = FocusRoomRunning
+	r.ResumedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Join は開始前の部屋に参加する。participantsは現在の参加者数
+func (r FocusRoom) Join(accID AccountID, participants int, now time.Time) (FocusRoomParticipant, error) {
+	if r.Status != FocusRoomWaiting {
+		return FocusRoomParticipant{}, errors.New("room is not accepting participants")
+	}
+	if participants >= maxFocusRoomParticipants {
+		return FocusRoomParticipant{}, errors.Newf("participants are limited to %d per room", maxFocusRoomParticipants)
+	}
+
+	return FocusRoomParticipant{
+		RoomID:    r.ID,
+		AccountID: accID,
+		JoinedAt:  now,
+	}, nil
+}
+
+// Host は部屋を作成したアカウントを最初の参加者とする
+func (r FocusRoom) Host() FocusRoomParticipant {
+	return FocusRoomParticipant{
+		RoomID:    r.ID,
+		AccountID: r.HostID,
+		JoinedAt:  r.CreatedAt,
+	}
+}
+
+// Complete は参加者がタイマーを最後まで終えたことを記録し、集中した時間(分)を返す
+func (r FocusRoom) Complete(p *FocusRoomParticipant, now time.Time) (float64, error) {
+	if p.RoomID != r.ID {
+		return 0, errors.New("participant is not in the room")
+	}
+	if p.CompletedAt != nil {
+		return 0, errors.New("participant has already completed")
+	}
+	if r.Status != FocusRoomRunning && r.Status != FocusRoomPaused {
+		return 0, errors.New("room is not active")
+	}
+	if r.Remaining(now) > completeTolerance {
+		return 0, errors.New("room has not finished yet")
+	}
+
+	p.CompletedAt = &now
+	return r.Elapsed(now).Minutes(), nil
+}
+
+// Abandons は参加者が抜けることで集中を途中でやめることになるかを返す
+func (r FocusRoom) Abandons(p FocusRoomParticipant, now time.Time) bool {
+	status := r.StatusAt(now)
+	return p.CompletedAt == nil && (status == FocusRoomRunning || status == FocusRoomPaused)
+}
+
+// Leave は参加者が抜けた後の部屋の状態を更新する。
+// ホストが抜けた場合は最も早く参加した残りの参加者に引き継ぎ、誰もいなくなれば閉じる
+func (r *FocusRoom) Leave(accID AccountID, remaining []FocusRoomParticipant, now time.Time) {
+	if len(remaining) == 0 {
+		r.ElapsedSeconds = int(r.Elapsed(now).Seconds())
+		r.Status = FocusRoomClosed
+		r.ResumedAt = nil
+		r.UpdatedAt = now
+		return
+	}
+
+	if r.HostID == accID {
+		r.HostID = remaining[0].AccountID
+		r.UpdatedAt = now
+	}
+}
+
+// FocusRoomParticipant は部屋の参加者。最後まで終えた参加者にはCompletedAtを記録する
+type FocusRoomParticipant struct {
+	RoomID      FocusRoomID
+	AccountID   AccountID
+	JoinedAt    time.Time
+	CompletedAt *time.Time
+}
+
+func RecreateFocusRoomParticipant(roomID FocusRoomID, accID AccountID, joinedAt time.Time, completedAt *time.Time) FocusRoomParticipant {
+	return FocusRoomParticipant{
+		RoomID:      roomID,
+		AccountID:   accID,
+		JoinedAt:    joinedAt,
+		CompletedAt: completedAt,
+	}
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type FocusRoomID string
+
+func NewFocusRoomID(s string) (FocusRoomID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid focus room id")
+	}
+
+	return FocusRoomID(id.String()), nil
+}
+
+func GenerateFocusRoomID() FocusRoomID {
+	return FocusRoomID(uuid.NewString())
+}
+
+func (r FocusRoomID) String() string {
+	return string(r)
+}
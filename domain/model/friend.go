@@ -0,0 +1,185 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxFriendsPerAccount = 200
+
+type FriendRequestStatus string
+
+const (
+	FriendRequestPending FriendRequestStatus = "pending"
+	// FriendRequestDeclined は申請者には保留中のまま見せ、断られたことを知らせない
+	FriendRequestDeclined FriendRequestStatus = "declined"
+)
+
+// FriendRequest はメールアドレス宛てのフレンド申請。
+// 宛先のアカウントが存在しない場合やブロックされている場合もAddresseeIDをnilとして同じように記録し、
+// 申請者からはアカウントの有無が区別できないようにする
+type FriendRequest struct {
+	ID             FriendRequestID
+	RequesterID    AccountID
+	AddresseeEmail string
+	AddresseeID    *AccountID
+	Status         FriendRequestStatus
+	CreatedAt      time.Time
+}
+
+func NewFriendRequest(id FriendRequestID, requester Account, addresseeEmail string, addresseeID *AccountID) (FriendRequest, error) {
+	addresseeEmail = strings.TrimSpace(addresseeEmail)
+	if addresseeEmail == "" {
+		return FriendRequest{}, errors.New("email is required")
+	}
+	if addresseeEmail == requester.Email {
+		return FriendRequest{}, errors.New("cannot send a friend request to yourself")
+	}
+
+	return FriendRequest{
+		ID:             id,
+		RequesterID:    requester.ID,
+		AddresseeEmail: addresseeEmail,
+		AddresseeID:    addresseeID,
+		Status:         FriendRequestPending,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func RecreateFriendRequest(id FriendRequestID, requesterID AccountID, addresseeEmail string, addresseeID *AccountID, status FriendRequestStatus, createdAt time.Time) FriendRequest {
+	return FriendRequest{
+		ID:             id,
+		RequesterID:    requesterID,
+		AddresseeEmail: addresseeEmail,
+		AddresseeID:    addresseeID,
+		Status:         status,
+		CreatedAt:      createdAt,
+	}
+}
+
+// IsAddressedTo は宛先のアカウントがまだ応答していない申請かどうかを返す
+func (r FriendRequest) IsAddressedTo(accID AccountID) bool {
+	return r.AddresseeID != nil && *r.AddresseeID == accID && r.Status == FriendRequestPending
+}
+
+// Accept は承認してフレンドになる。friendsは承認する側の現在のフレンド数
+func (r *FriendRequest) Accept(accID AccountID, friends int) (Friendship, error) {
+	if !r.IsAddressedTo(accID) {
+		return Friendship{}, errors.New("friend request is not addressed to the account")
+	}
+	if friends >= maxFriendsPerAccount {
+		return Friendship{}, errors.Newf("friends are limited to %d per account", maxFriendsPerAccount)
+	}
+
+	return NewFriendship(r.RequesterID, accID), nil
+}
+
+func (r *FriendRequest) Decline(accID AccountID) error {
+	if !r.IsAddressedTo(accID) {
+		return errors.New("friend request is not addressed to the account")
+	}
+
+	r.Status = FriendRequestDeclined
+	return nil
+}
+
+// Friendship は双方向のフレンド関係
+type Friendship struct {
+	AccountID AccountID
+	FriendID  AccountID
+	CreatedAt time.Time
+}
+
+func NewFriendship(accID, friendID AccountID) Friendship {
+	return Friendship{
+		AccountID: accID,
+		FriendID:  friendID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func RecreateFriendship(accID, friendID AccountID, createdAt time.Time) Friendship {
+	return Friendship{
+		AccountID: accID,
+		FriendID:  friendID,
+		CreatedAt: createdAt,
+	}
+}
+
+// Block はブロックしたアカウントからの申請を受け付けず、フレンドとしても表示しない
+type Block struct {
+	BlockerID AccountID
+	BlockedID AccountID
+	CreatedAt time.Time
+}
+
+func NewBlock(blockerID, blockedID AccountID) (Block, error) {
+	if blockerID == blockedID {
+		return Block{}, errors.New("cannot block yourself")
+	}
+
+	return Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func RecreateBlock(blockerID, blockedID AccountID, createdAt time.Time) Block {
+	return Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: createdAt,
+	}
+}
+
+// Privacy はフレンドに公開する情報の設定。既定では全て公開する
+type Privacy struct {
+	AccountID        AccountID
+	ShareLevel       bool
+	ShareStreak      bool
+	ShareWeeklyFocus bool
+}
+
+func DefaultPrivacy(accID AccountID) Privacy {
+	return Privacy{
+		AccountID:        accID,
+		ShareLevel:       true,
+		ShareStreak:      true,
+		ShareWeeklyFocus: true,
+	}
+}
+
+func NewPrivacy(accID AccountID, shareLevel, shareStreak, shareWeeklyFocus bool) Privacy {
+	return Privacy{
+		AccountID:        accID,
+		ShareLevel:       shareLevel,
+		ShareStreak:      shareStreak,
+		ShareWeeklyFocus: shareWeeklyFocus,
+	}
+}
+
+// Streak はtodayまで(today当日に記録がなければ前日まで)連続して集中した日数を返す。
+// statsはloc基準の日付ごとの集計で、todayもloc基準の0時とする
+func Streak(stats []DailyStat, today time.Time) int {
+	focused := make(map[string]bool, len(stats))
+	for _, st := range stats {
+		if st.FocusMinutes > 0 {
+			focused[st.Date.Format(time.DateOnly)] = true
+		}
+	}
+
+	day := today
+	if !focused[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for focused[day.Format(time.DateOnly)] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
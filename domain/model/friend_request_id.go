@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type FriendRequestID string
+
+func NewFriendRequestID(s string) (FriendRequestID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid friend request id")
+	}
+
+	return FriendRequestID(id.String()), nil
+}
+
+func GenerateFriendRequestID() FriendRequestID {
+	return FriendRequestID(uuid.NewString())
+}
+
+func (f FriendRequestID) String() string {
+	return string(f)
+}
@@ -0,0 +1,201 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type GoalPeriod string
+
+const (
+	GoalDaily   GoalPeriod = "daily"
+	GoalWeekly  GoalPeriod = "weekly"
+	GoalMonthly GoalPeriod = "monthly"
+)
+
+// GoalPeriods は一覧の表示順
+var GoalPeriods = []GoalPeriod{GoalDaily, GoalWeekly, GoalMonthly}
+
+func NewGoalPeriod(s string) (GoalPeriod, error) {
+	switch p := GoalPeriod(s); p {
+	case GoalDaily, GoalWeekly, GoalMonthly:
+		return p, nil
+	default:
+		return "", errors.Newf("unsupported goal period: %s", s)
+	}
+}
+
+// Range はtを含む期間をloc基準の[start, end)で返す。週は月曜始まり
+func (p GoalPeriod) Range(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := t.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	switch p {
+	case GoalWeekly:
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	case GoalMonthly:
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// maxTargetMinutes は期間内に集中できる時間を上限とする
+func (p GoalPeriod) maxTargetMinutes() int {
+	switch p {
+	case GoalWeekly:
+		return 7 * 24 * 60
+	case GoalMonthly:
+		return 31 * 24 * 60
+	default:
+		return 24 * 60
+	}
+}
+
+// Goal は期間ごとの集中時間の目標。アカウントごとに期間の種類につき1つまで
+type Goal struct {
+	AccountID     AccountID
+	Period        GoalPeriod
+	TargetMinutes int
+	CreatedAt     time.Time
+}
+
+func NewGoal(accID AccountID, period GoalPeriod, targetMinutes int) (Goal, error) {
+	if targetMinutes < 1 || targetMinutes > period.maxTargetMinutes() {
+		return Goal{}, errors.Newf("%s target minutes must be between 1 and %d", period, period.maxTargetMinutes())
+	}
+
+	return Goal{
+		AccountID:     accID,
+		Period:        period,
+		TargetMinutes: targetMinutes,
+	}, nil
+}
+
+func RecreateGoal(accID AccountID, period GoalPeriod, targetMinutes int, createdAt time.Time) Goal {
+	return Goal{
+		AccountID:     accID,
+		Period:        period,
+		TargetMinutes: targetMinutes,
+		CreatedAt:     createdAt,
+	}
+}
+
+// Covers は期間の開始時点で目標が設定されていたかを返す。途中で設定した期間は未達成として扱わない
+func (g Goal) Covers(start time.Time) bool {
+	return !g.CreatedAt.After(start)
+}
+
+// GoalProgress はある期間における目標の進捗
+type GoalProgress struct {
+	Goal         Goal
+	Start        time.Time
+	End          time.Time
+	FocusMinutes float64
+}
+
+func (p GoalProgress) Achieved() bool {
+	return p.FocusMinutes >= float64(p.Goal.TargetMinutes)
+}
+
+// Achieve は目標を達成していれば達成記録を返す
+func (p GoalProgress) Achieve(now time.Time) (GoalAchievement, error) {
+	if !p.Achieved() {
+		return GoalAchievement{}, errors.Newf("%s goal is not achieved yet", p.Goal.Period)
+	}
+
+	a := GoalAchievement{
+		AccountID:     p.Goal.AccountID,
+		Period:        p.Goal.Period,
+		PeriodStart:   p.Start,
+		TargetMinutes: p.Goal.TargetMinutes,
+		FocusMinutes:  p.FocusMinutes,
+		AchievedAt:    now,
+	}
+	a.record(GoalAchieved{
+		AccountID:     a.AccountID,
+		Period:        a.Period,
+		PeriodStart:   a.PeriodStart,
+		TargetMinutes: a.TargetMinutes,
+		At:            now,
+	})
+
+	return a, nil
+}
+
+// Miss は期間を終えて目標を達成できなかった場合に未達成の記録を返す
+func (p GoalProgress) Miss(now time.Time) (GoalMiss, error) {
+	if p.Achieved() {
+		return GoalMiss{}, errors.Newf("%s goal is achieved", p.Goal.Period)
+	}
+	if now.Before(p.End) {
+		return GoalMiss{}, errors.Newf("%s goal period has not ended yet", p.Goal.Period)
+	}
+
+	return GoalMiss{
+		AccountID:     p.Goal.AccountID,
+		Period:        p.Goal.Period,
+		PeriodStart:   p.Start,
+		TargetMinutes: p.Goal.TargetMinutes,
+		FocusMinutes:  p.FocusMinutes,
+		CreatedAt:     now,
+	}, nil
+}
+
+// GoalMiss は期間ごとに1回だけ記録され、未達成によるダメージの二重適用を防ぐ
+type GoalMiss struct {
+	AccountID     AccountID
+	Period        GoalPeriod
+	PeriodStart   time.Time
+	TargetMinutes int
+	FocusMinutes  float64
+	CreatedAt     time.Time
+}
+
+// GoalAchievement は期間ごとに1回だけ記録され、報酬の二重付与を防ぐ
+type GoalAchievement struct {
+	AccountID     AccountID
+	Period        GoalPeriod
+	PeriodStart   time.Time
+	TargetMinutes int
+	FocusMinutes  float64
+	AchievedAt    time.Time
+	eventRecorder
+}
+
+func RecreateGoalAchievement(accID AccountID, period GoalPeriod, periodStart time.Time, targetMinutes int, focusMinutes float64, achievedAt time.Time) GoalAchievement {
+	return GoalAchievement{
+		AccountID:     accID,
+		Period:        period,
+		PeriodStart:   periodStart,
+		TargetMinutes: targetMinutes,
+		FocusMinutes:  focusMinutes,
+		AchievedAt:    achievedAt,
+	}
+}
+
+// Key は達成の報酬を台帳で取り消せるよう、アカウント内で期間ごとに一意なキーを返す
+func (a GoalAchievement) Key() string {
+	return "goal:" + string(a.Period) + ":" + a.PeriodStart.Format(time.DateOnly)
+}
+
+// Revoke は記録の修正で集中時間が目標を下回った場合に達成を取り消す
+func (a *GoalAchievement) Revoke(p GoalProgress, now time.Time) error {
+	if p.Goal.Period != a.Period || p.Start.Format(time.DateOnly) != a.PeriodStart.Format(time.DateOnly) {
+		return errors.New("goal progress does not match the achievement")
+	}
+	if p.Achieved() {
+		return errors.Newf("%s goal is still achieved", a.Period)
+	}
+
+	a.record(GoalRevoked{
+		AccountID:   a.AccountID,
+		Period:      a.Period,
+		PeriodStart: a.PeriodStart,
+		At:          now,
+	})
+	return nil
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	maxGuildMembers           = 30
+	maxGuildNameLength        = 50
+	maxGuildDescriptionLength = 500
+)
+
+type GuildRole string
+
+const (
+	GuildLeader  GuildRole = "leader"
+	GuildOfficer GuildRole = "officer"
+	GuildMember  GuildRole = "member"
+)
+
+func NewGuildRole(s string) (GuildRole, error) {
+	switch r := GuildRole(s); r {
+	case GuildLeader, GuildOfficer, GuildMember:
+		return r, nil
+	default:
+		return "", errors.Newf("unsupported guild role: %s", s)
+	}
+}
+
+// rank は権限の強さ。大きいほど強い
+func (r GuildRole) rank() int {
+	switch r {
+	case GuildLeader:
+		return 2
+	case GuildOfficer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type Guild struct {
+	ID          GuildID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+func NewGuild(id GuildID, name, description string) (Guild, error) {
+	g := Guild{
+		ID:        id,
+		CreatedAt: time.Now(),
+	}
+	if err := g.Update(name, description); err != nil {
+		return Guild{}, err
+	}
+
+	return g, nil
+}
+
+func RecreateGuild(id GuildID, name, description string, createdAt time.Time) Guild {
+	return Guild{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		CreatedAt:   createdAt,
+	}
+}
+
+func (g *Guild) Update(name, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > maxGuildNameLength {
+		return errors.Newf("name must be at most %d characters", maxGuildNameLength)
+	}
+	if utf8.RuneCountInString(description) > maxGuildDescriptionLength {
+		return errors.Newf("description must be at most %d characters", maxGuildDescriptionLength)
+	}
+
+	g.Name = name
+	g.Description = description
+	return nil
+}
+
+// Join はメンバーとして加入する。membersはギルドの現在のメンバー数
+func (g Guild) Join(accID AccountID, members int) (GuildMembership, error) {
+	if members >= maxGuildMembers {
+		return GuildMembership{}, errors.Newf("guild members are limited to %d", maxGuildMembers)
+	}
+
+	return newGuildMembership(g.ID, accID, GuildMember), nil
+}
+
+// Found はギルドを作成したアカウントをリーダーとして加入させる
+func (g Guild) Found(accID AccountID) GuildMembership {
+	return newGuildMembership(g.ID, accID, GuildLeader)
+}
+
+// GuildListing は検索結果に表示するギルドとメンバー数
+type GuildListing struct {
+	Guild
+	Members int
+}
+
+// GuildMembership はアカウントのギルドへの所属。アカウントは1つのギルドにのみ所属できる
+type GuildMembership struct {
+	GuildID   GuildID
+	AccountID AccountID
+	Role      GuildRole
+	JoinedAt  time.Time
+}
+
+func newGuildMembership(guildID GuildID, accID AccountID, role GuildRole) GuildMembership {
+	return GuildMembership{
+		GuildID:   guildID,
+		AccountID: accID,
+		Role:      role,
+		JoinedAt:  time.Now(),
+	}
+}
+
+func RecreateGuildMembership(guildID GuildID, accID AccountID, role GuildRole, joinedAt time.Time) GuildMembership {
+	return GuildMembership{
+		GuildID:   guildID,
+		AccountID: accID,
+		Role:      role,
+		JoinedAt:  joinedAt,
+	}
+}
+
+func (m GuildMembership) IsLeader() bool {
+	return m.Role == GuildLeader
+}
+
+// CanManage はギルド情報の編集やレイドの開始ができるかを返す
+func (m GuildMembership) CanManage() bool {
+	return m.Role.rank() >= GuildOfficer.rank()
+}
+
+// Kick はtargetをギルドから外せるかを判定する。自分より権限の弱いメンバーのみ外せる
+func (m GuildMembership) Kick(target GuildMembership) error {
+	if m.GuildID != target.GuildID {
+		return errors.New("target is not a member of the guild")
+	}
+	if !m.CanManage() || m.Role.rank() <= target.Role.rank() {
+		return errors.New("not allowed to kick the member")
+	}
+
+	return nil
+}
+
+// Assign はtargetの役割を変更する。リーダーのみ実行でき、リーダーを譲った場合は自分が副リーダーになる
+func (m *GuildMembership) Assign(target *GuildMembership, role GuildRole) error {
+	if m.GuildID != target.GuildID {
+		return errors.New("target is not a member of the guild")
+	}
+	if !m.IsLeader() {
+		return errors.New("only the leader can change roles")
+	}
+	if m.AccountID == target.AccountID {
+		return errors.New("cannot change your own role")
+	}
+
+	target.Role = role
+	if role == GuildLeader {
+		m.Role = GuildOfficer
+	}
+	return nil
+}
+
+// Leave はギルドから抜けられるかを判定し、ギルドを解散する必要があるかを返す。
+// リーダーは他のメンバーがいる間はリーダーを譲るまで抜けられず、最後の1人であればギルドを解散する
+func (m GuildMembership) Leave(members int) (bool, error) {
+	if !m.IsLeader() {
+		return false, nil
+	}
+	if members > 1 {
+		return false, errors.New("leader must hand over the role before leaving")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type GuildID string
+
+func NewGuildID(s string) (GuildID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid guild id")
+	}
+
+	return GuildID(id.String()), nil
+}
+
+func GenerateGuildID() GuildID {
+	return GuildID(uuid.NewString())
+}
+
+func (g GuildID) String() string {
+	return string(g)
+}
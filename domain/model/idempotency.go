@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyRecord は同じIdempotency-Keyで再送されたリクエストに最初のレスポンスを返すための記録。
+// ScopeはKeyの衝突を避けるため、利用者とリクエスト先を組み合わせた値とする
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+func NewIdempotencyRecord(scope, key, requestHash string, now time.Time, ttl time.Duration) (IdempotencyRecord, error) {
+	if key == "" {
+		return IdempotencyRecord{}, errors.New("idempotency key is required")
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return IdempotencyRecord{}, errors.Newf("idempotency key must be %d bytes or less", maxIdempotencyKeyLength)
+	}
+
+	return IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+func RecreateIdempotencyRecord(scope, key, requestHash string, statusCode int, body []byte, completedAt *time.Time, expiresAt time.Time) IdempotencyRecord {
+	return IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		StatusCode:  statusCode,
+		Body:        body,
+		CompletedAt: completedAt,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (r *IdempotencyRecord) Complete(statusCode int, body []byte, now time.Time) {
+	r.StatusCode = statusCode
+	r.Body = body
+	r.CompletedAt = &now
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.CompletedAt != nil
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardFocus LeaderboardMetric = "focus"
+	LeaderboardXP    LeaderboardMetric = "xp"
+)
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardWeek    LeaderboardPeriod = "week"
+	LeaderboardMonth   LeaderboardPeriod = "month"
+	LeaderboardAllTime LeaderboardPeriod = "all"
+)
+
+type LeaderboardScope string
+
+const (
+	LeaderboardGlobal  LeaderboardScope = "global"
+	LeaderboardFriends LeaderboardScope = "friends"
+)
+
+// leaderboardEpoch は全期間のランキングの期間の開始日として扱う
+var leaderboardEpoch = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Leaderboard は指標と期間の組み合わせ。利用者ごとにタイムゾーンが異なるため、期間の区切りはUTCで判定する
+type Leaderboard struct {
+	Metric LeaderboardMetric
+	Period LeaderboardPeriod
+}
+
+// Leaderboards は定期的にスナップショットを更新するランキング
+var Leaderboards = []Leaderboard{
+	{LeaderboardFocus, LeaderboardWeek},
+	{LeaderboardFocus, LeaderboardMonth},
+	{LeaderboardFocus, LeaderboardAllTime},
+	{LeaderboardXP, LeaderboardWeek},
+	{LeaderboardXP, LeaderboardMonth},
+	{LeaderboardXP, LeaderboardAllTime},
+}
+
+func NewLeaderboard(metric, period string) (Leaderboard, error) {
+	m := LeaderboardMetric(metric)
+	if m != LeaderboardFocus && m != LeaderboardXP {
+		return Leaderboard{}, errors.Newf("unsupported leaderboard metric: %s", metric)
+	}
+
+	p := LeaderboardPeriod(period)
+	if p != LeaderboardWeek && p != LeaderboardMonth && p != LeaderboardAllTime {
+		return Leaderboard{}, errors.Newf("unsupported leaderboard period: %s", period)
+	}
+
+	return Leaderboard{Metric: m, Period: p}, nil
+}
+
+func NewLeaderboardScope(s string) (LeaderboardScope, error) {
+	switch scope := LeaderboardScope(s); scope {
+	case LeaderboardGlobal, LeaderboardFriends:
+		return scope, nil
+	default:
+		return "", errors.Newf("unsupported leaderboard scope: %s", s)
+	}
+}
+
+// Key はスナップショットを区別するための値
+func (l Leaderboard) Key() string {
+	return string(l.Metric) + ":" + string(l.Period)
+}
+
+// Range はtを含む期間を[start, end)で返す。全期間の場合、endはゼロ値
+func (l Leaderboard) Range(t time.Time) (time.Time, time.Time) {
+	switch l.Period {
+	case LeaderboardWeek:
+		return GoalWeekly.Range(t, time.UTC)
+	case LeaderboardMonth:
+		return GoalMonthly.Range(t, time.UTC)
+	default:
+		return leaderboardEpoch, time.Time{}
+	}
+}
+
+// Previous は直前の期間に含まれる日時を返す。全期間の場合はfalseを返す
+func (l Leaderboard) Previous(t time.Time) (time.Time, bool) {
+	if l.Period == LeaderboardAllTime {
+		return time.Time{}, false
+	}
+
+	start, _ := l.Range(t)
+	return start.Add(-time.Nanosecond), true
+}
+
+// LeaderboardEntry はスナップショット時点の順位。同点は同じ順位とし、次の順位は人数分飛ばす
+type LeaderboardEntry struct {
+	AccountID AccountID
+	Name      string
+	Image     string
+	Score     float64
+	Rank      int
+}
+
+// RankAmong はスコアの降順に並んだ一部のアカウントの中で、順位を付け直す
+func RankAmong(entries []LeaderboardEntry) []LeaderboardEntry {
+	res := make([]LeaderboardEntry, len(entries))
+	for i, e := range entries {
+		e.Rank = i + 1
+		if i > 0 && e.Score == res[i-1].Score {
+			e.Rank = res[i-1].Rank
+		}
+		res[i] = e
+	}
+	return res
+}
@@ -0,0 +1,352 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	mrand "math/rand/v2"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// lootSeedStream はPCGの2つ目のシードとして固定し、ドロップごとに保存するシードを1つにする
+const lootSeedStream = 0x9e3779b97f4a7c15
+
+type Rarity string
+
+const (
+	RarityCommon    Rarity = "common"
+	RarityUncommon  Rarity = "uncommon"
+	RarityRare      Rarity = "rare"
+	RarityEpic      Rarity = "epic"
+	RarityLegendary Rarity = "legendary"
+)
+
+var rarityRanks = map[Rarity]int{
+	RarityCommon:    0,
+	RarityUncommon:  1,
+	RarityRare:      2,
+	RarityEpic:      3,
+	RarityLegendary: 4,
+}
+
+func (r Rarity) Valid() bool {
+	_, ok := rarityRanks[r]
+	return ok
+}
+
+// AtLeast はrがother以上のレアリティかを返す
+func (r Rarity) AtLeast(other Rarity) bool {
+	return rarityRanks[r] >= rarityRanks[other]
+}
+
+type LootEntry struct {
+	Item   string
+	Name   string
+	Rarity Rarity
+	Weight int
+}
+
+// LootPityRule はAfter回続けてRarity以上が出なかった場合、次の抽選をRarity以上のアイテムに限定する
+type LootPityRule struct {
+	Rarity Rarity
+	After  int
+}
+
+// LootTable はSourceの報酬を得るたびに1回抽選するドロップテーブル。
+// Versionは内容から求めるため、同じバージョンであれば同じシードから同じ結果が得られる
+type LootTable struct {
+	Key     string
+	Name    string
+	Source  RewardSource
+	Entries []LootEntry
+	Pity    []LootPityRule
+	Version string
+}
+
+func NewLootTable(key, name string, source RewardSource, entries []LootEntry, pity []LootPityRule) (LootTable, error) {
+	t := LootTable{
+		Key:     key,
+		Name:    name,
+		Source:  source,
+		Entries: entries,
+		Pity:    pity,
+	}
+	if err := t.validate(); err != nil {
+		return LootTable{}, errors.Wrapf(err, "loot table %s", key)
+	}
+
+	version, err := t.fingerprint()
+	if err != nil {
+		return LootTable{}, err
+	}
+	t.Version = version
+	return t, nil
+}
+
+func (t LootTable) validate() error {
+	if t.Key == "" {
+		return errors.New("key is required")
+	}
+	if len(t.Entries) == 0 {
+		return errors.New("entries are required")
+	}
+
+	items := make(map[string]struct{}, len(t.Entries))
+	for _, e := range t.Entries {
+		if e.Item == "" {
+			return errors.New("item is required")
+		}
+		if _, ok := items[e.Item]; ok {
+			return errors.Newf("duplicate item: %s", e.Item)
+		}
+		items[e.Item] = struct{}{}
+		if !e.Rarity.Valid() {
+			return errors.Newf("invalid rarity: %s", e.Rarity)
+		}
+		if e.Weight <= 0 {
+			return errors.Newf("weight of %s must be greater than 0", e.Item)
+		}
+	}
+
+	for _, p := range t.Pity {
+		if !p.Rarity.Valid() {
+			return errors.Newf("invalid pity rarity: %s", p.Rarity)
+		}
+		if p.After <= 0 {
+			return errors.New("pity threshold must be greater than 0")
+		}
+		if len(t.candidates(p.Rarity)) == 0 {
+			return errors.Newf("no entries for pity rarity: %s", p.Rarity)
+		}
+	}
+	return nil
+}
+
+func (t LootTable) fingerprint() (string, error) {
+	b, err := json.Marshal(struct {
+		Key     string
+		Source  RewardSource
+		Entries []LootEntry
+		Pity    []LootPityRule
+	}{t.Key, t.Source, t.Entries, t.Pity})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func (t LootTable) candidates(floor Rarity) []LootEntry {
+	var res []LootEntry
+	for _, e := range t.Entries {
+		if e.Rarity.AtLeast(floor) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// Rates は天井を考慮しない各アイテムのドロップ率を返す
+func (t LootTable) Rates() map[string]float64 {
+	total := 0
+	for _, e := range t.Entries {
+		total += e.Weight
+	}
+
+	res := make(map[string]float64, len(t.Entries))
+	for _, e := range t.Entries {
+		res[e.Item] = float64(e.Weight) / float64(total)
+	}
+	return res
+}
+
+// Roll はpityの状態とseedから1つのアイテムを選び、更新後の天井カウンターとともに返す。
+// 同じテーブル・状態・シードからは常に同じ結果になる
+func (t LootTable) Roll(pity LootPity, seed uint64) (LootEntry, LootPity) {
+	floor := RarityCommon
+	for _, p := range t.Pity {
+		if pity.Misses[p.Rarity]+1 >= p.After && p.Rarity.AtLeast(floor) {
+			floor = p.Rarity
+		}
+	}
+
+	candidates := t.candidates(floor)
+	total := 0
+	for _, e := range candidates {
+		total += e.Weight
+	}
+
+	src := mrand.NewPCG(seed, lootSeedStream)
+	n := uniformUint64(src, uint64(total))
+
+	picked := candidates[len(candidates)-1]
+	for _, e := range candidates {
+		if n < uint64(e.Weight) {
+			picked = e
+			break
+		}
+		n -= uint64(e.Weight)
+	}
+
+	return picked, t.advance(pity, picked.Rarity)
+}
+
+// Revert はdropの抽選がなかったものとして天井カウンターを求め直す。
+// laterにはdropより後に同じテーブルで抽選したドロップを古い順に渡す
+func (t LootTable) Revert(drop LootDrop, later []LootDrop) LootPity {
+	pity := RecreateLootPity(drop.AccountID, t.Key, drop.PityBefore)
+	for _, d := range later {
+		pity = t.advance(pity, d.Rarity)
+	}
+	return pity
+}
+
+func (t LootTable) advance(pity LootPity, picked Rarity) LootPity {
+	next := pity.clone()
+	for _, p := range t.Pity {
+		if picked.AtLeast(p.Rarity) {
+			next.Misses[p.Rarity] = 0
+		} else {
+			next.Misses[p.Rarity]++
+		}
+	}
+	return next
+}
+
+// uniformUint64 は偏りが出ないよう範囲外の値を捨てて[0, n)の値を返す
+func uniformUint64(src mrand.Source, n uint64) uint64 {
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := src.Uint64(); v < limit {
+			return v % n
+		}
+	}
+}
+
+func GenerateLootSeed() (uint64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// LootPity はアカウントごと・テーブルごとの天井カウンター。Missesは天井のレアリティごとの連続で外れた回数
+type LootPity struct {
+	AccountID AccountID
+	TableKey  string
+	Misses    map[Rarity]int
+}
+
+func NewLootPity(accID AccountID, tableKey string) LootPity {
+	return LootPity{
+		AccountID: accID,
+		TableKey:  tableKey,
+		Misses:    map[Rarity]int{},
+	}
+}
+
+func RecreateLootPity(accID AccountID, tableKey string, misses map[Rarity]int) LootPity {
+	if misses == nil {
+		misses = map[Rarity]int{}
+	}
+	return LootPity{
+		AccountID: accID,
+		TableKey:  tableKey,
+		Misses:    misses,
+	}
+}
+
+func (p LootPity) clone() LootPity {
+	misses := make(map[Rarity]int, len(p.Misses))
+	for k, v := range p.Misses {
+		misses[k] = v
+	}
+	return LootPity{
+		AccountID: p.AccountID,
+		TableKey:  p.TableKey,
+		Misses:    misses,
+	}
+}
+
+// LootDrop は1回の抽選結果。シードと抽選前の天井カウンターを保存し、後から同じ結果を再現できるようにする
+type LootDrop struct {
+	ID        LootDropID
+	AccountID AccountID
+	// SourceID は抽選のきっかけになった報酬の元。集中の記録であれば記録のID
+	SourceID     string
+	TableKey     string
+	TableVersion string
+	Seed         uint64
+	PityBefore   map[Rarity]int
+	Item         string
+	ItemName     string
+	Rarity       Rarity
+	CreatedAt    time.Time
+	eventRecorder
+}
+
+func NewLootDrop(id LootDropID, t LootTable, pityBefore LootPity, seed uint64, entry LootEntry, sourceID string) LootDrop {
+	d := LootDrop{
+		ID:           id,
+		AccountID:    pityBefore.AccountID,
+		SourceID:     sourceID,
+		TableKey:     t.Key,
+		TableVersion: t.Version,
+		Seed:         seed,
+		PityBefore:   pityBefore.clone().Misses,
+		Item:         entry.Item,
+		ItemName:     entry.Name,
+		Rarity:       entry.Rarity,
+		CreatedAt:    time.Now(),
+	}
+	d.record(LootDropped{
+		DropID:    id,
+		AccountID: d.AccountID,
+		TableKey:  d.TableKey,
+		Item:      d.Item,
+		Rarity:    d.Rarity,
+		At:        d.CreatedAt,
+	})
+	return d
+}
+
+func RecreateLootDrop(id LootDropID, accID AccountID, sourceID, tableKey, tableVersion string, seed uint64, pityBefore map[Rarity]int, item, itemName string, rarity Rarity, createdAt time.Time) LootDrop {
+	return LootDrop{
+		ID:           id,
+		AccountID:    accID,
+		SourceID:     sourceID,
+		TableKey:     tableKey,
+		TableVersion: tableVersion,
+		Seed:         seed,
+		PityBefore:   pityBefore,
+		Item:         item,
+		ItemName:     itemName,
+		Rarity:       rarity,
+		CreatedAt:    createdAt,
+	}
+}
+
+// Replay は抽選時と同じバージョンのテーブルで抽選をやり直す
+func (d LootDrop) Replay(t LootTable) (LootEntry, error) {
+	if t.Key != d.TableKey || t.Version != d.TableVersion {
+		return LootEntry{}, errors.New("loot table version does not match")
+	}
+
+	entry, _ := t.Roll(RecreateLootPity(d.AccountID, d.TableKey, d.PityBefore), d.Seed)
+	return entry, nil
+}
+
+// InventoryItem はドロップで得たアイテムの所持数
+type InventoryItem struct {
+	Item   string
+	Name   string
+	Rarity Rarity
+	Count  int
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type LootDropID string
+
+func NewLootDropID(s string) (LootDropID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid loot drop id")
+	}
+
+	return LootDropID(id.String()), nil
+}
+
+func GenerateLootDropID() LootDropID {
+	return LootDropID(uuid.NewString())
+}
+
+func (r LootDropID) String() string {
+	return string(r)
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"math"
+	mrand "math/rand/v2"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+const (
+	testAccountID  AccountID  = "0b7e3f5e-6a4e-4d8f-9a2b-1c3d5e7f9a0b"
+	testLootDropID LootDropID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+)
+
+func newTestLootTable(t *testing.T) LootTable {
+	t.Helper()
+
+	table, err := NewLootTable("test", "テスト", RewardSourceFocus, []LootEntry{
+		{Item: "herb", Name: "薬草", Rarity: RarityCommon, Weight: 550},
+		{Item: "wooden_charm", Name: "木のお守り", Rarity: RarityCommon, Weight: 250},
+		{Item: "silver_feather", Name: "銀の羽根", Rarity: RarityUncommon, Weight: 130},
+		{Item: "focus_crystal", Name: "集中の結晶", Rarity: RarityRare, Weight: 50},
+		{Item: "hourglass_of_sage", Name: "賢者の砂時計", Rarity: RarityEpic, Weight: 17},
+		{Item: "phoenix_egg", Name: "不死鳥の卵", Rarity: RarityLegendary, Weight: 3},
+	}, []LootPityRule{
+		{Rarity: RarityRare, After: 20},
+		{Rarity: RarityLegendary, After: 200},
+	})
+	if err != nil {
+		t.Fatalf("NewLootTable() error = %v", err)
+	}
+	return table
+}
+
+// 天井が発動しない状態で多数のシードから抽選すると、各アイテムの出現率はRatesに収束する
+func TestLootTableRollConvergesToRates(t *testing.T) {
+	table := newTestLootTable(t)
+	const n = 200000
+
+	seeds := mrand.New(mrand.NewPCG(1, 2))
+	counts := map[string]int{}
+	for range n {
+		entry, _ := table.Roll(NewLootPity(testAccountID, table.Key), seeds.Uint64())
+		counts[entry.Item]++
+	}
+
+	for item, rate := range table.Rates() {
+		got := float64(counts[item]) / n
+		// 二項分布の標準偏差の5倍を許容誤差とする
+		tolerance := 5 * math.Sqrt(rate*(1-rate)/n)
+		if math.Abs(got-rate) > tolerance {
+			t.Errorf("rate of %s = %.5f, want %.5f ± %.5f", item, got, rate, tolerance)
+		}
+	}
+}
+
+// 連続して抽選すると、天井のレアリティ以上のアイテムは必ずAfter回以内に出る
+func TestLootTableRollGuaranteesPityFloor(t *testing.T) {
+	table := newTestLootTable(t)
+
+	seeds := mrand.New(mrand.NewPCG(3, 4))
+	for account := range 50 {
+		pity := NewLootPity(testAccountID, table.Key)
+		misses := map[Rarity]int{}
+		for range 2000 {
+			entry, next := table.Roll(pity, seeds.Uint64())
+			for _, p := range table.Pity {
+				if entry.Rarity.AtLeast(p.Rarity) {
+					misses[p.Rarity] = 0
+					continue
+				}
+				misses[p.Rarity]++
+				if misses[p.Rarity] >= p.After {
+					t.Fatalf("account %d: no %s within %d rolls", account, p.Rarity, p.After)
+				}
+			}
+			if !reflect.DeepEqual(next.Misses, misses) {
+				t.Fatalf("account %d: pity = %v, want %v", account, next.Misses, misses)
+			}
+			pity = next
+		}
+	}
+}
+
+// 天井の直前の状態からは、どのシードでも天井のレアリティ以上が出てカウンターが戻る
+func TestLootTableRollAtPityThreshold(t *testing.T) {
+	table := newTestLootTable(t)
+
+	for _, p := range table.Pity {
+		f := func(seed uint64) bool {
+			pity := RecreateLootPity(testAccountID, table.Key, map[Rarity]int{p.Rarity: p.After - 1})
+			entry, next := table.Roll(pity, seed)
+			return entry.Rarity.AtLeast(p.Rarity) && next.Misses[p.Rarity] == 0
+		}
+		if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+			t.Errorf("pity %s: %v", p.Rarity, err)
+		}
+	}
+}
+
+// 同じバージョンのテーブル・天井カウンター・シードからは常に同じ結果になり、Replayで再現できる
+func TestLootTableRollIsDeterministic(t *testing.T) {
+	table := newTestLootTable(t)
+
+	f := func(seed uint64, rareMisses, legendaryMisses uint8) bool {
+		misses := map[Rarity]int{
+			RarityRare:      int(rareMisses) % 20,
+			RarityLegendary: int(legendaryMisses) % 200,
+		}
+		pity := RecreateLootPity(testAccountID, table.Key, misses)
+		before := pity.clone().Misses
+
+		first, firstNext := table.Roll(pity, seed)
+		second, secondNext := table.Roll(pity, seed)
+		if first != second || !reflect.DeepEqual(firstNext, secondNext) {
+			return false
+		}
+		// 抽選前のカウンターは変更しない
+		if !reflect.DeepEqual(pity.Misses, before) {
+			return false
+		}
+
+		drop := NewLootDrop(testLootDropID, table, pity, seed, first, "")
+		replayed, err := drop.Replay(newTestLootTable(t))
+		return err == nil && replayed == first
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLootDropReplayRejectsOtherVersion(t *testing.T) {
+	table := newTestLootTable(t)
+	entry, _ := table.Roll(NewLootPity(testAccountID, table.Key), 42)
+	drop := NewLootDrop(testLootDropID, table, NewLootPity(testAccountID, table.Key), 42, entry, "")
+
+	entries := append([]LootEntry{}, table.Entries...)
+	entries[0].Weight++
+	changed, err := NewLootTable(table.Key, table.Name, table.Source, entries, table.Pity)
+	if err != nil {
+		t.Fatalf("NewLootTable() error = %v", err)
+	}
+
+	if _, err := drop.Replay(changed); err == nil {
+		t.Fatal("Replay() with another version should fail")
+	}
+}
+
+// 取り消したドロップの抽選がなかったものとして、以降のドロップのレアリティから天井カウンターを求め直す
+func TestLootTableRevert(t *testing.T) {
+	table := newTestLootTable(t)
+	common := LootEntry{Item: "herb", Rarity: RarityCommon}
+	rare := LootEntry{Item: "focus_crystal", Rarity: RarityRare}
+
+	before := RecreateLootPity(testAccountID, table.Key, map[Rarity]int{RarityRare: 5, RarityLegendary: 50})
+	voided := NewLootDrop(testLootDropID, table, before, 1, rare, "time")
+	later := []LootDrop{
+		NewLootDrop(testLootDropID, table, NewLootPity(testAccountID, table.Key), 2, common, "other"),
+		NewLootDrop(testLootDropID, table, NewLootPity(testAccountID, table.Key), 3, common, "other"),
+	}
+
+	got := table.Revert(voided, later)
+	want := map[Rarity]int{RarityRare: 7, RarityLegendary: 52}
+	if !reflect.DeepEqual(got.Misses, want) {
+		t.Errorf("Revert() = %v, want %v", got.Misses, want)
+	}
+
+	// 最後のドロップを取り消すと抽選前のカウンターに戻る
+	if got := table.Revert(voided, nil); !reflect.DeepEqual(got.Misses, before.Misses) {
+		t.Errorf("Revert() = %v, want %v", got.Misses, before.Misses)
+	}
+}
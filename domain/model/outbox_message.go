@@ -0,0 +1,68 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	OutboxMaxAttempts = 10
+	outboxBaseBackoff = 5 * time.Second
+	outboxMaxBackoff  = time.Hour
+)
+
+type OutboxMessage struct {
+	ID            string
+	EventName     EventName
+	AggregateID   string
+	Payload       []byte
+	OccurredAt    time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+func NewOutboxMessage(id string, ev DomainEvent) (OutboxMessage, error) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return OutboxMessage{}, errors.WithStack(err)
+	}
+
+	return OutboxMessage{
+		ID:            id,
+		EventName:     ev.EventName(),
+		AggregateID:   ev.AggregateID(),
+		Payload:       payload,
+		OccurredAt:    ev.OccurredAt(),
+		NextAttemptAt: ev.OccurredAt(),
+	}, nil
+}
+
+func (m OutboxMessage) Event() (DomainEvent, error) {
+	return DecodeEvent(m.EventName, m.Payload)
+}
+
+// Fail は配信失敗を記録し、指数バックオフで次回の配信時刻を決める
+func (m *OutboxMessage) Fail(err error, now time.Time) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = now.Add(Backoff(m.Attempts, outboxBaseBackoff, outboxMaxBackoff))
+}
+
+func (m OutboxMessage) IsDead() bool {
+	return m.Attempts >= OutboxMaxAttempts
+}
+
+// Backoff はattempts回目の失敗後の待機時間を返す
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxProjectNameLength = 100
+
+type Project struct {
+	ID        ProjectID
+	AccountID AccountID
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewProject(id ProjectID, accID AccountID, name string) (Project, error) {
+	p := Project{
+		ID:        id,
+		AccountID: accID,
+		CreatedAt: time.Now(),
+	}
+	if err := p.Rename(name); err != nil {
+		return Project{}, err
+	}
+
+	return p, nil
+}
+
+func RecreateProject(id ProjectID, accID AccountID, name string, createdAt time.Time) Project {
+	return Project{
+		ID:        id,
+		AccountID: accID,
+		Name:      name,
+		CreatedAt: createdAt,
+	}
+}
+
+func (p *Project) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > maxProjectNameLength {
+		return errors.Newf("name must be %d characters or less", maxProjectNameLength)
+	}
+
+	p.Name = name
+	return nil
+}
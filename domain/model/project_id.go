@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type ProjectID string
+
+func NewProjectID(s string) (ProjectID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid project id")
+	}
+
+	return ProjectID(id.String()), nil
+}
+
+func GenerateProjectID() ProjectID {
+	return ProjectID(uuid.NewString())
+}
+
+func (p ProjectID) String() string {
+	return string(p)
+}
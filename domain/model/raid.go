@@ -0,0 +1,189 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RaidBoss はギルドで挑むボス。HPはメンバー全員の集中時間(分)で削る
+type RaidBoss struct {
+	Key       string
+	Name      string
+	HP        int
+	TimeLimit time.Duration
+	// Reward は勝利時に貢献したメンバーそれぞれに付与する
+	Reward Reward
+}
+
+// RaidBosses は選択できるボスの一覧。表示順に並べる
+var RaidBosses = []RaidBoss{
+	{Key: "slime", Name: "スライム", HP: 300, TimeLimit: 24 * time.Hour, Reward: Reward{Exp: 200, Gold: 50}},
+	{Key: "golem", Name: "ゴーレム", HP: 1500, TimeLimit: 3 * 24 * time.Hour, Reward: Reward{Exp: 800, Gold: 200}},
+	{Key: "dragon", Name: "ドラゴン", HP: 6000, TimeLimit: 7 * 24 * time.Hour, Reward: Reward{Exp: 3000, Gold: 800}},
+}
+
+func FindRaidBoss(key string) (RaidBoss, error) {
+	for _, b := range RaidBosses {
+		if b.Key == key {
+			return b, nil
+		}
+	}
+
+	return RaidBoss{}, errors.Newf("unknown raid boss: %s", key)
+}
+
+type RaidStatus string
+
+const (
+	RaidActive  RaidStatus = "active"
+	RaidVictory RaidStatus = "victory"
+	// RaidDefeat は制限時間内にHPを削りきれなかった状態
+	RaidDefeat RaidStatus = "defeat"
+)
+
+// Raid はギルドで挑戦中または挑戦済みのボス。ギルドごとに同時に1体まで挑戦できる
+type Raid struct {
+	ID         RaidID
+	GuildID    GuildID
+	Boss       string
+	MaxHP      int
+	Damage     float64
+	Reward     Reward
+	Status     RaidStatus
+	StartedAt  time.Time
+	EndsAt     time.Time
+	FinishedAt *time.Time
+}
+
+func NewRaid(id RaidID, guildID GuildID, boss RaidBoss, now time.Time) Raid {
+	return Raid{
+		ID:        id,
+		GuildID:   guildID,
+		Boss:      boss.Key,
+		MaxHP:     boss.HP,
+		Reward:    boss.Reward,
+		Status:    RaidActive,
+		StartedAt: now,
+		EndsAt:    now.Add(boss.TimeLimit),
+	}
+}
+
+func RecreateRaid(id RaidID, guildID GuildID, boss string, maxHP int, damage float64, reward Reward, status RaidStatus, startedAt, endsAt time.Time, finishedAt *time.Time) Raid {
+	return Raid{
+		ID:         id,
+		GuildID:    guildID,
+		Boss:       boss,
+		MaxHP:      maxHP,
+		Damage:     damage,
+		Reward:     reward,
+		Status:     status,
+		StartedAt:  startedAt,
+		EndsAt:     endsAt,
+		FinishedAt: finishedAt,
+	}
+}
+
+func (r Raid) HP() float64 {
+	return max(float64(r.MaxHP)-r.Damage, 0)
+}
+
+// StatusAt は制限時間を過ぎても記録上は挑戦中のままのレイドを敗北として返す
+func (r Raid) StatusAt(now time.Time) RaidStatus {
+	if r.Status == RaidActive && !now.Before(r.EndsAt) {
+		return RaidDefeat
+	}
+	return r.Status
+}
+
+// Accepts はatに集中した記録がダメージとして数えられるかを返す
+func (r Raid) Accepts(at time.Time) bool {
+	return r.Status == RaidActive && !at.Before(r.StartedAt) && at.Before(r.EndsAt)
+}
+
+// Hit は貢献をダメージとして反映し、HPを削りきった場合はtrueを返す
+func (r *Raid) Hit(c RaidContribution, now time.Time) (bool, error) {
+	if r.ID != c.RaidID {
+		return false, errors.New("contribution is not for the raid")
+	}
+	if !r.Accepts(c.ExecutedAt) {
+		return false, errors.New("raid does not accept the contribution")
+	}
+
+	r.Damage += c.Minutes
+	if r.HP() > 0 {
+		return false, nil
+	}
+
+	r.Status = RaidVictory
+	r.FinishedAt = &now
+	return true, nil
+}
+
+// Withdraw は記録の修正や削除に合わせて貢献のダメージをminutesまで減らす。修正で増えた分はダメージに加えない。
+// 勝利したレイドは報酬を分配済みのため減らせない
+func (r *Raid) Withdraw(c *RaidContribution, minutes float64) error {
+	if r.ID != c.RaidID {
+		return errors.New("contribution is not for the raid")
+	}
+	if minutes >= c.Minutes {
+		return nil
+	}
+	if r.Status == RaidVictory {
+		return errors.New("raid is already won")
+	}
+
+	r.Damage = max(r.Damage-(c.Minutes-minutes), 0)
+	c.Minutes = minutes
+	return nil
+}
+
+// Expire は制限時間を過ぎたレイドを敗北として終了させる
+func (r *Raid) Expire(now time.Time) error {
+	if r.Status != RaidActive || now.Before(r.EndsAt) {
+		return errors.New("raid is not expired")
+	}
+
+	endsAt := r.EndsAt
+	r.Status = RaidDefeat
+	r.FinishedAt = &endsAt
+	return nil
+}
+
+// RaidContribution は1回の集中記録によるダメージ。同じ記録は1度だけ数える
+type RaidContribution struct {
+	RaidID     RaidID
+	AccountID  AccountID
+	TimeID     TimeID
+	Minutes    float64
+	ExecutedAt time.Time
+	CreatedAt  time.Time
+}
+
+func NewRaidContribution(raidID RaidID, accID AccountID, timeID TimeID, minutes float64, executedAt time.Time) RaidContribution {
+	return RaidContribution{
+		RaidID:     raidID,
+		AccountID:  accID,
+		TimeID:     timeID,
+		Minutes:    minutes,
+		ExecutedAt: executedAt,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func RecreateRaidContribution(raidID RaidID, accID AccountID, timeID TimeID, minutes float64, executedAt, createdAt time.Time) RaidContribution {
+	return RaidContribution{
+		RaidID:     raidID,
+		AccountID:  accID,
+		TimeID:     timeID,
+		Minutes:    minutes,
+		ExecutedAt: executedAt,
+		CreatedAt:  createdAt,
+	}
+}
+
+// RaidContributor はメンバーごとの合計ダメージ
+type RaidContributor struct {
+	AccountID AccountID
+	Minutes   float64
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type RaidID string
+
+func NewRaidID(s string) (RaidID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid raid id")
+	}
+
+	return RaidID(id.String()), nil
+}
+
+func GenerateRaidID() RaidID {
+	return RaidID(uuid.NewString())
+}
+
+func (r RaidID) String() string {
+	return string(r)
+}
@@ -0,0 +1,118 @@
+package model
+
+import "time"
+
+const (
+	expPerFocusMinute  = 10
+	goldPerFocusMinute = 1
+	expPerBreakMinute  = 2
+	cycleBonusGold     = 20
+
+	focusRoomBonusExp               = 20
+	focusRoomBonusExpPerParticipant = 10
+	focusRoomBonusGold              = 5
+
+	// abandonDamage は集中を途中でやめたときに受けるダメージ
+	abandonDamage         = 15
+	missedDailyGoalDamage = 25
+	hpPerBreakMinute      = 4
+)
+
+// goalRewards は目標の期間が長いほど大きくする
+var goalRewards = map[GoalPeriod]Reward{
+	GoalDaily:   {Exp: 50, Gold: 10},
+	GoalWeekly:  {Exp: 300, Gold: 60},
+	GoalMonthly: {Exp: 1500, Gold: 300},
+}
+
+type Reward struct {
+	Exp  int
+	Gold int
+}
+
+func (r Reward) Add(other Reward) Reward {
+	return Reward{
+		Exp:  r.Exp + other.Exp,
+		Gold: r.Gold + other.Gold,
+	}
+}
+
+func (r Reward) Sub(other Reward) Reward {
+	return Reward{
+		Exp:  r.Exp - other.Exp,
+		Gold: r.Gold - other.Gold,
+	}
+}
+
+func NewFocusReward(t Time) Reward {
+	minutes := int(t.FocusTime)
+	return Reward{
+		Exp:  minutes * expPerFocusMinute,
+		Gold: minutes * goldPerFocusMinute,
+	}
+}
+
+// NewBreakReward は休憩を最後まで取ったことへの報酬を返す
+func NewBreakReward(b Break) Reward {
+	return Reward{
+		Exp: int(b.Minutes) * expPerBreakMinute,
+	}
+}
+
+func NewCycleReward() Reward {
+	return Reward{
+		Gold: cycleBonusGold,
+	}
+}
+
+func NewGoalReward(period GoalPeriod) Reward {
+	return goalRewards[period]
+}
+
+// NewFocusRoomBonus は部屋のタイマーを最後まで終えた参加者への報酬を返す。
+// 一緒に参加している人数が多いほど大きくする
+func NewFocusRoomBonus(participants int) Reward {
+	others := min(max(participants-1, 0), maxFocusRoomParticipants-1)
+	return Reward{
+		Exp:  focusRoomBonusExp + others*focusRoomBonusExpPerParticipant,
+		Gold: focusRoomBonusGold,
+	}
+}
+
+func AbandonDamage() int {
+	return abandonDamage
+}
+
+func MissedDailyGoalDamage() int {
+	return missedDailyGoalDamage
+}
+
+// BreakRegen は休憩を最後まで取ったときに回復するHPを返す
+func BreakRegen(b Break) int {
+	return int(b.Minutes * hpPerBreakMinute)
+}
+
+// RewardEntry は実際にキャラクターに反映された経験値とゴールドの増減の記録。
+// 期間ごとの獲得経験値の集計と、集中の記録ごとの付与済みの報酬の算出に使う
+type RewardEntry struct {
+	AccountID AccountID
+	// SourceID は取り消しうる報酬とその修正のときのみ設定する
+	SourceID  string
+	Exp       int
+	Gold      int
+	CreatedAt time.Time
+}
+
+func NewRewardEntry(accID AccountID, sourceID string, exp, gold int) RewardEntry {
+	return RewardEntry{
+		AccountID: accID,
+		SourceID:  sourceID,
+		Exp:       exp,
+		Gold:      gold,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (e RewardEntry) IsZero() bool {
+	return e.Exp == 0 && e.Gold == 0
+}
@@ -0,0 +1,109 @@
+package model
+
+import "slices"
+
+type RewardSource string
+
+// MinFocusSessionMinutes に満たない集中はドロップを抽選せず、シーズンの集中回数にも数えない。
+// 短い記録を繰り返してドロップや天井の進み具合を稼げないようにする
+const MinFocusSessionMinutes = 10
+
+const (
+	RewardSourceFocus     RewardSource = "focus"
+	RewardSourceBreak     RewardSource = "break"
+	RewardSourceGoal      RewardSource = "goal"
+	RewardSourceRaid      RewardSource = "raid"
+	RewardSourceFocusRoom RewardSource = "focus_room"
+	RewardSourceSeason    RewardSource = "season"
+)
+
+// RewardContext は報酬が発生した状況。スキルの効果の条件判定に使う
+type RewardContext struct {
+	Source RewardSource
+	// SessionMinutes は集中・休憩の報酬のときのみ設定する
+	SessionMinutes float64
+	// StreakDays はいずれかのルールが必要とする場合のみRewarderが設定する
+	StreakDays int
+	// SourceID は後から取り消しうる報酬のときのみ設定し、付与済みの報酬を求めるために台帳に残す。
+	// 集中の記録は記録のID、目標の達成は期間ごとのキーとする
+	SourceID string
+}
+
+// Lootable はドロップを抽選できる状況かを返す。集中の報酬は最低限の長さに達した場合のみ抽選する
+func (rc RewardContext) Lootable() bool {
+	return rc.Source != RewardSourceFocus || rc.SessionMinutes >= MinFocusSessionMinutes
+}
+
+func NewFocusRewardContext(t Time) RewardContext {
+	return RewardContext{Source: RewardSourceFocus, SessionMinutes: t.FocusTime, SourceID: t.ID.String()}
+}
+
+func NewGoalRewardContext(a GoalAchievement) RewardContext {
+	return RewardContext{Source: RewardSourceGoal, SourceID: a.Key()}
+}
+
+func NewBreakRewardContext(b Break) RewardContext {
+	return RewardContext{Source: RewardSourceBreak, SessionMinutes: b.Minutes}
+}
+
+func NewRewardContext(source RewardSource) RewardContext {
+	return RewardContext{Source: source}
+}
+
+// RewardCondition はすべての項目を満たしたときに成立する。ゼロ値の項目は判定しない
+type RewardCondition struct {
+	Sources           []RewardSource
+	MinSessionMinutes float64
+	MinStreakDays     int
+}
+
+func (c RewardCondition) Match(rc RewardContext) bool {
+	if len(c.Sources) > 0 && !slices.Contains(c.Sources, rc.Source) {
+		return false
+	}
+	return rc.SessionMinutes >= c.MinSessionMinutes && rc.StreakDays >= c.MinStreakDays
+}
+
+// RewardEffect の割合は元の報酬に対するもので、複数のルールが成立した場合は加算する
+type RewardEffect struct {
+	ExpPercent  int
+	GoldPercent int
+	Exp         int
+	Gold        int
+}
+
+type RewardRule struct {
+	When   RewardCondition
+	Effect RewardEffect
+}
+
+// NeedsStreak は連続日数の集計が必要なルールが含まれるかを返す
+func NeedsStreak(rules []RewardRule) bool {
+	return slices.ContainsFunc(rules, func(r RewardRule) bool {
+		return r.When.MinStreakDays > 0
+	})
+}
+
+// ApplyRewardRules は成立したルールの効果を報酬に反映する。
+// 元の報酬がない経験値・ゴールドには効果を適用しない
+func ApplyRewardRules(base Reward, rules []RewardRule, rc RewardContext) Reward {
+	var expPercent, goldPercent int
+	bonus := Reward{}
+	for _, r := range rules {
+		if !r.When.Match(rc) {
+			continue
+		}
+		expPercent += r.Effect.ExpPercent
+		goldPercent += r.Effect.GoldPercent
+		bonus = bonus.Add(Reward{Exp: r.Effect.Exp, Gold: r.Effect.Gold})
+	}
+
+	res := base
+	if base.Exp > 0 {
+		res.Exp += base.Exp*expPercent/100 + bonus.Exp
+	}
+	if base.Gold > 0 {
+		res.Gold += base.Gold*goldPercent/100 + bonus.Gold
+	}
+	return res
+}
@@ -0,0 +1,380 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	seasonExpPerFocusMinute = 1
+	// defaultSeasonClaimPeriod はデータで指定しない場合に終了後も報酬を受け取れる期間
+	defaultSeasonClaimPeriod = 30 * 24 * time.Hour
+)
+
+type SeasonStatus string
+
+const (
+	SeasonUpcoming SeasonStatus = "upcoming"
+	SeasonActive   SeasonStatus = "active"
+	// SeasonEnded はシーズンポイントは増えないが、まだ報酬を受け取れる状態
+	SeasonEnded  SeasonStatus = "ended"
+	SeasonClosed SeasonStatus = "closed"
+)
+
+type SeasonQuestKind string
+
+const (
+	SeasonQuestFocusMinutes  SeasonQuestKind = "focus_minutes"
+	SeasonQuestFocusSessions SeasonQuestKind = "focus_sessions"
+	SeasonQuestGoalsAchieved SeasonQuestKind = "goals_achieved"
+)
+
+func (k SeasonQuestKind) Valid() bool {
+	switch k {
+	case SeasonQuestFocusMinutes, SeasonQuestFocusSessions, SeasonQuestGoalsAchieved:
+		return true
+	}
+	return false
+}
+
+// SeasonQuest は開催期間中の活動の累計がTargetに達すると、Expだけシーズンポイントを加える
+type SeasonQuest struct {
+	Key    string
+	Name   string
+	Kind   SeasonQuestKind
+	Target int
+	Exp    int
+}
+
+func (q SeasonQuest) Progress(t SeasonTally) int {
+	switch q.Kind {
+	case SeasonQuestFocusMinutes:
+		return int(t.FocusMinutes)
+	case SeasonQuestFocusSessions:
+		return t.FocusSessions
+	case SeasonQuestGoalsAchieved:
+		return t.GoalsAchieved
+	}
+	return 0
+}
+
+func (q SeasonQuest) Completed(t SeasonTally) bool {
+	return q.Progress(t) >= q.Target
+}
+
+type SeasonTrack string
+
+const (
+	SeasonTrackFree    SeasonTrack = "free"
+	SeasonTrackPremium SeasonTrack = "premium"
+)
+
+// SeasonTier はシーズンポイントの累計がExpに達すると受け取れる報酬。
+// Premiumはプレミアムパスを購入した場合のみ受け取れる
+type SeasonTier struct {
+	Tier    int
+	Exp     int
+	Free    Reward
+	Premium Reward
+}
+
+func (t SeasonTier) Reward(track SeasonTrack) Reward {
+	if track == SeasonTrackPremium {
+		return t.Premium
+	}
+	return t.Free
+}
+
+// Season は開催期間の決まったシーズン。開催期間中の活動だけをシーズンポイントとして数え、
+// 終了後もClaimEndsAtまでは到達済みの報酬を受け取れる。
+// Versionは内容から求め、データファイルの再読み込み時の差分の記録に使う
+type Season struct {
+	Key         string
+	Name        string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	ClaimEndsAt time.Time
+	// PremiumCost はプレミアムパスの購入に必要なゴールド
+	PremiumCost int
+	// Tiers は段階の順に並べる
+	Tiers  []SeasonTier
+	Quests []SeasonQuest
+	// Bosses は開催期間中のみギルドのレイドで挑めるボス
+	Bosses  []RaidBoss
+	Version string
+}
+
+// NewSeason はclaimEndsAtがnilの場合、終了から一定期間は報酬を受け取れるようにする
+func NewSeason(key, name string, startsAt, endsAt time.Time, claimEndsAt *time.Time, premiumCost int, tiers []SeasonTier, quests []SeasonQuest, bosses []RaidBoss) (Season, error) {
+	s := Season{
+		Key:         key,
+		Name:        name,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		ClaimEndsAt: endsAt.Add(defaultSeasonClaimPeriod),
+		PremiumCost: premiumCost,
+		Tiers:       tiers,
+		Quests:      quests,
+		Bosses:      bosses,
+	}
+	if claimEndsAt != nil {
+		s.ClaimEndsAt = *claimEndsAt
+	}
+	if err := s.validate(); err != nil {
+		return Season{}, errors.Wrapf(err, "season %s", key)
+	}
+
+	version, err := s.fingerprint()
+	if err != nil {
+		return Season{}, err
+	}
+	s.Version = version
+	return s, nil
+}
+
+func (s Season) validate() error {
+	if s.Key == "" {
+		return errors.New("key is required")
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("season must end after it starts")
+	}
+	if s.ClaimEndsAt.Before(s.EndsAt) {
+		return errors.New("claim period must not end before the season ends")
+	}
+	if s.PremiumCost < 0 {
+		return errors.New("premium cost must not be negative")
+	}
+	if len(s.Tiers) == 0 {
+		return errors.New("tiers are required")
+	}
+
+	for i, t := range s.Tiers {
+		if t.Tier != i+1 {
+			return errors.Newf("tiers must be numbered from 1 in order: %d", t.Tier)
+		}
+		if t.Exp <= 0 || (i > 0 && t.Exp <= s.Tiers[i-1].Exp) {
+			return errors.Newf("exp of tier %d must be greater than the previous tier", t.Tier)
+		}
+		if t.Free.Exp < 0 || t.Free.Gold < 0 || t.Premium.Exp < 0 || t.Premium.Gold < 0 {
+			return errors.Newf("reward of tier %d must not be negative", t.Tier)
+		}
+	}
+
+	quests := make(map[string]struct{}, len(s.Quests))
+	for _, q := range s.Quests {
+		if q.Key == "" {
+			return errors.New("quest key is required")
+		}
+		if _, ok := quests[q.Key]; ok {
+			return errors.Newf("duplicate quest: %s", q.Key)
+		}
+		quests[q.Key] = struct{}{}
+		if !q.Kind.Valid() {
+			return errors.Newf("invalid quest kind: %s", q.Kind)
+		}
+		if q.Target <= 0 || q.Exp <= 0 {
+			return errors.Newf("target and exp of %s must be greater than 0", q.Key)
+		}
+	}
+
+	for _, b := range s.Bosses {
+		if b.Key == "" {
+			return errors.New("boss key is required")
+		}
+		// 常設のボスとキーが重なると、レイドの記録からどちらのボスか判別できなくなる
+		if _, err := FindRaidBoss(b.Key); err == nil {
+			return errors.Newf("boss %s conflicts with a permanent boss", b.Key)
+		}
+		if b.HP <= 0 || b.TimeLimit <= 0 {
+			return errors.Newf("hp and time limit of %s must be greater than 0", b.Key)
+		}
+	}
+	return nil
+}
+
+func (s Season) fingerprint() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func (s Season) StatusAt(now time.Time) SeasonStatus {
+	switch {
+	case now.Before(s.StartsAt):
+		return SeasonUpcoming
+	case now.Before(s.EndsAt):
+		return SeasonActive
+	case now.Before(s.ClaimEndsAt):
+		return SeasonEnded
+	default:
+		return SeasonClosed
+	}
+}
+
+// Accepts はatに行った活動がシーズンポイントとして数えられるかを返す
+func (s Season) Accepts(at time.Time) bool {
+	return s.StatusAt(at) == SeasonActive
+}
+
+// Exp は集中時間とクリアしたクエストからシーズンポイントを求める
+func (s Season) Exp(t SeasonTally) int {
+	exp := int(t.FocusMinutes) * seasonExpPerFocusMinute
+	for _, q := range s.Quests {
+		if q.Completed(t) {
+			exp += q.Exp
+		}
+	}
+	return exp
+}
+
+// TierAt はexpで到達している段階を返す。1段階目に届いていなければ0
+func (s Season) TierAt(exp int) int {
+	tier := 0
+	for _, t := range s.Tiers {
+		if exp >= t.Exp {
+			tier = t.Tier
+		}
+	}
+	return tier
+}
+
+// Unclaimed はexpで到達済みかつ未受け取りの報酬を返す。
+// プレミアムの報酬は購入前に到達した段階の分もさかのぼって受け取れる
+func (s Season) Unclaimed(accID AccountID, exp int, premium bool, claimed []SeasonClaim, now time.Time) []SeasonClaim {
+	tracks := []SeasonTrack{SeasonTrackFree}
+	if premium {
+		tracks = append(tracks, SeasonTrackPremium)
+	}
+
+	var res []SeasonClaim
+	for _, t := range s.Tiers[:s.TierAt(exp)] {
+		for _, track := range tracks {
+			reward := t.Reward(track)
+			if reward == (Reward{}) || HasSeasonClaim(claimed, t.Tier, track) {
+				continue
+			}
+			res = append(res, NewSeasonClaim(s.Key, accID, t.Tier, track, reward, now))
+		}
+	}
+	return res
+}
+
+// SeasonTally はシーズンの開催期間中の活動の累計
+type SeasonTally struct {
+	FocusMinutes  float64
+	FocusSessions int
+	GoalsAchieved int
+}
+
+type SeasonActivityKind string
+
+const (
+	SeasonActivityFocus SeasonActivityKind = "focus"
+	SeasonActivityGoal  SeasonActivityKind = "goal"
+)
+
+// SeasonActivity はシーズンポイントの元になる活動。同じSourceIDの活動は1度だけ数える
+type SeasonActivity struct {
+	SeasonKey  string
+	AccountID  AccountID
+	SourceID   string
+	Kind       SeasonActivityKind
+	Minutes    float64
+	OccurredAt time.Time
+}
+
+func NewFocusSeasonActivity(seasonKey string, ev FocusSessionCompleted) SeasonActivity {
+	return SeasonActivity{
+		SeasonKey:  seasonKey,
+		AccountID:  ev.AccountID,
+		SourceID:   ev.TimeID.String(),
+		Kind:       SeasonActivityFocus,
+		Minutes:    ev.FocusTime,
+		OccurredAt: ev.ExecutionDate,
+	}
+}
+
+// NewGoalSeasonActivity は目標の期間ごとに1度だけ数える
+func NewGoalSeasonActivity(seasonKey string, ev GoalAchieved) SeasonActivity {
+	return SeasonActivity{
+		SeasonKey:  seasonKey,
+		AccountID:  ev.AccountID,
+		SourceID:   string(ev.Period) + ":" + ev.PeriodStart.Format(time.DateOnly),
+		Kind:       SeasonActivityGoal,
+		OccurredAt: ev.At,
+	}
+}
+
+// SeasonPass はプレミアムパスの購入記録
+type SeasonPass struct {
+	SeasonKey   string
+	AccountID   AccountID
+	Cost        int
+	PurchasedAt time.Time
+}
+
+func NewSeasonPass(s Season, accID AccountID, now time.Time) SeasonPass {
+	return SeasonPass{
+		SeasonKey:   s.Key,
+		AccountID:   accID,
+		Cost:        s.PremiumCost,
+		PurchasedAt: now,
+	}
+}
+
+func RecreateSeasonPass(seasonKey string, accID AccountID, cost int, purchasedAt time.Time) SeasonPass {
+	return SeasonPass{
+		SeasonKey:   seasonKey,
+		AccountID:   accID,
+		Cost:        cost,
+		PurchasedAt: purchasedAt,
+	}
+}
+
+// SeasonClaim は受け取った報酬。段階とトラックの組ごとに1度だけ受け取れる
+type SeasonClaim struct {
+	SeasonKey string
+	AccountID AccountID
+	Tier      int
+	Track     SeasonTrack
+	Reward    Reward
+	ClaimedAt time.Time
+}
+
+func NewSeasonClaim(seasonKey string, accID AccountID, tier int, track SeasonTrack, reward Reward, now time.Time) SeasonClaim {
+	return SeasonClaim{
+		SeasonKey: seasonKey,
+		AccountID: accID,
+		Tier:      tier,
+		Track:     track,
+		Reward:    reward,
+		ClaimedAt: now,
+	}
+}
+
+func RecreateSeasonClaim(seasonKey string, accID AccountID, tier int, track SeasonTrack, reward Reward, claimedAt time.Time) SeasonClaim {
+	return SeasonClaim{
+		SeasonKey: seasonKey,
+		AccountID: accID,
+		Tier:      tier,
+		Track:     track,
+		Reward:    reward,
+		ClaimedAt: claimedAt,
+	}
+}
+
+func HasSeasonClaim(claimed []SeasonClaim, tier int, track SeasonTrack) bool {
+	return slices.ContainsFunc(claimed, func(c SeasonClaim) bool {
+		return c.Tier == tier && c.Track == track
+	})
+}
@@ -0,0 +1,24 @@
+package model
+
+import "github.com/cockroachdb/errors"
+
+type SessionKind string
+
+const (
+	SessionFocus      SessionKind = "focus"
+	SessionShortBreak SessionKind = "short_break"
+	SessionLongBreak  SessionKind = "long_break"
+)
+
+func NewSessionKind(s string) (SessionKind, error) {
+	switch k := SessionKind(s); k {
+	case SessionFocus, SessionShortBreak, SessionLongBreak:
+		return k, nil
+	default:
+		return "", errors.Newf("invalid session kind: %s", s)
+	}
+}
+
+func (k SessionKind) IsBreak() bool {
+	return k == SessionShortBreak || k == SessionLongBreak
+}
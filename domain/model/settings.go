@@ -0,0 +1,136 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultFocusMinutes      = 25
+	defaultShortBreakMinutes = 5
+	defaultLongBreakMinutes  = 15
+	defaultLongBreakInterval = 4
+
+	maxFocusMinutes      = 120
+	maxBreakMinutes      = 60
+	maxLongBreakInterval = 12
+
+	defaultTimezone = "UTC"
+)
+
+type Settings struct {
+	AccountID         AccountID
+	FocusMinutes      int
+	ShortBreakMinutes int
+	LongBreakMinutes  int
+	LongBreakInterval int
+	AutoStartBreaks   bool
+	AutoStartFocus    bool
+	NotifySound       bool
+	NotifyDesktop     bool
+	// Timezone は目標の達成状況など、日付の区切りを利用者基準で判定するためのIANAタイムゾーン名
+	Timezone string
+}
+
+func NewSettings(accID AccountID, focus, shortBreak, longBreak, longBreakInterval int, autoStartBreaks, autoStartFocus, notifySound, notifyDesktop bool, timezone string) (Settings, error) {
+	if focus < 1 || focus > maxFocusMinutes {
+		return Settings{}, errors.Newf("focus minutes must be between 1 and %d", maxFocusMinutes)
+	}
+
+	if shortBreak < 1 || shortBreak > maxBreakMinutes {
+		return Settings{}, errors.Newf("short break minutes must be between 1 and %d", maxBreakMinutes)
+	}
+
+	if longBreak < shortBreak || longBreak > maxBreakMinutes {
+		return Settings{}, errors.Newf("long break minutes must be between short break minutes and %d", maxBreakMinutes)
+	}
+
+	if longBreakInterval < 1 || longBreakInterval > maxLongBreakInterval {
+		return Settings{}, errors.Newf("long break interval must be between 1 and %d", maxLongBreakInterval)
+	}
+
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return Settings{}, errors.Wrap(err, "invalid timezone")
+	}
+
+	return Settings{
+		AccountID:         accID,
+		FocusMinutes:      focus,
+		ShortBreakMinutes: shortBreak,
+		LongBreakMinutes:  longBreak,
+		LongBreakInterval: longBreakInterval,
+		AutoStartBreaks:   autoStartBreaks,
+		AutoStartFocus:    autoStartFocus,
+		NotifySound:       notifySound,
+		NotifyDesktop:     notifyDesktop,
+		Timezone:          timezone,
+	}, nil
+}
+
+// DefaultSettings は設定を保存していないアカウントに適用する値を返す
+func DefaultSettings(accID AccountID) Settings {
+	return Settings{
+		AccountID:         accID,
+		FocusMinutes:      defaultFocusMinutes,
+		ShortBreakMinutes: defaultShortBreakMinutes,
+		LongBreakMinutes:  defaultLongBreakMinutes,
+		LongBreakInterval: defaultLongBreakInterval,
+		NotifySound:       true,
+		NotifyDesktop:     true,
+		Timezone:          defaultTimezone,
+	}
+}
+
+func RecreateSettings(accID AccountID, focus, shortBreak, longBreak, longBreakInterval int, autoStartBreaks, autoStartFocus, notifySound, notifyDesktop bool, timezone string) Settings {
+	return Settings{
+		AccountID:         accID,
+		FocusMinutes:      focus,
+		ShortBreakMinutes: shortBreak,
+		LongBreakMinutes:  longBreak,
+		LongBreakInterval: longBreakInterval,
+		AutoStartBreaks:   autoStartBreaks,
+		AutoStartFocus:    autoStartFocus,
+		NotifySound:       notifySound,
+		NotifyDesktop:     notifyDesktop,
+		Timezone:          timezone,
+	}
+}
+
+// Location は保存後にタイムゾーンのデータベースから消えた場合に備え、読み込めなければUTCを返す
+func (s Settings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ValidateFocusMinutes は記録しようとしている集中時間が設定した長さを超えていないか検証する
+func (s Settings) ValidateFocusMinutes(minutes float64) error {
+	return s.ValidateSessionMinutes(SessionFocus, minutes)
+}
+
+// ValidateSessionMinutes はセッションの長さが種類ごとに設定した長さを超えていないか検証する
+func (s Settings) ValidateSessionMinutes(kind SessionKind, minutes float64) error {
+	limit := s.Minutes(kind)
+	if math.IsNaN(minutes) || minutes > float64(limit) {
+		return errors.Newf("%s must be %d minutes or less", kind, limit)
+	}
+	return nil
+}
+
+func (s Settings) Minutes(kind SessionKind) int {
+	switch kind {
+	case SessionShortBreak:
+		return s.ShortBreakMinutes
+	case SessionLongBreak:
+		return s.LongBreakMinutes
+	default:
+		return s.FocusMinutes
+	}
+}
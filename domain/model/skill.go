@@ -0,0 +1,126 @@
+package model
+
+import "github.com/cockroachdb/errors"
+
+type CharacterClass string
+
+const (
+	ClassWarrior CharacterClass = "warrior"
+	ClassMage    CharacterClass = "mage"
+	ClassScholar CharacterClass = "scholar"
+)
+
+// Stats はキャラクターの能力値。クラスごとの成長量とレベルから求める
+type Stats struct {
+	Strength  int
+	Intellect int
+	Wisdom    int
+}
+
+const baseStat = 5
+
+// ClassDefinition のGrowthはレベルが1上がるごとに増える能力値
+type ClassDefinition struct {
+	Class       CharacterClass
+	Name        string
+	Description string
+	Growth      Stats
+}
+
+// Classes は選択できるクラスの一覧。表示順に並べる
+var Classes = []ClassDefinition{
+	{Class: ClassWarrior, Name: "戦士", Description: "力が伸びやすく、ギルドのレイドで活躍する", Growth: Stats{Strength: 3, Intellect: 1, Wisdom: 1}},
+	{Class: ClassMage, Name: "魔法使い", Description: "知力が伸びやすく、長時間の集中を得意とする", Growth: Stats{Strength: 1, Intellect: 3, Wisdom: 1}},
+	{Class: ClassScholar, Name: "学者", Description: "知恵が伸びやすく、目標の達成を得意とする", Growth: Stats{Strength: 1, Intellect: 1, Wisdom: 3}},
+}
+
+func FindClass(class CharacterClass) (ClassDefinition, error) {
+	for _, c := range Classes {
+		if c.Class == class {
+			return c, nil
+		}
+	}
+
+	return ClassDefinition{}, errors.Newf("unknown class: %s", class)
+}
+
+// Skill はスキルポイントを消費して習得する。Classが空のスキルはどのクラスでも習得できる
+type Skill struct {
+	Key         string
+	Name        string
+	Description string
+	Cost        int
+	Requires    []string
+	Class       CharacterClass
+	Rules       []RewardRule
+}
+
+// SkillTree は習得できるスキルの一覧。前提となるスキルを先に並べる
+var SkillTree = []Skill{
+	{
+		Key: "deep_focus", Name: "集中の心得", Description: "25分以上の集中で獲得経験値+10%", Cost: 1,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceFocus}, MinSessionMinutes: 25},
+			Effect: RewardEffect{ExpPercent: 10},
+		}},
+	},
+	{
+		Key: "marathon", Name: "長期戦", Description: "50分以上の集中で獲得経験値+20%", Cost: 2, Requires: []string{"deep_focus"},
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceFocus}, MinSessionMinutes: 50},
+			Effect: RewardEffect{ExpPercent: 20},
+		}},
+	},
+	{
+		Key: "steady_habit", Name: "継続の力", Description: "3日以上連続で集中していると集中の獲得ゴールド+10%", Cost: 1,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceFocus}, MinStreakDays: 3},
+			Effect: RewardEffect{GoldPercent: 10},
+		}},
+	},
+	{
+		Key: "golden_streak", Name: "黄金の連続記録", Description: "7日以上連続で集中していると集中・目標達成の獲得ゴールド+25%", Cost: 2, Requires: []string{"steady_habit"},
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceFocus, RewardSourceGoal}, MinStreakDays: 7},
+			Effect: RewardEffect{GoldPercent: 25},
+		}},
+	},
+	{
+		Key: "restful_mind", Name: "休息の達人", Description: "休憩を最後まで取ると経験値+5", Cost: 1,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceBreak}},
+			Effect: RewardEffect{Exp: 5},
+		}},
+	},
+	{
+		Key: "battle_cry", Name: "鬨の声", Description: "レイドと集中ルームの獲得経験値・ゴールド+20%", Cost: 2, Requires: []string{"deep_focus"}, Class: ClassWarrior,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceRaid, RewardSourceFocusRoom}},
+			Effect: RewardEffect{ExpPercent: 20, GoldPercent: 20},
+		}},
+	},
+	{
+		Key: "arcane_flow", Name: "魔力の奔流", Description: "50分以上の集中で経験値+100", Cost: 3, Requires: []string{"marathon"}, Class: ClassMage,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceFocus}, MinSessionMinutes: 50},
+			Effect: RewardEffect{Exp: 100},
+		}},
+	},
+	{
+		Key: "scholarly_pursuit", Name: "探究心", Description: "目標達成の獲得経験値+30%", Cost: 2, Requires: []string{"steady_habit"}, Class: ClassScholar,
+		Rules: []RewardRule{{
+			When:   RewardCondition{Sources: []RewardSource{RewardSourceGoal}},
+			Effect: RewardEffect{ExpPercent: 30},
+		}},
+	},
+}
+
+func FindSkill(key string) (Skill, error) {
+	for _, s := range SkillTree {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+
+	return Skill{}, errors.Newf("unknown skill: %s", key)
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// DailyStat はある日(利用者のタイムゾーン基準)の集中時間と休憩時間の合計
+type DailyStat struct {
+	Date         time.Time
+	FocusMinutes float64
+	BreakMinutes float64
+}
+
+// FocusRatio は集中と休憩の合計時間に占める集中時間の割合を返す
+func (s DailyStat) FocusRatio() float64 {
+	total := s.FocusMinutes + s.BreakMinutes
+	if total == 0 {
+		return 0
+	}
+	return s.FocusMinutes / total
+}
+
+// heatmapLevels はヒートマップの濃淡の段階数(0は記録なし)
+const heatmapLevels = 4
+
+// HeatmapDay はヒートマップの1日分。Levelは0〜4の濃淡
+type HeatmapDay struct {
+	Date         time.Time
+	FocusMinutes float64
+	Level        int
+}
+
+// NewHeatmap はyearの1月1日から12月31日まで(loc基準)の全日について、集中時間と濃淡を返す。
+// 濃淡はその年で最も集中した日を最大として4段階に分ける
+func NewHeatmap(year int, loc *time.Location, stats []DailyStat) []HeatmapDay {
+	minutes := make(map[string]float64, len(stats))
+	var peak float64
+	for _, st := range stats {
+		key := st.Date.Format(time.DateOnly)
+		minutes[key] += st.FocusMinutes
+		peak = max(peak, minutes[key])
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+	res := make([]HeatmapDay, 0, 366)
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		m := minutes[d.Format(time.DateOnly)]
+		res = append(res, HeatmapDay{Date: d, FocusMinutes: m, Level: heatmapLevel(m, peak)})
+	}
+
+	return res
+}
+
+func heatmapLevel(minutes, peak float64) int {
+	if minutes <= 0 || peak <= 0 {
+		return 0
+	}
+	return min(heatmapLevels, int(math.Ceil(minutes/peak*heatmapLevels)))
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	MaxSyncBatchSize = 100
+	// maxOfflineAge より前に終えたセッションは報酬目当ての後付けを防ぐため受け付けない
+	maxOfflineAge = 7 * 24 * time.Hour
+	// clockSkewTolerance は端末の時計のずれとして許容する未来方向の誤差
+	clockSkewTolerance = 5 * time.Minute
+	// syncOverlap は同期時点で未コミットだった変更を取りこぼさないよう、次回の差分を重ねて返す幅
+	syncOverlap = 5 * time.Second
+)
+
+// SyncStatus は同期で送られたセッションごとの処理結果
+type SyncStatus string
+
+const (
+	SyncCreated   SyncStatus = "created"
+	SyncDuplicate SyncStatus = "duplicate"
+	SyncRejected  SyncStatus = "rejected"
+	SyncFailed    SyncStatus = "failed"
+)
+
+// SyncPosition は差分を取得済みの位置。同じ日時の変更がページの境界をまたいでも取りこぼさないよう、IDも含めて比べる
+type SyncPosition struct {
+	At time.Time `json:"at"`
+	ID string    `json:"id,omitempty"`
+}
+
+// NextSyncPosition は差分を返し切った場合の次回の起点を返す
+func NextSyncPosition(now time.Time) SyncPosition {
+	return SyncPosition{At: now.Add(-syncOverlap).UTC()}
+}
+
+// SyncCursor は記録と休憩それぞれの差分を取得済みの位置
+type SyncCursor struct {
+	Times  SyncPosition `json:"times"`
+	Breaks SyncPosition `json:"breaks"`
+}
+
+// ParseSyncToken はクライアントが前回受け取ったトークンから差分の起点を返す。空の場合は全件を対象とする
+func ParseSyncToken(token string) (SyncCursor, error) {
+	if token == "" {
+		return SyncCursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return SyncCursor{}, errors.New("invalid sync token")
+	}
+
+	var c SyncCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return SyncCursor{}, errors.New("invalid sync token")
+	}
+	return c, nil
+}
+
+func (c SyncCursor) Encode() string {
+	c.Times.At = c.Times.At.UTC()
+	c.Breaks.At = c.Breaks.At.UTC()
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ValidateOfflineSession はオフライン中に終えたセッションとして受け付けられる日時か検証する
+func ValidateOfflineSession(endedAt, now time.Time) error {
+	if endedAt.After(now.Add(clockSkewTolerance)) {
+		return errors.New("session ended in the future")
+	}
+	if now.Sub(endedAt) > maxOfflineAge {
+		return errors.Newf("session must have ended within %s", maxOfflineAge)
+	}
+	return nil
+}
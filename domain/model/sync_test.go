@@ -0,0 +1,38 @@
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestSyncCursorEncodeRoundTrip(t *testing.T) {
+	at := time.Date(2024, 12, 1, 9, 30, 0, 123456000, time.UTC)
+	want := SyncCursor{
+		Times:  SyncPosition{At: at, ID: "0b7e3f5e-6a4e-4d8f-9a2b-1c3d5e7f9a0b"},
+		Breaks: SyncPosition{At: at.Add(-time.Minute)},
+	}
+
+	got, err := ParseSyncToken(want.Encode())
+	if err != nil {
+		t.Fatalf("ParseSyncToken() error = %v", err)
+	}
+	if !got.Times.At.Equal(want.Times.At) || got.Times.ID != want.Times.ID ||
+		!got.Breaks.At.Equal(want.Breaks.At) || got.Breaks.ID != want.Breaks.ID {
+		t.Errorf("ParseSyncToken() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseSyncTokenRejectsInvalidToken(t *testing.T) {
+	tokens := []string{
+		"!",
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday")),
+		// 日時のみのトークンは受け付けない
+		base64.RawURLEncoding.EncodeToString([]byte(time.Date(2024, 12, 1, 9, 30, 0, 0, time.UTC).Format(time.RFC3339Nano))),
+	}
+	for _, token := range tokens {
+		if _, err := ParseSyncToken(token); err == nil {
+			t.Errorf("ParseSyncToken(%q) should fail", token)
+		}
+	}
+}
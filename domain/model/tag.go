@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxTagNameLength = 32
+
+type Tag struct {
+	ID        TagID
+	AccountID AccountID
+	Name      string
+}
+
+func RecreateTag(id TagID, accID AccountID, name string) Tag {
+	return Tag{
+		ID:        id,
+		AccountID: accID,
+		Name:      name,
+	}
+}
+
+// NormalizeTagName は表記揺れで同じタグが別々に作られないよう、小文字化し空白を詰めた名前を返す
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	name = strings.TrimPrefix(name, "#")
+	if name == "" {
+		return "", errors.New("tag is required")
+	}
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", errors.Newf("tag must be %d characters or less", maxTagNameLength)
+	}
+	return name, nil
+}
+
+// TagTotal はタグごとの集中時間の合計
+type TagTotal struct {
+	Name            string
+	ActualPomodoros int
+	FocusMinutes    float64
+}
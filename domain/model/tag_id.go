@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type TagID string
+
+func NewTagID(s string) (TagID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid tag id")
+	}
+
+	return TagID(id.String()), nil
+}
+
+func GenerateTagID() TagID {
+	return TagID(uuid.NewString())
+}
+
+func (t TagID) String() string {
+	return string(t)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	maxTaskTitleLength    = 200
+	maxEstimatedPomodoros = 100
+)
+
+// Task は集中時間を紐付ける作業単位。見積もりはポモドーロの回数で行う
+type Task struct {
+	ID                 TaskID
+	AccountID          AccountID
+	ProjectID          *ProjectID
+	Title              string
+	EstimatedPomodoros int
+	Completed          bool
+	CreatedAt          time.Time
+}
+
+func NewTask(id TaskID, accID AccountID, projectID *ProjectID, title string, estimatedPomodoros int) (Task, error) {
+	t := Task{
+		ID:        id,
+		AccountID: accID,
+		CreatedAt: time.Now(),
+	}
+	if err := t.Update(projectID, title, estimatedPomodoros, false); err != nil {
+		return Task{}, err
+	}
+
+	return t, nil
+}
+
+func RecreateTask(id TaskID, accID AccountID, projectID *ProjectID, title string, estimatedPomodoros int, completed bool, createdAt time.Time) Task {
+	return Task{
+		ID:                 id,
+		AccountID:          accID,
+		ProjectID:          projectID,
+		Title:              title,
+		EstimatedPomodoros: estimatedPomodoros,
+		Completed:          completed,
+		CreatedAt:          createdAt,
+	}
+}
+
+func (t *Task) Update(projectID *ProjectID, title string, estimatedPomodoros int, completed bool) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("title is required")
+	}
+	if utf8.RuneCountInString(title) > maxTaskTitleLength {
+		return errors.Newf("title must be %d characters or less", maxTaskTitleLength)
+	}
+	if estimatedPomodoros < 0 || estimatedPomodoros > maxEstimatedPomodoros {
+		return errors.Newf("estimated pomodoros must be between 0 and %d", maxEstimatedPomodoros)
+	}
+
+	t.ProjectID = projectID
+	t.Title = title
+	t.EstimatedPomodoros = estimatedPomodoros
+	t.Completed = completed
+	return nil
+}
+
+// TaskTotal はタスクごとの見積もりと実績。実績は記録された集中の回数を1ポモドーロとして数える
+type TaskTotal struct {
+	TaskID             TaskID
+	ProjectID          *ProjectID
+	Title              string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
+
+type ProjectTotal struct {
+	ProjectID          ProjectID
+	Name               string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type TaskID string
+
+func NewTaskID(s string) (TaskID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid task id")
+	}
+
+	return TaskID(id.String()), nil
+}
+
+func GenerateTaskID() TaskID {
+	return TaskID(uuid.NewString())
+}
+
+func (t TaskID) String() string {
+	return string(t)
+}
@@ -1,39 +1,136 @@
 package model
 
 import (
+	"slices"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	maxNoteLength   = 280
+	maxTagsPerEntry = 10
+	// correctionWindow を過ぎた記録は報酬目当ての付け替えを防ぐため変更できない
+	correctionWindow = 24 * time.Hour
+)
+
 type Time struct {
 	ID            TimeID
 	FocusTime     float64
 	AccountID     AccountID
+	TaskID        *TaskID
+	Note          string
+	Tags          []string
 	ExecutionDate time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time
+	eventRecorder
 }
 
-func NewTime(id TimeID, focusTime float64, accID AccountID) (Time, error) {
+// NewTime はtaskIDがnilの場合、タスクに紐付けずに記録する
+func NewTime(id TimeID, focusTime float64, accID AccountID, taskID *TaskID) (Time, error) {
+	return NewTimeAt(id, focusTime, accID, taskID, time.Now())
+}
+
+// NewTimeAt はオフライン中に記録された集中時間など、実施日時を指定して記録する
+func NewTimeAt(id TimeID, focusTime float64, accID AccountID, taskID *TaskID, executionDate time.Time) (Time, error) {
 	if focusTime <= 0 {
 		return Time{}, errors.New("focus time is 0 or more")
 	}
 
-	return Time{
+	t := Time{
 		ID:            id,
 		FocusTime:     focusTime,
 		AccountID:     accID,
-		ExecutionDate: time.Now(),
-	}, nil
-}
+		TaskID:        taskID,
+		ExecutionDate: executionDate,
+	}
+	t.record(FocusSessionCompleted{
+		TimeID:        id,
+		AccountID:     accID,
+		TaskID:        taskID,
+		FocusTime:     focusTime,
+		ExecutionDate: executionDate,
+		At:            time.Now(),
+	})
 
-func RecreateTime(id TimeID, focusTime float64, accID AccountID, dateStr string) Time {
-	layout := "2006-01-02T15:04"
-	executionDate, _ := time.Parse(layout, dateStr)
+	return t, nil
+}
 
+func RecreateTime(id TimeID, focusTime float64, accID AccountID, taskID *TaskID, note string, tags []string, executionDate, updatedAt time.Time, deletedAt *time.Time) Time {
 	return Time{
 		ID:            id,
 		FocusTime:     focusTime,
 		AccountID:     accID,
+		TaskID:        taskID,
+		Note:          note,
+		Tags:          tags,
 		ExecutionDate: executionDate,
+		UpdatedAt:     updatedAt,
+		DeletedAt:     deletedAt,
+	}
+}
+
+// StartedAt は実施日時を終了日時とみなし、集中時間から逆算した開始日時を返す
+func (t Time) StartedAt() time.Time {
+	return t.ExecutionDate.Add(-time.Duration(t.FocusTime * float64(time.Minute)))
+}
+
+// Annotate はメモとタグを設定する。タグは正規化し、重複を取り除く
+func (t *Time) Annotate(note string, tags []string) error {
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return errors.Errorf("note must be %d characters or less", maxNoteLength)
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name, err := NormalizeTagName(tag)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	if len(normalized) > maxTagsPerEntry {
+		return errors.Errorf("tags are limited to %d per entry", maxTagsPerEntry)
+	}
+
+	t.Note = note
+	t.Tags = normalized
+	return nil
+}
+
+// Correct は記録した集中時間やタスク、メモ、タグを修正する
+func (t *Time) Correct(focusTime float64, taskID *TaskID, note string, tags []string, now time.Time) error {
+	if err := t.checkCorrectable(now); err != nil {
+		return err
+	}
+	if focusTime <= 0 {
+		return errors.New("focus time is 0 or more")
+	}
+
+	if err := t.Annotate(note, tags); err != nil {
+		return err
+	}
+	t.FocusTime = focusTime
+	t.TaskID = taskID
+	return nil
+}
+
+func (t *Time) Delete(now time.Time) error {
+	if err := t.checkCorrectable(now); err != nil {
+		return err
+	}
+
+	t.DeletedAt = &now
+	return nil
+}
+
+func (t Time) checkCorrectable(now time.Time) error {
+	if now.Sub(t.ExecutionDate) > correctionWindow {
+		return errors.Errorf("focus record can only be corrected within %s", correctionWindow)
 	}
+	return nil
 }
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// completeTolerance は端末との時刻差を考慮し、予定時間に満たなくても完了とみなす猶予
+const completeTolerance = 10 * time.Second
+
+type TimerStatus string
+
+const (
+	TimerIdle      TimerStatus = "idle"
+	TimerRunning   TimerStatus = "running"
+	TimerPaused    TimerStatus = "paused"
+	TimerCompleted TimerStatus = "completed"
+	// TimerAbandoned は予定時間の前に途中でやめた状態
+	TimerAbandoned TimerStatus = "abandoned"
+)
+
+// TimerSession はサーバー側で管理するタイマーの状態。複数端末間で共有される
+type TimerSession struct {
+	ID             TimerSessionID
+	AccountID      AccountID
+	Kind           SessionKind
+	Status         TimerStatus
+	PlannedSeconds int
+	ElapsedSeconds int
+	StartedAt      time.Time
+	ResumedAt      *time.Time
+	UpdatedAt      time.Time
+}
+
+func StartTimerSession(id TimerSessionID, accID AccountID, kind SessionKind, plannedSeconds int, now time.Time) (TimerSession, error) {
+	if plannedSeconds <= 0 {
+		return TimerSession{}, errors.New("planned seconds must be greater than 0")
+	}
+
+	return TimerSession{
+		ID:             id,
+		AccountID:      accID,
+		Kind:           kind,
+		Status:         TimerRunning,
+		PlannedSeconds: plannedSeconds,
+		StartedAt:      now,
+		ResumedAt:      &now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func RecreateTimerSession(id TimerSessionID, accID AccountID, kind SessionKind, status TimerStatus, plannedSeconds, elapsedSeconds int, startedAt time.Time, resumedAt *time.Time, updatedAt time.Time) TimerSession {
+	return TimerSession{
+		ID:             id,
+		AccountID:      accID,
+		Kind:           kind,
+		Status:         status,
+		PlannedSeconds: plannedSeconds,
+		ElapsedSeconds: elapsedSeconds,
+		StartedAt:      startedAt,
+		ResumedAt:      resumedAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// IdleTimerSession は実行中のセッションが無い状態を表す
+func IdleTimerSession(accID AccountID, now time.Time) TimerSession {
+	return TimerSession{
+		AccountID: accID,
+		Status:    TimerIdle,
+		UpdatedAt: now,
+	}
+}
+
+func (s TimerSession) Elapsed(now time.Time) time.Duration {
+	elapsed := time.Duration(s.ElapsedSeconds) * time.Second
+	if s.Status == TimerRunning && s.ResumedAt != nil {
+		elapsed += now.Sub(*s.ResumedAt)
+	}
+	return elapsed
+}
+
+func (s TimerSession) Remaining(now time.Time) time.Duration {
+	remaining := time.Duration(s.PlannedSeconds)*time.Second - s.Elapsed(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (s *TimerSession) Pause(now time.Time) error {
+	if s.Status != TimerRunning {
+		return errors.New("timer is not running")
+	}
+
+	s.ElapsedSeconds = int(s.Elapsed(now).Seconds())
+	s.Status = TimerPaused
+	s.ResumedAt = nil
+	s.UpdatedAt = now
+	return nil
+}
+
+func (s *TimerSession) Resume(now time.Time) error {
+	if s.Status != TimerPaused {
+		return errors.New("timer is not paused")
+	}
+
+	s.Status = TimerRunning
+	s.ResumedAt = &now
+	s.UpdatedAt = now
+	return nil
+}
+
+// Complete はセッションを完了し、計測した時間(分)を返す。予定時間を超えた分は計上しない
+func (s *TimerSession) Complete(now time.Time) (float64, error) {
+	if s.Status != TimerRunning && s.Status != TimerPaused {
+		return 0, errors.New("timer is not active")
+	}
+
+	if s.Remaining(now) > completeTolerance {
+		return 0, errors.New("timer has not finished yet")
+	}
+
+	elapsed := min(s.Elapsed(now), time.Duration(s.PlannedSeconds)*time.Second)
+	s.ElapsedSeconds = int(elapsed.Seconds())
+	s.Status = TimerCompleted
+	s.ResumedAt = nil
+	s.UpdatedAt = now
+	return elapsed.Minutes(), nil
+}
+
+// Abandon はセッションを途中でやめる。予定時間を終えている場合は完了させること
+func (s *TimerSession) Abandon(now time.Time) error {
+	if s.Status != TimerRunning && s.Status != TimerPaused {
+		return errors.New("timer is not active")
+	}
+
+	if s.Remaining(now) <= completeTolerance {
+		return errors.New("timer has already finished")
+	}
+
+	s.ElapsedSeconds = int(s.Elapsed(now).Seconds())
+	s.Status = TimerAbandoned
+	s.ResumedAt = nil
+	s.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type TimerSessionID string
+
+func NewTimerSessionID(s string) (TimerSessionID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid timer session id")
+	}
+
+	return TimerSessionID(id.String()), nil
+}
+
+func GenerateTimerSessionID() TimerSessionID {
+	return TimerSessionID(uuid.NewString())
+}
+
+func (t TimerSessionID) String() string {
+	return string(t)
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxWebhooksPerAccount = 10
+
+// WebhookEvents はWebhookで購読できるイベント
+var WebhookEvents = []EventName{
+	EventFocusSessionCompleted,
+	EventLevelUp,
+	EventGoalAchieved,
+	EventGoalRevoked,
+	EventKnockedOut,
+	EventRevived,
+	EventCompanionObtained,
+	EventCompanionEvolved,
+}
+
+type Webhook struct {
+	ID        WebhookID
+	AccountID AccountID
+	URL       string
+	Secret    string
+	Events    []EventName
+	CreatedAt time.Time
+}
+
+func NewWebhook(id WebhookID, accID AccountID, rawURL string, events []EventName, registered int) (Webhook, error) {
+	if registered >= maxWebhooksPerAccount {
+		return Webhook{}, errors.Newf("webhooks are limited to %d per account", maxWebhooksPerAccount)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return Webhook{}, errors.New("url must be an absolute http(s) url")
+	}
+
+	if len(events) == 0 {
+		return Webhook{}, errors.New("events is required")
+	}
+	for _, ev := range events {
+		if !slices.Contains(WebhookEvents, ev) {
+			return Webhook{}, errors.Newf("unsupported event: %s", ev)
+		}
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	events = slices.Clone(events)
+	slices.Sort(events)
+
+	return Webhook{
+		ID:        id,
+		AccountID: accID,
+		URL:       u.String(),
+		Secret:    secret,
+		Events:    slices.Compact(events),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func RecreateWebhook(id WebhookID, accID AccountID, url, secret string, events []EventName, createdAt time.Time) Webhook {
+	return Webhook{
+		ID:        id,
+		AccountID: accID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: createdAt,
+	}
+}
+
+func (w Webhook) Subscribes(name EventName) bool {
+	return slices.Contains(w.Events, name)
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return hex.EncodeToString(b), nil
+}
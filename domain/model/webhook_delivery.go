@@ -0,0 +1,96 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookMaxAttempts = 8
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID            string
+	WebhookID     WebhookID
+	EventKey      string
+	EventName     EventName
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	ResponseCode  int
+	LastError     string
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+}
+
+type webhookPayload struct {
+	ID         string      `json:"id"`
+	Event      EventName   `json:"event"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       DomainEvent `json:"data"`
+}
+
+func NewWebhookDelivery(webhookID WebhookID, ev DomainEvent) (WebhookDelivery, error) {
+	id := uuid.NewString()
+	payload, err := json.Marshal(webhookPayload{
+		ID:         id,
+		Event:      ev.EventName(),
+		OccurredAt: ev.OccurredAt(),
+		Data:       ev,
+	})
+	if err != nil {
+		return WebhookDelivery{}, errors.WithStack(err)
+	}
+
+	now := time.Now()
+	return WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventKey:      EventKey(ev),
+		EventName:     ev.EventName(),
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// EventKey はイベントを一意に識別するキーを返す。再配信されたイベントの重複排除に使う
+func EventKey(ev DomainEvent) string {
+	return string(ev.EventName()) + ":" + ev.AggregateID() + ":" + ev.OccurredAt().UTC().Format(time.RFC3339Nano)
+}
+
+func (d *WebhookDelivery) Succeed(code int, now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.ResponseCode = code
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// Fail は配信失敗を記録し、上限回数に達した場合は失敗として確定する
+func (d *WebhookDelivery) Fail(code int, err error, now time.Time) {
+	d.Attempts++
+	d.ResponseCode = code
+	d.LastError = err.Error()
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+	d.NextAttemptAt = now.Add(Backoff(d.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+}
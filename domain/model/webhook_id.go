@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type WebhookID string
+
+func NewWebhookID(s string) (WebhookID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", errors.New("invalid webhook id")
+	}
+
+	return WebhookID(id.String()), nil
+}
+
+func GenerateWebhookID() WebhookID {
+	return WebhookID(uuid.NewString())
+}
+
+func (w WebhookID) String() string {
+	return string(w)
+}
@@ -1,10 +1,13 @@
 package repository
 
-import "pomodoro-rpg-api/domain/model"
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
 
 type AccountRepository interface {
-	FindByID(id model.AccountID) (model.Account, error)
-	FindByEmail(email string) (model.Account, error)
-	Create(acc model.Account) error
-	Update(acc model.Account) error
+	FindByID(ctx context.Context, id model.AccountID) (model.Account, error)
+	FindByEmail(ctx context.Context, email string) (model.Account, error)
+	Create(ctx context.Context, acc model.Account) error
+	Update(ctx context.Context, acc model.Account) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type AuditEventFilter struct {
+	ActorID    model.AccountID
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, ev model.AuditEvent) error
+	Find(ctx context.Context, filter AuditEventFilter) ([]model.AuditEvent, error)
+}
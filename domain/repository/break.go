@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type BreakRepository interface {
+	Create(ctx context.Context, b model.Break) error
+	FindAccountIDByID(ctx context.Context, id model.BreakID) (model.AccountID, error)
+	// FindCreatedSince はsinceより後に記録された休憩を作成日時とIDの昇順で返す
+	FindCreatedSince(ctx context.Context, accID model.AccountID, since model.SyncPosition, limit int) ([]model.Break, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type CalendarFeedRepository interface {
+	FindByTokenHash(ctx context.Context, tokenHash string) (model.CalendarFeed, error)
+	// Save は既存のトークンを置き換える
+	Save(ctx context.Context, f model.CalendarFeed) error
+	DeleteByAccountID(ctx context.Context, accID model.AccountID) error
+}
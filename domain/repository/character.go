@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type CharacterRepository interface {
+	// FindByAccountIDForUpdate はトランザクション内で行ロックを取得して取得する
+	FindByAccountIDForUpdate(ctx context.Context, accID model.AccountID) (model.Character, error)
+	FindByAccountID(ctx context.Context, accID model.AccountID) (model.Character, error)
+	Save(ctx context.Context, c model.Character) error
+	AddSkill(ctx context.Context, accID model.AccountID, key string) error
+}
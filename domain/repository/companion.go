@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type CompanionRepository interface {
+	FindByID(ctx context.Context, id model.CompanionID) (model.Companion, error)
+	FindByIDForUpdate(ctx context.Context, id model.CompanionID) (model.Companion, error)
+	FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Companion, error)
+	FindActive(ctx context.Context, accID model.AccountID) (model.Companion, error)
+	FindActiveForUpdate(ctx context.Context, accID model.AccountID) (model.Companion, error)
+	// Create は同じ種族をすでに仲間にしている場合は何もせずfalseを返す
+	Create(ctx context.Context, c model.Companion) (bool, error)
+	Update(ctx context.Context, c model.Companion) error
+	// Activate はidの仲間を連れて歩き、それ以外の仲間を外す
+	Activate(ctx context.Context, accID model.AccountID, id model.CompanionID) error
+}
+
+type CompanionTrainingRepository interface {
+	// Create は同じ記録で育成済みの場合は何もせずfalseを返す
+	Create(ctx context.Context, t model.CompanionTraining) (bool, error)
+	FindByTimeID(ctx context.Context, timeID model.TimeID) (model.CompanionTraining, error)
+	Update(ctx context.Context, t model.CompanionTraining) error
+	Delete(ctx context.Context, timeID model.TimeID) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type CycleRepository interface {
+	// FindByAccountIDForUpdate はトランザクション内で行ロックを取得して返す
+	FindByAccountIDForUpdate(ctx context.Context, accID model.AccountID) (model.Cycle, error)
+	FindByAccountID(ctx context.Context, accID model.AccountID) (model.Cycle, error)
+	Save(ctx context.Context, c model.Cycle) error
+}
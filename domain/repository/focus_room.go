@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type FocusRoomRepository interface {
+	FindByID(ctx context.Context, id model.FocusRoomID) (model.FocusRoom, error)
+	FindByIDForUpdate(ctx context.Context, id model.FocusRoomID) (model.FocusRoom, error)
+	FindByCodeForUpdate(ctx context.Context, code string) (model.FocusRoom, error)
+	// Create はコードが他の部屋と重複する場合はfalseを返す
+	Create(ctx context.Context, r model.FocusRoom) (bool, error)
+	Update(ctx context.Context, r model.FocusRoom) error
+	// Notify は他のAPIレプリカを含め、部屋を購読しているクライアントに状態の変更を通知する。
+	// トランザクション内で呼び出した場合はコミット時に通知される
+	Notify(ctx context.Context, id model.FocusRoomID) error
+}
+
+type FocusRoomParticipantRepository interface {
+	// FindByRoomID は参加の古い順に返す
+	FindByRoomID(ctx context.Context, roomID model.FocusRoomID) ([]model.FocusRoomParticipant, error)
+	Find(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID) (model.FocusRoomParticipant, error)
+	// FindUncompletedByAccountID はまだ最後まで終えていない参加を返す。同時に参加できる部屋は1つのみ
+	FindUncompletedByAccountID(ctx context.Context, accID model.AccountID) (model.FocusRoomParticipant, error)
+	Count(ctx context.Context, roomID model.FocusRoomID) (int, error)
+	// Create は既にこの部屋に参加しているか、他の部屋を最後まで終えていない場合はfalseを返す
+	Create(ctx context.Context, p model.FocusRoomParticipant) (bool, error)
+	// Complete は未完了の参加者を完了にし、既に完了していればfalseを返す
+	Complete(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID, at time.Time) (bool, error)
+	Delete(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID) error
+}
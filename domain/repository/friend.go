@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type FriendRequestRepository interface {
+	FindByID(ctx context.Context, id model.FriendRequestID) (model.FriendRequest, error)
+	// FindByRequester は申請者から見た申請を新しい順に返す。断られた申請も含む
+	FindByRequester(ctx context.Context, requesterID model.AccountID) ([]model.FriendRequest, error)
+	// FindPendingByAddressee は宛先のアカウントが応答していない申請を新しい順に返す
+	FindPendingByAddressee(ctx context.Context, addresseeID model.AccountID) ([]model.FriendRequest, error)
+	// FindPendingBetween はrequesterからaddresseeへの応答待ちの申請を返す
+	FindPendingBetween(ctx context.Context, requesterID, addresseeID model.AccountID) (model.FriendRequest, error)
+	// Create は同じ宛先への応答待ちの申請が既にあれば何もしない
+	Create(ctx context.Context, r model.FriendRequest) error
+	// LinkAddressee は登録前のメールアドレスに届いていた応答待ちの申請を、登録したアカウントに紐づける
+	LinkAddressee(ctx context.Context, email string, addresseeID model.AccountID) error
+	Update(ctx context.Context, r model.FriendRequest) error
+	Delete(ctx context.Context, id model.FriendRequestID) error
+	// DeleteBetween は2つのアカウント間の申請を向きに関わらず全て削除する
+	DeleteBetween(ctx context.Context, a, b model.AccountID) error
+}
+
+type FriendshipRepository interface {
+	FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Friendship, error)
+	Count(ctx context.Context, accID model.AccountID) (int, error)
+	Exists(ctx context.Context, accID, friendID model.AccountID) (bool, error)
+	// Create は双方向の関係として保存する
+	Create(ctx context.Context, f model.Friendship) error
+	Delete(ctx context.Context, accID, friendID model.AccountID) error
+}
+
+type BlockRepository interface {
+	FindByBlockerID(ctx context.Context, blockerID model.AccountID) ([]model.Block, error)
+	// ExistsBetween はどちらかがもう一方をブロックしているかを返す
+	ExistsBetween(ctx context.Context, a, b model.AccountID) (bool, error)
+	// Create は既にブロックしていれば何もしない
+	Create(ctx context.Context, b model.Block) error
+	Delete(ctx context.Context, blockerID, blockedID model.AccountID) error
+}
+
+type PrivacyRepository interface {
+	FindByAccountID(ctx context.Context, accID model.AccountID) (model.Privacy, error)
+	Save(ctx context.Context, p model.Privacy) error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type GoalRepository interface {
+	FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Goal, error)
+	// Save は同じ期間の目標があれば置き換える
+	Save(ctx context.Context, g model.Goal) error
+	Delete(ctx context.Context, accID model.AccountID, period model.GoalPeriod) error
+}
+
+type GoalAchievementFilter struct {
+	// Period が空の場合は全ての期間を対象とする
+	Period model.GoalPeriod
+	Limit  int
+	Offset int
+}
+
+type GoalAchievementRepository interface {
+	// Create は同じ期間の達成記録が既にあれば何もせずfalseを返す
+	Create(ctx context.Context, a model.GoalAchievement) (bool, error)
+	FindByPeriodStart(ctx context.Context, accID model.AccountID, period model.GoalPeriod, start time.Time) (model.GoalAchievement, error)
+	// Delete は達成記録を取り消し、既に取り消されていればfalseを返す
+	Delete(ctx context.Context, a model.GoalAchievement) (bool, error)
+	// Find は達成日時の新しい順に返す
+	Find(ctx context.Context, accID model.AccountID, filter GoalAchievementFilter) ([]model.GoalAchievement, error)
+}
+
+type GoalMissRepository interface {
+	// Create は同じ期間の未達成の記録が既にあれば何もせずfalseを返す
+	Create(ctx context.Context, m model.GoalMiss) (bool, error)
+	// FindMissedDaily は利用者のタイムゾーンでnowの前日を対象に、未達成でまだ判定していない日次目標の進捗を返す
+	FindMissedDaily(ctx context.Context, now time.Time) ([]model.GoalProgress, error)
+	// TryLock はトランザクションの終了まで未達成の判定を排他し、他で判定中であればfalseを返す。トランザクション内で呼び出す
+	TryLock(ctx context.Context) (bool, error)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type GuildRepository interface {
+	FindByID(ctx context.Context, id model.GuildID) (model.Guild, error)
+	// FindByIDForUpdate はメンバーの加入やレイドの開始を直列化するためにギルドをロックする
+	FindByIDForUpdate(ctx context.Context, id model.GuildID) (model.Guild, error)
+	// Search は名前に部分一致するギルドをメンバー数とともに作成が新しい順に返す
+	Search(ctx context.Context, query string, limit, offset int) ([]model.GuildListing, error)
+	Create(ctx context.Context, g model.Guild) error
+	Update(ctx context.Context, g model.Guild) error
+	// Delete はメンバーやレイドの記録もまとめて削除する
+	Delete(ctx context.Context, id model.GuildID) error
+}
+
+type GuildMembershipRepository interface {
+	FindByAccountID(ctx context.Context, accID model.AccountID) (model.GuildMembership, error)
+	// FindByGuildID は役割の強い順、加入の古い順に返す
+	FindByGuildID(ctx context.Context, guildID model.GuildID) ([]model.GuildMembership, error)
+	Count(ctx context.Context, guildID model.GuildID) (int, error)
+	Create(ctx context.Context, m model.GuildMembership) error
+	Update(ctx context.Context, m model.GuildMembership) error
+	Delete(ctx context.Context, guildID model.GuildID, accID model.AccountID) error
+}
+
+type RaidRepository interface {
+	FindByID(ctx context.Context, id model.RaidID) (model.Raid, error)
+	// FindLatestByGuildID は最後に開始したレイドを返す
+	FindLatestByGuildID(ctx context.Context, guildID model.GuildID) (model.Raid, error)
+	// FindActiveByGuildIDForUpdate は記録上挑戦中のレイドをロックして返す
+	FindActiveByGuildIDForUpdate(ctx context.Context, guildID model.GuildID) (model.Raid, error)
+	FindByIDForUpdate(ctx context.Context, id model.RaidID) (model.Raid, error)
+	// FindByGuildID は開始が新しい順に返す
+	FindByGuildID(ctx context.Context, guildID model.GuildID, limit, offset int) ([]model.Raid, error)
+	Create(ctx context.Context, r model.Raid) error
+	Update(ctx context.Context, r model.Raid) error
+}
+
+type RaidContributionRepository interface {
+	// Create は同じ記録が既に数えられていればfalseを返す
+	Create(ctx context.Context, c model.RaidContribution) (bool, error)
+	FindByTimeID(ctx context.Context, timeID model.TimeID) ([]model.RaidContribution, error)
+	Update(ctx context.Context, c model.RaidContribution) error
+	Delete(ctx context.Context, c model.RaidContribution) error
+	// Contributors はメンバーごとの合計ダメージを多い順に返す
+	Contributors(ctx context.Context, raidID model.RaidID) ([]model.RaidContributor, error)
+}
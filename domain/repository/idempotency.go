@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	// Reserve は同じキーの記録が無いか期限切れの場合に保存してtrueを返す
+	Reserve(ctx context.Context, r model.IdempotencyRecord) (bool, error)
+	Find(ctx context.Context, scope, key string) (model.IdempotencyRecord, error)
+	Complete(ctx context.Context, r model.IdempotencyRecord) error
+	Delete(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type LeaderboardRepository interface {
+	// Refresh はstartから始まる期間のスナップショットを集計し直す。endがゼロ値の場合は全期間を集計する。
+	// 複数のプロセスから同時に呼び出されても、同じランキングの集計は直列に行う
+	Refresh(ctx context.Context, board model.Leaderboard, start, end time.Time) error
+	// FindTop は順位の高い順にlimit件返す
+	FindTop(ctx context.Context, board model.Leaderboard, start time.Time, limit int) ([]model.LeaderboardEntry, error)
+	// FindByAccountIDs は指定したアカウントの順位をスコアの降順で返す。ランキングに載っていないアカウントは含まない
+	FindByAccountIDs(ctx context.Context, board model.Leaderboard, start time.Time, accIDs []model.AccountID) ([]model.LeaderboardEntry, error)
+}
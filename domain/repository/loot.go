@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+// LootTableRepository は管理者が編集するデータファイルからドロップテーブルを読み込む
+type LootTableRepository interface {
+	Load(ctx context.Context) ([]model.LootTable, error)
+}
+
+// LootTableVersionRepository は抽選結果を後から検証できるよう、使用したバージョンのテーブルを保存する
+type LootTableVersionRepository interface {
+	// Save は同じバージョンが保存済みであれば何もしない
+	Save(ctx context.Context, t model.LootTable) error
+	Find(ctx context.Context, key, version string) (model.LootTable, error)
+}
+
+type LootPityRepository interface {
+	FindForUpdate(ctx context.Context, accID model.AccountID, tableKey string) (model.LootPity, error)
+	Save(ctx context.Context, p model.LootPity) error
+}
+
+type LootDropRepository interface {
+	Create(ctx context.Context, d model.LootDrop) error
+	FindByID(ctx context.Context, id model.LootDropID) (model.LootDrop, error)
+	FindBySourceID(ctx context.Context, accID model.AccountID, sourceID string) ([]model.LootDrop, error)
+	// FindLater はdropより後に同じテーブルで抽選したドロップを古い順に返す
+	FindLater(ctx context.Context, drop model.LootDrop) ([]model.LootDrop, error)
+	Delete(ctx context.Context, id model.LootDropID) error
+	// FindByAccountID は新しい順に返す
+	FindByAccountID(ctx context.Context, accID model.AccountID, limit, offset int) ([]model.LootDrop, error)
+	Inventory(ctx context.Context, accID model.AccountID) ([]model.InventoryItem, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type OutboxRepository interface {
+	Save(ctx context.Context, events ...model.DomainEvent) error
+	// Claim は配信待ちのメッセージを最大limit件取得し、lease の間は他のワーカーから取得されないようにする
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error)
+	MarkDispatched(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, msg model.OutboxMessage) error
+}
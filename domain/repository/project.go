@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type ProjectRepository interface {
+	FindByID(ctx context.Context, id model.ProjectID) (model.Project, error)
+	FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Project, error)
+	Save(ctx context.Context, p model.Project) error
+	Delete(ctx context.Context, id model.ProjectID) error
+}
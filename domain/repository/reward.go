@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type RewardLedgerRepository interface {
+	Create(ctx context.Context, e model.RewardEntry) error
+	// SumBySourceID は取り消しうる報酬について付与済みの合計を返す。台帳に記録がなければErrDataNotFoundを返す
+	SumBySourceID(ctx context.Context, accID model.AccountID, sourceID string) (model.Reward, error)
+}
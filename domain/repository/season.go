@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+// SeasonRepository は管理者が編集するデータファイルからシーズンを読み込む
+type SeasonRepository interface {
+	Load(ctx context.Context) ([]model.Season, error)
+}
+
+type SeasonActivityRepository interface {
+	// Create は同じ活動を記録済みの場合は何もせずfalseを返す
+	Create(ctx context.Context, a model.SeasonActivity) (bool, error)
+	// Tally は集中の活動を元の記録の現在の内容で数え、削除された記録や取り消された目標の達成は含めない
+	Tally(ctx context.Context, seasonKey string, accID model.AccountID) (model.SeasonTally, error)
+}
+
+type SeasonPassRepository interface {
+	Find(ctx context.Context, seasonKey string, accID model.AccountID) (model.SeasonPass, error)
+	// Create は購入済みの場合は何もせずfalseを返す
+	Create(ctx context.Context, p model.SeasonPass) (bool, error)
+}
+
+type SeasonClaimRepository interface {
+	Find(ctx context.Context, seasonKey string, accID model.AccountID) ([]model.SeasonClaim, error)
+	// Create は受け取り済みの場合は何もせずfalseを返す
+	Create(ctx context.Context, c model.SeasonClaim) (bool, error)
+}
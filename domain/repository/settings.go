@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type SettingsRepository interface {
+	FindByAccountID(ctx context.Context, accID model.AccountID) (model.Settings, error)
+	Save(ctx context.Context, s model.Settings) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type StatisticsRepository interface {
+	// Daily は[from, to)の集中時間と休憩時間をloc基準の日付ごとに集計する
+	Daily(ctx context.Context, accID model.AccountID, from, to time.Time, loc *time.Location) ([]model.DailyStat, error)
+	// DailyFocus は[from, to)の集中時間のみをloc基準の日付ごとに集計する
+	DailyFocus(ctx context.Context, accID model.AccountID, from, to time.Time, loc *time.Location) ([]model.DailyStat, error)
+	// FocusMinutes は[from, to)の集中時間の合計を返す
+	FocusMinutes(ctx context.Context, accID model.AccountID, from, to time.Time) (float64, error)
+	TaskTotals(ctx context.Context, accID model.AccountID) ([]model.TaskTotal, error)
+	ProjectTotals(ctx context.Context, accID model.AccountID) ([]model.ProjectTotal, error)
+	TagTotals(ctx context.Context, accID model.AccountID) ([]model.TagTotal, error)
+}
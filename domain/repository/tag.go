@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type TagRepository interface {
+	// Suggest は前方一致するタグを利用回数の多い順に返す
+	Suggest(ctx context.Context, accID model.AccountID, prefix string, limit int) ([]model.Tag, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type TaskRepository interface {
+	FindByID(ctx context.Context, id model.TaskID) (model.Task, error)
+	// FindByAccountID はprojectIDがnilでない場合、そのプロジェクトのタスクに絞り込む
+	FindByAccountID(ctx context.Context, accID model.AccountID, projectID *model.ProjectID) ([]model.Task, error)
+	Save(ctx context.Context, t model.Task) error
+	Delete(ctx context.Context, id model.TaskID) error
+}
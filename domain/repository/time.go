@@ -1,8 +1,35 @@
 package repository
 
-import "pomodoro-rpg-api/domain/model"
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type TimeFilter struct {
+	// Tag は正規化済みのタグ名
+	Tag    string
+	Limit  int
+	Offset int
+}
 
 type TimeRepository interface {
-	GetAll(accID model.AccountID) ([]model.Time, error)
-	Create(t model.Time) error
+	Find(ctx context.Context, accID model.AccountID, filter TimeFilter) ([]model.Time, error)
+	// FindByIDForUpdate はトランザクション内で行ロックを取得して取得する。削除済みの記録は含まない
+	FindByIDForUpdate(ctx context.Context, id model.TimeID) (model.Time, error)
+	// FindAccountIDByID は削除済みの記録も含めて所有者を返す
+	FindAccountIDByID(ctx context.Context, id model.TimeID) (model.AccountID, error)
+	// FindChangedSince はsinceより後に作成・更新・削除された記録を更新日時とIDの昇順で返す
+	FindChangedSince(ctx context.Context, accID model.AccountID, since model.SyncPosition, limit int) ([]model.Time, error)
+	// ExistsOverlapping は削除済みとt自身を除き、tの開始日時から実施日時までと重なる記録があるかを返す。
+	// トランザクション内で呼び出し、同じアカウントの確認と記録をトランザクションの終了まで直列化する
+	ExistsOverlapping(ctx context.Context, t model.Time) (bool, error)
+	// LatestUpdatedAt は削除済みの記録も含めて最後に作成・更新・削除された日時を返す。記録がなければゼロ値を返す
+	LatestUpdatedAt(ctx context.Context, accID model.AccountID) (time.Time, error)
+	// Create はタグが未登録であればアカウントのタグとして登録してから紐付ける
+	Create(ctx context.Context, t model.Time) error
+	// Update はタグの紐付けも置き換える
+	Update(ctx context.Context, t model.Time) error
+	// Delete は論理削除する
+	Delete(ctx context.Context, t model.Time) error
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+)
+
+type TimerSessionRepository interface {
+	FindActiveByAccountID(ctx context.Context, accID model.AccountID) (model.TimerSession, error)
+	Save(ctx context.Context, s model.TimerSession) error
+	// Notify は他のAPIレプリカを含め、接続中のクライアントに状態の変更を通知する。
+	// トランザクション内で呼び出した場合はコミット時に通知される
+	Notify(ctx context.Context, s model.TimerSession) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// Transaction はfnを1つのトランザクション内で実行する。
+// fnがエラーを返すかpanicした場合はロールバックし、それ以外はコミットする。
+// fnに渡されるcontextを各リポジトリに渡すことで同じトランザクションが使われる。
+// 既にトランザクション内で呼ばれた場合は新たに開始せず外側のトランザクションに参加するため、
+// 内側のエラーは外側全体のロールバックとなる。
+type Transaction interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type WebhookRepository interface {
+	FindByID(ctx context.Context, id model.WebhookID) (model.Webhook, error)
+	FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Webhook, error)
+	Create(ctx context.Context, w model.Webhook) error
+	Delete(ctx context.Context, id model.WebhookID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create は同じWebhookに同じイベントが既に登録されている場合は何もしない
+	Create(ctx context.Context, deliveries ...model.WebhookDelivery) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	Update(ctx context.Context, d model.WebhookDelivery) error
+	FindByWebhookID(ctx context.Context, id model.WebhookID, limit int) ([]model.WebhookDelivery, error)
+}
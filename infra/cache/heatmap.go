@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"sync"
+	"time"
+)
+
+// maxHeatmapAccounts を超えた場合は、メモリを圧迫しないよう任意のアカウントのキャッシュを破棄する
+const maxHeatmapAccounts = 10000
+
+type heatmapKey struct {
+	year     int
+	location string
+}
+
+type heatmapEntry struct {
+	// version は集計時点での集中時間の最終更新日時。これが変わったらキャッシュは無効になる
+	version time.Time
+	days    map[heatmapKey][]model.HeatmapDay
+}
+
+// Heatmap はアカウントごとにヒートマップをプロセス内でキャッシュする
+type Heatmap struct {
+	mu      sync.Mutex
+	entries map[model.AccountID]*heatmapEntry
+}
+
+func NewHeatmap() *Heatmap {
+	return &Heatmap{
+		entries: map[model.AccountID]*heatmapEntry{},
+	}
+}
+
+// Get はversion時点で集計されたキャッシュがあれば返す
+func (c *Heatmap) Get(accID model.AccountID, version time.Time, year int, loc *time.Location) ([]model.HeatmapDay, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[accID]
+	if !ok || !e.version.Equal(version) {
+		return nil, false
+	}
+
+	days, ok := e.days[heatmapKey{year, loc.String()}]
+	return days, ok
+}
+
+// Set はversionが変わっていれば、そのアカウントの古いキャッシュを全て破棄してから保存する
+func (c *Heatmap) Set(accID model.AccountID, version time.Time, year int, loc *time.Location, days []model.HeatmapDay) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[accID]
+	if !ok || !e.version.Equal(version) {
+		if !ok && len(c.entries) >= maxHeatmapAccounts {
+			for id := range c.entries {
+				delete(c.entries, id)
+				break
+			}
+		}
+		e = &heatmapEntry{version: version, days: map[heatmapKey][]model.HeatmapDay{}}
+		c.entries[accID] = e
+	}
+
+	e.days[heatmapKey{year, loc.String()}] = days
+}
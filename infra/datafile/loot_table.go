@@ -0,0 +1,58 @@
+package datafile
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+)
+
+type lootTableFile struct {
+	dir string
+}
+
+// Load はディレクトリ内の*.jsonをファイル名順に読み込む。1つでも不正なファイルがあればエラーにする
+func (f *lootTableFile) Load(ctx context.Context) ([]model.LootTable, error) {
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sort.Strings(paths)
+
+	keys := make(map[string]struct{}, len(paths))
+	res := make([]model.LootTable, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		var def entity.LootTableDefinition
+		if err := json.Unmarshal(b, &def); err != nil {
+			return nil, errors.Wrapf(err, "parse %s", path)
+		}
+
+		t, err := def.ToModel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "load %s", path)
+		}
+		if _, ok := keys[t.Key]; ok {
+			return nil, errors.Newf("duplicate loot table key %s in %s", t.Key, path)
+		}
+		keys[t.Key] = struct{}{}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func NewLootTableFile(dir string) repository.LootTableRepository {
+	return &lootTableFile{dir}
+}
@@ -0,0 +1,69 @@
+package datafile
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+)
+
+type seasonFile struct {
+	dir string
+}
+
+// Load はディレクトリ内の*.jsonを開始日時の順に並べて返す。1つでも不正なファイルがあればエラーにする
+func (f *seasonFile) Load(ctx context.Context) ([]model.Season, error) {
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sort.Strings(paths)
+
+	keys := make(map[string]struct{}, len(paths))
+	bosses := make(map[string]struct{})
+	res := make([]model.Season, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		var def entity.SeasonDefinition
+		if err := json.Unmarshal(b, &def); err != nil {
+			return nil, errors.Wrapf(err, "parse %s", path)
+		}
+
+		s, err := def.ToModel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "load %s", path)
+		}
+		if _, ok := keys[s.Key]; ok {
+			return nil, errors.Newf("duplicate season key %s in %s", s.Key, path)
+		}
+		keys[s.Key] = struct{}{}
+		// シーズンが終わった後もレイドの記録からボスを引けるよう、シーズンをまたいでキーを一意にする
+		for _, boss := range s.Bosses {
+			if _, ok := bosses[boss.Key]; ok {
+				return nil, errors.Newf("duplicate season boss key %s in %s", boss.Key, path)
+			}
+			bosses[boss.Key] = struct{}{}
+		}
+
+		res = append(res, s)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].StartsAt.Before(res[j].StartsAt)
+	})
+	return res, nil
+}
+
+func NewSeasonFile(dir string) repository.SeasonRepository {
+	return &seasonFile{dir}
+}
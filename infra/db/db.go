@@ -13,7 +13,7 @@ import (
 )
 
 func NewDB(cfg *config.DBConfig) (*gorm.DB, error) {
-	dsn := genDSN(cfg)
+	dsn := DSN(cfg)
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -44,7 +44,7 @@ func Migration(db *gorm.DB, cfg *config.DBConfig) error {
 	return nil
 }
 
-func genDSN(cfg *config.DBConfig) string {
+func DSN(cfg *config.DBConfig) string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
 }
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"encoding/json"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type AuditEvent struct {
+	ID         string `gorm:"primaryKey"`
+	ActorID    *string
+	Action     string `gorm:"not null"`
+	TargetType string `gorm:"not null"`
+	TargetID   string
+	RequestID  string
+	IP         string
+	Diff       string    `gorm:"type:jsonb"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func ToAuditEventEntity(ev model.AuditEvent) (AuditEvent, error) {
+	diff, err := json.Marshal(ev.Diff)
+	if err != nil {
+		return AuditEvent{}, errors.WithStack(err)
+	}
+
+	var actorID *string
+	if ev.ActorID != "" {
+		id := ev.ActorID.String()
+		actorID = &id
+	}
+
+	return AuditEvent{
+		ID:         ev.ID.String(),
+		ActorID:    actorID,
+		Action:     string(ev.Action),
+		TargetType: string(ev.TargetType),
+		TargetID:   ev.TargetID,
+		RequestID:  ev.RequestID,
+		IP:         ev.IP,
+		Diff:       string(diff),
+		CreatedAt:  ev.CreatedAt,
+	}, nil
+}
+
+func (e AuditEvent) ToModel() (model.AuditEvent, error) {
+	var diff model.AuditDiff
+	if err := json.Unmarshal([]byte(e.Diff), &diff); err != nil {
+		return model.AuditEvent{}, errors.WithStack(err)
+	}
+
+	var actorID model.AccountID
+	if e.ActorID != nil {
+		actorID = model.AccountID(*e.ActorID)
+	}
+
+	return model.RecreateAuditEvent(
+		model.AuditEventID(e.ID),
+		actorID,
+		model.AuditAction(e.Action),
+		model.AuditTargetType(e.TargetType),
+		e.TargetID,
+		e.RequestID,
+		e.IP,
+		diff,
+		e.CreatedAt,
+	), nil
+}
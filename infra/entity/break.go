@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Break struct {
+	ID            string    `gorm:"primaryKey"`
+	AccountID     string    `gorm:"not null"`
+	Kind          string    `gorm:"not null"`
+	Minutes       float64   `gorm:"not null"`
+	ExecutionDate time.Time `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToBreakEntity(b model.Break) Break {
+	return Break{
+		ID:            b.ID.String(),
+		AccountID:     b.AccountID.String(),
+		Kind:          string(b.Kind),
+		Minutes:       b.Minutes,
+		ExecutionDate: b.ExecutionDate,
+	}
+}
+
+func (e Break) ToModel() model.Break {
+	return model.RecreateBreak(
+		model.BreakID(e.ID),
+		model.AccountID(e.AccountID),
+		model.SessionKind(e.Kind),
+		e.Minutes,
+		e.ExecutionDate,
+		e.CreatedAt,
+	)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type CalendarFeed struct {
+	AccountID string    `gorm:"primaryKey"`
+	TokenHash string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToCalendarFeedEntity(f model.CalendarFeed) CalendarFeed {
+	return CalendarFeed{
+		AccountID: f.AccountID.String(),
+		TokenHash: f.TokenHash,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func (e CalendarFeed) ToModel() model.CalendarFeed {
+	return model.RecreateCalendarFeed(model.AccountID(e.AccountID), e.TokenHash, e.CreatedAt)
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Character struct {
+	AccountID string `gorm:"primaryKey"`
+	Level     int    `gorm:"not null"`
+	Exp       int    `gorm:"not null"`
+	Gold      int    `gorm:"not null"`
+	Class     string `gorm:"not null"`
+	// HP はHPの導入前に作られたキャラクターではNULL
+	HP         *int
+	KnockedOut bool      `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToCharacterEntity(c model.Character) Character {
+	return Character{
+		AccountID:  c.AccountID.String(),
+		Level:      c.Level,
+		Exp:        c.Exp,
+		Gold:       c.Gold,
+		Class:      string(c.Class),
+		HP:         &c.HP,
+		KnockedOut: c.KnockedOut,
+	}
+}
+
+func (e Character) ToModel(skills []string) model.Character {
+	return model.RecreateCharacter(model.AccountID(e.AccountID), e.Level, e.Exp, e.Gold, model.CharacterClass(e.Class), skills, e.HP, e.KnockedOut)
+}
+
+type CharacterSkill struct {
+	AccountID string `gorm:"primaryKey"`
+	SkillKey  string `gorm:"primaryKey"`
+	LearnedAt time.Time
+}
+
+func ToCharacterSkillEntity(accID model.AccountID, key string, learnedAt time.Time) CharacterSkill {
+	return CharacterSkill{
+		AccountID: accID.String(),
+		SkillKey:  key,
+		LearnedAt: learnedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Companion struct {
+	ID         string    `gorm:"primaryKey"`
+	AccountID  string    `gorm:"not null"`
+	Species    string    `gorm:"not null"`
+	Nickname   string    `gorm:"not null"`
+	Exp        int       `gorm:"not null"`
+	Stage      int       `gorm:"not null"`
+	Active     bool      `gorm:"not null"`
+	ObtainedAt time.Time `gorm:"not null"`
+}
+
+func ToCompanionEntity(c model.Companion) Companion {
+	return Companion{
+		ID:         c.ID.String(),
+		AccountID:  c.AccountID.String(),
+		Species:    c.Species,
+		Nickname:   c.Nickname,
+		Exp:        c.Exp,
+		Stage:      c.Stage,
+		Active:     c.Active,
+		ObtainedAt: c.ObtainedAt,
+	}
+}
+
+func (e Companion) ToModel() model.Companion {
+	return model.RecreateCompanion(
+		model.CompanionID(e.ID),
+		model.AccountID(e.AccountID),
+		e.Species,
+		e.Nickname,
+		e.Exp,
+		e.Stage,
+		e.Active,
+		e.ObtainedAt,
+	)
+}
+
+type CompanionTraining struct {
+	TimeID      string    `gorm:"primaryKey"`
+	CompanionID string    `gorm:"not null"`
+	Minutes     float64   `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+func ToCompanionTrainingEntity(t model.CompanionTraining) CompanionTraining {
+	return CompanionTraining{
+		TimeID:      t.TimeID.String(),
+		CompanionID: t.CompanionID.String(),
+		Minutes:     t.Minutes,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func (e CompanionTraining) ToModel() model.CompanionTraining {
+	return model.RecreateCompanionTraining(
+		model.CompanionID(e.CompanionID),
+		model.TimeID(e.TimeID),
+		e.Minutes,
+		e.CreatedAt,
+	)
+}
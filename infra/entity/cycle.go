@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type PomodoroCycle struct {
+	AccountID       string    `gorm:"primaryKey"`
+	FocusCount      int       `gorm:"not null"`
+	CompletedCycles int       `gorm:"not null"`
+	LastKind        string    `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToPomodoroCycleEntity(c model.Cycle) PomodoroCycle {
+	return PomodoroCycle{
+		AccountID:       c.AccountID.String(),
+		FocusCount:      c.FocusCount,
+		CompletedCycles: c.CompletedCycles,
+		LastKind:        string(c.LastKind),
+	}
+}
+
+func (e PomodoroCycle) ToModel() model.Cycle {
+	return model.RecreateCycle(
+		model.AccountID(e.AccountID),
+		e.FocusCount,
+		e.CompletedCycles,
+		model.SessionKind(e.LastKind),
+	)
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+// FocusRoomChannel は部屋の状態変更を通知するPostgresのチャネル名。ペイロードは部屋のID
+const FocusRoomChannel = "focus_rooms"
+
+type FocusRoom struct {
+	ID             string `gorm:"primaryKey"`
+	Code           string `gorm:"not null"`
+	HostID         string `gorm:"not null"`
+	Status         string `gorm:"not null"`
+	PlannedSeconds int    `gorm:"not null"`
+	ElapsedSeconds int    `gorm:"not null"`
+	StartedAt      *time.Time
+	ResumedAt      *time.Time
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time
+}
+
+func ToFocusRoomEntity(r model.FocusRoom) FocusRoom {
+	return FocusRoom{
+		ID:             r.ID.String(),
+		Code:           r.Code,
+		HostID:         r.HostID.String(),
+		Status:         string(r.Status),
+		PlannedSeconds: r.PlannedSeconds,
+		ElapsedSeconds: r.ElapsedSeconds,
+		StartedAt:      r.StartedAt,
+		ResumedAt:      r.ResumedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+func (e FocusRoom) ToModel() model.FocusRoom {
+	return model.RecreateFocusRoom(
+		model.FocusRoomID(e.ID),
+		e.Code,
+		model.AccountID(e.HostID),
+		model.FocusRoomStatus(e.Status),
+		e.PlannedSeconds,
+		e.ElapsedSeconds,
+		e.StartedAt,
+		e.ResumedAt,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+}
+
+type FocusRoomParticipant struct {
+	RoomID      string    `gorm:"primaryKey"`
+	AccountID   string    `gorm:"primaryKey"`
+	JoinedAt    time.Time `gorm:"not null"`
+	CompletedAt *time.Time
+}
+
+func ToFocusRoomParticipantEntity(p model.FocusRoomParticipant) FocusRoomParticipant {
+	return FocusRoomParticipant{
+		RoomID:      p.RoomID.String(),
+		AccountID:   p.AccountID.String(),
+		JoinedAt:    p.JoinedAt,
+		CompletedAt: p.CompletedAt,
+	}
+}
+
+func (e FocusRoomParticipant) ToModel() model.FocusRoomParticipant {
+	return model.RecreateFocusRoomParticipant(model.FocusRoomID(e.RoomID), model.AccountID(e.AccountID), e.JoinedAt, e.CompletedAt)
+}
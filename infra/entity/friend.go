@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type FriendRequest struct {
+	ID             string `gorm:"primaryKey"`
+	RequesterID    string `gorm:"not null"`
+	AddresseeEmail string `gorm:"not null"`
+	AddresseeID    *string
+	Status         string    `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToFriendRequestEntity(r model.FriendRequest) FriendRequest {
+	var addresseeID *string
+	if r.AddresseeID != nil {
+		id := r.AddresseeID.String()
+		addresseeID = &id
+	}
+
+	return FriendRequest{
+		ID:             r.ID.String(),
+		RequesterID:    r.RequesterID.String(),
+		AddresseeEmail: r.AddresseeEmail,
+		AddresseeID:    addresseeID,
+		Status:         string(r.Status),
+		CreatedAt:      r.CreatedAt,
+	}
+}
+
+func (e FriendRequest) ToModel() model.FriendRequest {
+	var addresseeID *model.AccountID
+	if e.AddresseeID != nil {
+		id := model.AccountID(*e.AddresseeID)
+		addresseeID = &id
+	}
+
+	return model.RecreateFriendRequest(
+		model.FriendRequestID(e.ID),
+		model.AccountID(e.RequesterID),
+		e.AddresseeEmail,
+		addresseeID,
+		model.FriendRequestStatus(e.Status),
+		e.CreatedAt,
+	)
+}
+
+type Friendship struct {
+	AccountID string    `gorm:"primaryKey"`
+	FriendID  string    `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (e Friendship) ToModel() model.Friendship {
+	return model.RecreateFriendship(model.AccountID(e.AccountID), model.AccountID(e.FriendID), e.CreatedAt)
+}
+
+type AccountBlock struct {
+	BlockerID string    `gorm:"primaryKey"`
+	BlockedID string    `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func ToAccountBlockEntity(b model.Block) AccountBlock {
+	return AccountBlock{
+		BlockerID: b.BlockerID.String(),
+		BlockedID: b.BlockedID.String(),
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func (e AccountBlock) ToModel() model.Block {
+	return model.RecreateBlock(model.AccountID(e.BlockerID), model.AccountID(e.BlockedID), e.CreatedAt)
+}
+
+type PrivacySetting struct {
+	AccountID        string    `gorm:"primaryKey"`
+	ShareLevel       bool      `gorm:"not null"`
+	ShareStreak      bool      `gorm:"not null"`
+	ShareWeeklyFocus bool      `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToPrivacySettingEntity(p model.Privacy) PrivacySetting {
+	return PrivacySetting{
+		AccountID:        p.AccountID.String(),
+		ShareLevel:       p.ShareLevel,
+		ShareStreak:      p.ShareStreak,
+		ShareWeeklyFocus: p.ShareWeeklyFocus,
+	}
+}
+
+func (e PrivacySetting) ToModel() model.Privacy {
+	return model.NewPrivacy(model.AccountID(e.AccountID), e.ShareLevel, e.ShareStreak, e.ShareWeeklyFocus)
+}
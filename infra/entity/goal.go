@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Goal struct {
+	AccountID     string    `gorm:"primaryKey"`
+	Period        string    `gorm:"primaryKey"`
+	TargetMinutes int       `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToGoalEntity(g model.Goal) Goal {
+	return Goal{
+		AccountID:     g.AccountID.String(),
+		Period:        string(g.Period),
+		TargetMinutes: g.TargetMinutes,
+	}
+}
+
+func (e Goal) ToModel() model.Goal {
+	return model.RecreateGoal(model.AccountID(e.AccountID), model.GoalPeriod(e.Period), e.TargetMinutes, e.CreatedAt)
+}
+
+type GoalAchievement struct {
+	AccountID string `gorm:"primaryKey"`
+	Period    string `gorm:"primaryKey"`
+	// PeriodStart は利用者のタイムゾーン基準の日付。DBのタイムゾーンで日付がずれないようUTCの0時として扱う
+	PeriodStart   time.Time `gorm:"primaryKey;type:date"`
+	TargetMinutes int       `gorm:"not null"`
+	FocusMinutes  float64   `gorm:"not null"`
+	AchievedAt    time.Time `gorm:"not null"`
+}
+
+func ToGoalAchievementEntity(a model.GoalAchievement) GoalAchievement {
+	return GoalAchievement{
+		AccountID:     a.AccountID.String(),
+		Period:        string(a.Period),
+		PeriodStart:   time.Date(a.PeriodStart.Year(), a.PeriodStart.Month(), a.PeriodStart.Day(), 0, 0, 0, 0, time.UTC),
+		TargetMinutes: a.TargetMinutes,
+		FocusMinutes:  a.FocusMinutes,
+		AchievedAt:    a.AchievedAt,
+	}
+}
+
+func (e GoalAchievement) ToModel() model.GoalAchievement {
+	return model.RecreateGoalAchievement(
+		model.AccountID(e.AccountID),
+		model.GoalPeriod(e.Period),
+		time.Date(e.PeriodStart.Year(), e.PeriodStart.Month(), e.PeriodStart.Day(), 0, 0, 0, 0, time.UTC),
+		e.TargetMinutes,
+		e.FocusMinutes,
+		e.AchievedAt,
+	)
+}
+
+type GoalMiss struct {
+	AccountID string `gorm:"primaryKey"`
+	Period    string `gorm:"primaryKey"`
+	// PeriodStart はGoalAchievementと同じく利用者のタイムゾーン基準の日付をUTCの0時として扱う
+	PeriodStart   time.Time `gorm:"primaryKey;type:date"`
+	TargetMinutes int       `gorm:"not null"`
+	FocusMinutes  float64   `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+func ToGoalMissEntity(m model.GoalMiss) GoalMiss {
+	return GoalMiss{
+		AccountID:     m.AccountID.String(),
+		Period:        string(m.Period),
+		PeriodStart:   time.Date(m.PeriodStart.Year(), m.PeriodStart.Month(), m.PeriodStart.Day(), 0, 0, 0, 0, time.UTC),
+		TargetMinutes: m.TargetMinutes,
+		FocusMinutes:  m.FocusMinutes,
+		CreatedAt:     m.CreatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Guild struct {
+	ID          string    `gorm:"primaryKey"`
+	Name        string    `gorm:"not null"`
+	Description string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToGuildEntity(g model.Guild) Guild {
+	return Guild{
+		ID:          g.ID.String(),
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+	}
+}
+
+func (e Guild) ToModel() model.Guild {
+	return model.RecreateGuild(model.GuildID(e.ID), e.Name, e.Description, e.CreatedAt)
+}
+
+type GuildMember struct {
+	GuildID   string    `gorm:"primaryKey"`
+	AccountID string    `gorm:"primaryKey"`
+	Role      string    `gorm:"not null"`
+	JoinedAt  time.Time `gorm:"not null"`
+}
+
+func ToGuildMemberEntity(m model.GuildMembership) GuildMember {
+	return GuildMember{
+		GuildID:   m.GuildID.String(),
+		AccountID: m.AccountID.String(),
+		Role:      string(m.Role),
+		JoinedAt:  m.JoinedAt,
+	}
+}
+
+func (e GuildMember) ToModel() model.GuildMembership {
+	return model.RecreateGuildMembership(model.GuildID(e.GuildID), model.AccountID(e.AccountID), model.GuildRole(e.Role), e.JoinedAt)
+}
+
+type Raid struct {
+	ID         string    `gorm:"primaryKey"`
+	GuildID    string    `gorm:"not null"`
+	Boss       string    `gorm:"not null"`
+	MaxHP      int       `gorm:"column:max_hp;not null"`
+	Damage     float64   `gorm:"not null"`
+	RewardExp  int       `gorm:"not null"`
+	RewardGold int       `gorm:"not null"`
+	Status     string    `gorm:"not null"`
+	StartedAt  time.Time `gorm:"not null"`
+	EndsAt     time.Time `gorm:"not null"`
+	FinishedAt *time.Time
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToRaidEntity(r model.Raid) Raid {
+	return Raid{
+		ID:         r.ID.String(),
+		GuildID:    r.GuildID.String(),
+		Boss:       r.Boss,
+		MaxHP:      r.MaxHP,
+		Damage:     r.Damage,
+		RewardExp:  r.Reward.Exp,
+		RewardGold: r.Reward.Gold,
+		Status:     string(r.Status),
+		StartedAt:  r.StartedAt,
+		EndsAt:     r.EndsAt,
+		FinishedAt: r.FinishedAt,
+	}
+}
+
+func (e Raid) ToModel() model.Raid {
+	return model.RecreateRaid(
+		model.RaidID(e.ID),
+		model.GuildID(e.GuildID),
+		e.Boss,
+		e.MaxHP,
+		e.Damage,
+		model.Reward{Exp: e.RewardExp, Gold: e.RewardGold},
+		model.RaidStatus(e.Status),
+		e.StartedAt,
+		e.EndsAt,
+		e.FinishedAt,
+	)
+}
+
+type RaidContribution struct {
+	RaidID     string    `gorm:"primaryKey"`
+	TimeID     string    `gorm:"primaryKey"`
+	AccountID  string    `gorm:"not null"`
+	Minutes    float64   `gorm:"not null"`
+	ExecutedAt time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func ToRaidContributionEntity(c model.RaidContribution) RaidContribution {
+	return RaidContribution{
+		RaidID:     c.RaidID.String(),
+		TimeID:     c.TimeID.String(),
+		AccountID:  c.AccountID.String(),
+		Minutes:    c.Minutes,
+		ExecutedAt: c.ExecutedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func (e RaidContribution) ToModel() model.RaidContribution {
+	return model.RecreateRaidContribution(model.RaidID(e.RaidID), model.AccountID(e.AccountID), model.TimeID(e.TimeID), e.Minutes, e.ExecutedAt, e.CreatedAt)
+}
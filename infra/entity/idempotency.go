@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type IdempotencyKey struct {
+	Scope       string `gorm:"primaryKey"`
+	Key         string `gorm:"primaryKey"`
+	RequestHash string `gorm:"not null"`
+	StatusCode  int    `gorm:"not null"`
+	Body        []byte
+	CompletedAt *time.Time
+	ExpiresAt   time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func ToIdempotencyKeyEntity(r model.IdempotencyRecord) IdempotencyKey {
+	return IdempotencyKey{
+		Scope:       r.Scope,
+		Key:         r.Key,
+		RequestHash: r.RequestHash,
+		StatusCode:  r.StatusCode,
+		Body:        r.Body,
+		CompletedAt: r.CompletedAt,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
+
+func (e IdempotencyKey) ToModel() model.IdempotencyRecord {
+	return model.RecreateIdempotencyRecord(
+		e.Scope,
+		e.Key,
+		e.RequestHash,
+		e.StatusCode,
+		e.Body,
+		e.CompletedAt,
+		e.ExpiresAt,
+	)
+}
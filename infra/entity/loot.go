@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"encoding/json"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// LootTableDefinition はデータファイルと保存するテーブルのスナップショットの形式
+type LootTableDefinition struct {
+	Key     string                `json:"key"`
+	Name    string                `json:"name"`
+	Source  string                `json:"source"`
+	Entries []LootEntryDefinition `json:"entries"`
+	Pity    []LootPityDefinition  `json:"pity"`
+}
+
+type LootEntryDefinition struct {
+	Item   string `json:"item"`
+	Name   string `json:"name"`
+	Rarity string `json:"rarity"`
+	Weight int    `json:"weight"`
+}
+
+type LootPityDefinition struct {
+	Rarity string `json:"rarity"`
+	After  int    `json:"after"`
+}
+
+func ToLootTableDefinition(t model.LootTable) LootTableDefinition {
+	entries := make([]LootEntryDefinition, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		entries = append(entries, LootEntryDefinition{
+			Item:   e.Item,
+			Name:   e.Name,
+			Rarity: string(e.Rarity),
+			Weight: e.Weight,
+		})
+	}
+
+	pity := make([]LootPityDefinition, 0, len(t.Pity))
+	for _, p := range t.Pity {
+		pity = append(pity, LootPityDefinition{
+			Rarity: string(p.Rarity),
+			After:  p.After,
+		})
+	}
+
+	return LootTableDefinition{
+		Key:     t.Key,
+		Name:    t.Name,
+		Source:  string(t.Source),
+		Entries: entries,
+		Pity:    pity,
+	}
+}
+
+func (d LootTableDefinition) ToModel() (model.LootTable, error) {
+	entries := make([]model.LootEntry, 0, len(d.Entries))
+	for _, e := range d.Entries {
+		entries = append(entries, model.LootEntry{
+			Item:   e.Item,
+			Name:   e.Name,
+			Rarity: model.Rarity(e.Rarity),
+			Weight: e.Weight,
+		})
+	}
+
+	pity := make([]model.LootPityRule, 0, len(d.Pity))
+	for _, p := range d.Pity {
+		pity = append(pity, model.LootPityRule{
+			Rarity: model.Rarity(p.Rarity),
+			After:  p.After,
+		})
+	}
+
+	return model.NewLootTable(d.Key, d.Name, model.RewardSource(d.Source), entries, pity)
+}
+
+type LootTableVersion struct {
+	TableKey   string    `gorm:"primaryKey"`
+	Version    string    `gorm:"primaryKey"`
+	Definition string    `gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func ToLootTableVersionEntity(t model.LootTable) (LootTableVersion, error) {
+	def, err := json.Marshal(ToLootTableDefinition(t))
+	if err != nil {
+		return LootTableVersion{}, errors.WithStack(err)
+	}
+
+	return LootTableVersion{
+		TableKey:   t.Key,
+		Version:    t.Version,
+		Definition: string(def),
+	}, nil
+}
+
+func (e LootTableVersion) ToModel() (model.LootTable, error) {
+	var def LootTableDefinition
+	if err := json.Unmarshal([]byte(e.Definition), &def); err != nil {
+		return model.LootTable{}, errors.WithStack(err)
+	}
+	return def.ToModel()
+}
+
+type LootPity struct {
+	AccountID string    `gorm:"primaryKey"`
+	TableKey  string    `gorm:"primaryKey"`
+	Misses    string    `gorm:"type:jsonb;not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (LootPity) TableName() string {
+	return "loot_pity"
+}
+
+func ToLootPityEntity(p model.LootPity) (LootPity, error) {
+	misses, err := json.Marshal(p.Misses)
+	if err != nil {
+		return LootPity{}, errors.WithStack(err)
+	}
+
+	return LootPity{
+		AccountID: p.AccountID.String(),
+		TableKey:  p.TableKey,
+		Misses:    string(misses),
+	}, nil
+}
+
+func (e LootPity) ToModel() (model.LootPity, error) {
+	var misses map[model.Rarity]int
+	if err := json.Unmarshal([]byte(e.Misses), &misses); err != nil {
+		return model.LootPity{}, errors.WithStack(err)
+	}
+	return model.RecreateLootPity(model.AccountID(e.AccountID), e.TableKey, misses), nil
+}
+
+type LootDrop struct {
+	ID           string `gorm:"primaryKey"`
+	AccountID    string `gorm:"not null"`
+	SourceID     *string
+	TableKey     string `gorm:"not null"`
+	TableVersion string `gorm:"not null"`
+	// Seed はuint64のビット列をそのままBIGINTに保存する
+	Seed       int64     `gorm:"not null"`
+	PityBefore string    `gorm:"type:jsonb;not null"`
+	Item       string    `gorm:"not null"`
+	ItemName   string    `gorm:"not null"`
+	Rarity     string    `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func ToLootDropEntity(d model.LootDrop) (LootDrop, error) {
+	pity, err := json.Marshal(d.PityBefore)
+	if err != nil {
+		return LootDrop{}, errors.WithStack(err)
+	}
+
+	var sourceID *string
+	if d.SourceID != "" {
+		sourceID = &d.SourceID
+	}
+
+	return LootDrop{
+		ID:           d.ID.String(),
+		AccountID:    d.AccountID.String(),
+		SourceID:     sourceID,
+		TableKey:     d.TableKey,
+		TableVersion: d.TableVersion,
+		Seed:         int64(d.Seed),
+		PityBefore:   string(pity),
+		Item:         d.Item,
+		ItemName:     d.ItemName,
+		Rarity:       string(d.Rarity),
+		CreatedAt:    d.CreatedAt,
+	}, nil
+}
+
+func (e LootDrop) ToModel() (model.LootDrop, error) {
+	var pity map[model.Rarity]int
+	if err := json.Unmarshal([]byte(e.PityBefore), &pity); err != nil {
+		return model.LootDrop{}, errors.WithStack(err)
+	}
+
+	var sourceID string
+	if e.SourceID != nil {
+		sourceID = *e.SourceID
+	}
+
+	return model.RecreateLootDrop(
+		model.LootDropID(e.ID),
+		model.AccountID(e.AccountID),
+		sourceID,
+		e.TableKey,
+		e.TableVersion,
+		uint64(e.Seed),
+		pity,
+		e.Item,
+		e.ItemName,
+		model.Rarity(e.Rarity),
+		e.CreatedAt,
+	), nil
+}
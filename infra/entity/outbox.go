@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Outbox struct {
+	ID            string    `gorm:"primaryKey"`
+	EventName     string    `gorm:"not null"`
+	AggregateID   string    `gorm:"not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	DispatchedAt  *time.Time
+	LastError     string
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (Outbox) TableName() string {
+	return "outbox"
+}
+
+func ToOutboxEntity(m model.OutboxMessage) Outbox {
+	return Outbox{
+		ID:            m.ID,
+		EventName:     string(m.EventName),
+		AggregateID:   m.AggregateID,
+		Payload:       string(m.Payload),
+		OccurredAt:    m.OccurredAt,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+	}
+}
+
+func (e Outbox) ToModel() model.OutboxMessage {
+	return model.OutboxMessage{
+		ID:            e.ID,
+		EventName:     model.EventName(e.EventName),
+		AggregateID:   e.AggregateID,
+		Payload:       []byte(e.Payload),
+		OccurredAt:    e.OccurredAt,
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+	}
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Project struct {
+	ID        string    `gorm:"primaryKey"`
+	AccountID string    `gorm:"not null"`
+	Name      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToProjectEntity(p model.Project) Project {
+	return Project{
+		ID:        p.ID.String(),
+		AccountID: p.AccountID.String(),
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func (e Project) ToModel() model.Project {
+	return model.RecreateProject(
+		model.ProjectID(e.ID),
+		model.AccountID(e.AccountID),
+		e.Name,
+		e.CreatedAt,
+	)
+}
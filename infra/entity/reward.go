@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type RewardLedger struct {
+	ID        int64  `gorm:"primaryKey"`
+	AccountID string `gorm:"not null"`
+	SourceID  *string
+	Exp       int       `gorm:"not null"`
+	Gold      int       `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (RewardLedger) TableName() string {
+	return "reward_ledger"
+}
+
+func ToRewardLedgerEntity(e model.RewardEntry) RewardLedger {
+	var sourceID *string
+	if e.SourceID != "" {
+		sourceID = &e.SourceID
+	}
+
+	return RewardLedger{
+		AccountID: e.AccountID.String(),
+		SourceID:  sourceID,
+		Exp:       e.Exp,
+		Gold:      e.Gold,
+		CreatedAt: e.CreatedAt,
+	}
+}
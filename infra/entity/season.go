@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+// SeasonDefinition はデータファイルの形式
+type SeasonDefinition struct {
+	Key         string                  `json:"key"`
+	Name        string                  `json:"name"`
+	StartsAt    time.Time               `json:"startsAt"`
+	EndsAt      time.Time               `json:"endsAt"`
+	ClaimEndsAt *time.Time              `json:"claimEndsAt"`
+	PremiumCost int                     `json:"premiumCost"`
+	Tiers       []SeasonTierDefinition  `json:"tiers"`
+	Quests      []SeasonQuestDefinition `json:"quests"`
+	Bosses      []SeasonBossDefinition  `json:"bosses"`
+}
+
+type SeasonTierDefinition struct {
+	Tier    int              `json:"tier"`
+	Exp     int              `json:"exp"`
+	Free    RewardDefinition `json:"free"`
+	Premium RewardDefinition `json:"premium"`
+}
+
+type RewardDefinition struct {
+	Exp  int `json:"exp"`
+	Gold int `json:"gold"`
+}
+
+func (d RewardDefinition) ToModel() model.Reward {
+	return model.Reward{Exp: d.Exp, Gold: d.Gold}
+}
+
+type SeasonQuestDefinition struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Target int    `json:"target"`
+	Exp    int    `json:"exp"`
+}
+
+type SeasonBossDefinition struct {
+	Key            string           `json:"key"`
+	Name           string           `json:"name"`
+	HP             int              `json:"hp"`
+	TimeLimitHours int              `json:"timeLimitHours"`
+	Reward         RewardDefinition `json:"reward"`
+}
+
+func (d SeasonDefinition) ToModel() (model.Season, error) {
+	tiers := make([]model.SeasonTier, 0, len(d.Tiers))
+	for _, t := range d.Tiers {
+		tiers = append(tiers, model.SeasonTier{
+			Tier:    t.Tier,
+			Exp:     t.Exp,
+			Free:    t.Free.ToModel(),
+			Premium: t.Premium.ToModel(),
+		})
+	}
+
+	quests := make([]model.SeasonQuest, 0, len(d.Quests))
+	for _, q := range d.Quests {
+		quests = append(quests, model.SeasonQuest{
+			Key:    q.Key,
+			Name:   q.Name,
+			Kind:   model.SeasonQuestKind(q.Kind),
+			Target: q.Target,
+			Exp:    q.Exp,
+		})
+	}
+
+	bosses := make([]model.RaidBoss, 0, len(d.Bosses))
+	for _, b := range d.Bosses {
+		bosses = append(bosses, model.RaidBoss{
+			Key:       b.Key,
+			Name:      b.Name,
+			HP:        b.HP,
+			TimeLimit: time.Duration(b.TimeLimitHours) * time.Hour,
+			Reward:    b.Reward.ToModel(),
+		})
+	}
+
+	return model.NewSeason(d.Key, d.Name, d.StartsAt, d.EndsAt, d.ClaimEndsAt, d.PremiumCost, tiers, quests, bosses)
+}
+
+type SeasonActivity struct {
+	SeasonKey  string    `gorm:"primaryKey"`
+	AccountID  string    `gorm:"primaryKey"`
+	SourceID   string    `gorm:"primaryKey"`
+	Kind       string    `gorm:"not null"`
+	Minutes    float64   `gorm:"not null"`
+	OccurredAt time.Time `gorm:"not null"`
+}
+
+func ToSeasonActivityEntity(a model.SeasonActivity) SeasonActivity {
+	return SeasonActivity{
+		SeasonKey:  a.SeasonKey,
+		AccountID:  a.AccountID.String(),
+		SourceID:   a.SourceID,
+		Kind:       string(a.Kind),
+		Minutes:    a.Minutes,
+		OccurredAt: a.OccurredAt,
+	}
+}
+
+type SeasonPass struct {
+	SeasonKey   string    `gorm:"primaryKey"`
+	AccountID   string    `gorm:"primaryKey"`
+	Cost        int       `gorm:"not null"`
+	PurchasedAt time.Time `gorm:"not null"`
+}
+
+func ToSeasonPassEntity(p model.SeasonPass) SeasonPass {
+	return SeasonPass{
+		SeasonKey:   p.SeasonKey,
+		AccountID:   p.AccountID.String(),
+		Cost:        p.Cost,
+		PurchasedAt: p.PurchasedAt,
+	}
+}
+
+func (e SeasonPass) ToModel() model.SeasonPass {
+	return model.RecreateSeasonPass(
+		e.SeasonKey,
+		model.AccountID(e.AccountID),
+		e.Cost,
+		e.PurchasedAt,
+	)
+}
+
+type SeasonClaim struct {
+	SeasonKey string    `gorm:"primaryKey"`
+	AccountID string    `gorm:"primaryKey"`
+	Tier      int       `gorm:"primaryKey"`
+	Track     string    `gorm:"primaryKey"`
+	Exp       int       `gorm:"not null"`
+	Gold      int       `gorm:"not null"`
+	ClaimedAt time.Time `gorm:"not null"`
+}
+
+func ToSeasonClaimEntity(c model.SeasonClaim) SeasonClaim {
+	return SeasonClaim{
+		SeasonKey: c.SeasonKey,
+		AccountID: c.AccountID.String(),
+		Tier:      c.Tier,
+		Track:     string(c.Track),
+		Exp:       c.Reward.Exp,
+		Gold:      c.Reward.Gold,
+		ClaimedAt: c.ClaimedAt,
+	}
+}
+
+func (e SeasonClaim) ToModel() model.SeasonClaim {
+	return model.RecreateSeasonClaim(
+		e.SeasonKey,
+		model.AccountID(e.AccountID),
+		e.Tier,
+		model.SeasonTrack(e.Track),
+		model.Reward{Exp: e.Exp, Gold: e.Gold},
+		e.ClaimedAt,
+	)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type AccountSettings struct {
+	AccountID         string    `gorm:"primaryKey"`
+	FocusMinutes      int       `gorm:"not null"`
+	ShortBreakMinutes int       `gorm:"not null"`
+	LongBreakMinutes  int       `gorm:"not null"`
+	LongBreakInterval int       `gorm:"not null"`
+	AutoStartBreaks   bool      `gorm:"not null"`
+	AutoStartFocus    bool      `gorm:"not null"`
+	NotifySound       bool      `gorm:"not null"`
+	NotifyDesktop     bool      `gorm:"not null"`
+	Timezone          string    `gorm:"not null"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToAccountSettingsEntity(s model.Settings) AccountSettings {
+	return AccountSettings{
+		AccountID:         s.AccountID.String(),
+		FocusMinutes:      s.FocusMinutes,
+		ShortBreakMinutes: s.ShortBreakMinutes,
+		LongBreakMinutes:  s.LongBreakMinutes,
+		LongBreakInterval: s.LongBreakInterval,
+		AutoStartBreaks:   s.AutoStartBreaks,
+		AutoStartFocus:    s.AutoStartFocus,
+		NotifySound:       s.NotifySound,
+		NotifyDesktop:     s.NotifyDesktop,
+		Timezone:          s.Timezone,
+	}
+}
+
+func (e AccountSettings) ToModel() model.Settings {
+	return model.RecreateSettings(
+		model.AccountID(e.AccountID),
+		e.FocusMinutes,
+		e.ShortBreakMinutes,
+		e.LongBreakMinutes,
+		e.LongBreakInterval,
+		e.AutoStartBreaks,
+		e.AutoStartFocus,
+		e.NotifySound,
+		e.NotifyDesktop,
+		e.Timezone,
+	)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Tag struct {
+	ID        string    `gorm:"primaryKey"`
+	AccountID string    `gorm:"not null"`
+	Name      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (e Tag) ToModel() model.Tag {
+	return model.RecreateTag(model.TagID(e.ID), model.AccountID(e.AccountID), e.Name)
+}
+
+type TimeTag struct {
+	TimeID string `gorm:"primaryKey"`
+	TagID  string `gorm:"primaryKey"`
+}
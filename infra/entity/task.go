@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type Task struct {
+	ID                 string `gorm:"primaryKey"`
+	AccountID          string `gorm:"not null"`
+	ProjectID          *string
+	Title              string    `gorm:"not null"`
+	EstimatedPomodoros int       `gorm:"not null"`
+	Completed          bool      `gorm:"not null"`
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToTaskEntity(t model.Task) Task {
+	var projectID *string
+	if t.ProjectID != nil {
+		id := t.ProjectID.String()
+		projectID = &id
+	}
+
+	return Task{
+		ID:                 t.ID.String(),
+		AccountID:          t.AccountID.String(),
+		ProjectID:          projectID,
+		Title:              t.Title,
+		EstimatedPomodoros: t.EstimatedPomodoros,
+		Completed:          t.Completed,
+		CreatedAt:          t.CreatedAt,
+	}
+}
+
+func (e Task) ToModel() model.Task {
+	var projectID *model.ProjectID
+	if e.ProjectID != nil {
+		id := model.ProjectID(*e.ProjectID)
+		projectID = &id
+	}
+
+	return model.RecreateTask(
+		model.TaskID(e.ID),
+		model.AccountID(e.AccountID),
+		projectID,
+		e.Title,
+		e.EstimatedPomodoros,
+		e.Completed,
+		e.CreatedAt,
+	)
+}
@@ -3,6 +3,8 @@ package entity
 import (
 	"pomodoro-rpg-api/domain/model"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Time struct {
@@ -10,16 +12,52 @@ type Time struct {
 	FocusTime     float64   `gorm:"not null"`
 	ExecutionDate time.Time `gorm:"not null"`
 	AccountID     string
+	TaskID        *string
+	Note          string    `gorm:"not null"`
 	Account       Account   `gorm:"foreignKey:AccountID"`
 	CreatedAt     time.Time `gorm:"autoCreateTime"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt
 }
 
 func ToTimeEntity(t model.Time) Time {
+	var taskID *string
+	if t.TaskID != nil {
+		id := t.TaskID.String()
+		taskID = &id
+	}
+
 	return Time{
 		ID:            t.ID.String(),
 		FocusTime:     t.FocusTime,
 		ExecutionDate: t.ExecutionDate,
 		AccountID:     t.AccountID.String(),
+		TaskID:        taskID,
+		Note:          t.Note,
 	}
 }
+
+func (e Time) ToModel(tags []string) model.Time {
+	var taskID *model.TaskID
+	if e.TaskID != nil {
+		id := model.TaskID(*e.TaskID)
+		taskID = &id
+	}
+
+	var deletedAt *time.Time
+	if e.DeletedAt.Valid {
+		deletedAt = &e.DeletedAt.Time
+	}
+
+	return model.RecreateTime(
+		model.TimeID(e.ID),
+		e.FocusTime,
+		model.AccountID(e.AccountID),
+		taskID,
+		e.Note,
+		tags,
+		e.ExecutionDate,
+		e.UpdatedAt,
+		deletedAt,
+	)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+// TimerSessionChannel はタイマーの状態変更を通知するPostgresのチャネル名
+const TimerSessionChannel = "timer_sessions"
+
+type TimerSession struct {
+	ID             string    `gorm:"primaryKey"`
+	AccountID      string    `gorm:"not null"`
+	Kind           string    `gorm:"not null"`
+	Status         string    `gorm:"not null"`
+	PlannedSeconds int       `gorm:"not null"`
+	ElapsedSeconds int       `gorm:"not null"`
+	StartedAt      time.Time `gorm:"not null"`
+	ResumedAt      *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time
+}
+
+func ToTimerSessionEntity(s model.TimerSession) TimerSession {
+	return TimerSession{
+		ID:             s.ID.String(),
+		AccountID:      s.AccountID.String(),
+		Kind:           string(s.Kind),
+		Status:         string(s.Status),
+		PlannedSeconds: s.PlannedSeconds,
+		ElapsedSeconds: s.ElapsedSeconds,
+		StartedAt:      s.StartedAt,
+		ResumedAt:      s.ResumedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func (e TimerSession) ToModel() model.TimerSession {
+	return model.RecreateTimerSession(
+		model.TimerSessionID(e.ID),
+		model.AccountID(e.AccountID),
+		model.SessionKind(e.Kind),
+		model.TimerStatus(e.Status),
+		e.PlannedSeconds,
+		e.ElapsedSeconds,
+		e.StartedAt,
+		e.ResumedAt,
+		e.UpdatedAt,
+	)
+}
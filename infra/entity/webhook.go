@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"encoding/json"
+	"pomodoro-rpg-api/domain/model"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type Webhook struct {
+	ID        string    `gorm:"primaryKey"`
+	AccountID string    `gorm:"not null"`
+	URL       string    `gorm:"not null"`
+	Secret    string    `gorm:"not null"`
+	Events    string    `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToWebhookEntity(w model.Webhook) (Webhook, error) {
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return Webhook{}, errors.WithStack(err)
+	}
+
+	return Webhook{
+		ID:        w.ID.String(),
+		AccountID: w.AccountID.String(),
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    string(events),
+		CreatedAt: w.CreatedAt,
+	}, nil
+}
+
+func (e Webhook) ToModel() (model.Webhook, error) {
+	var events []model.EventName
+	if err := json.Unmarshal([]byte(e.Events), &events); err != nil {
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	return model.RecreateWebhook(
+		model.WebhookID(e.ID),
+		model.AccountID(e.AccountID),
+		e.URL,
+		e.Secret,
+		events,
+		e.CreatedAt,
+	), nil
+}
+
+type WebhookDelivery struct {
+	ID            string    `gorm:"primaryKey"`
+	WebhookID     string    `gorm:"not null"`
+	EventKey      string    `gorm:"not null"`
+	EventName     string    `gorm:"not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	Status        string    `gorm:"not null"`
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	ResponseCode  int
+	LastError     string
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+func ToWebhookDeliveryEntity(d model.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID.String(),
+		EventKey:      d.EventKey,
+		EventName:     string(d.EventName),
+		Payload:       string(d.Payload),
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		ResponseCode:  d.ResponseCode,
+		LastError:     d.LastError,
+		DeliveredAt:   d.DeliveredAt,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+func (e WebhookDelivery) ToModel() model.WebhookDelivery {
+	return model.WebhookDelivery{
+		ID:            e.ID,
+		WebhookID:     model.WebhookID(e.WebhookID),
+		EventKey:      e.EventKey,
+		EventName:     model.EventName(e.EventName),
+		Payload:       []byte(e.Payload),
+		Status:        model.WebhookDeliveryStatus(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		ResponseCode:  e.ResponseCode,
+		LastError:     e.LastError,
+		DeliveredAt:   e.DeliveredAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
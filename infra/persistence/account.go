@@ -1,22 +1,28 @@
 package persistence
 
 import (
+	"context"
 	"pomodoro-rpg-api/domain/model"
 	"pomodoro-rpg-api/domain/repository"
 	"pomodoro-rpg-api/infra/entity"
 	"pomodoro-rpg-api/pkg/apperr"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"gorm.io/gorm"
 )
 
 type accountPersistence struct {
-	db *gorm.DB
+	db      *gorm.DB
+	timeout time.Duration
 }
 
-func (p *accountPersistence) FindByEmail(email string) (model.Account, error) {
+func (p *accountPersistence) FindByEmail(ctx context.Context, email string) (model.Account, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
 	var entity entity.Account
-	if err := p.db.Where("email = ?", email).First(&entity).Error; err != nil {
+	if err := db.Where("email = ?", email).First(&entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.Account{}, errors.WithStack(apperr.ErrDataNotFound)
 		}
@@ -32,20 +38,26 @@ func (p *accountPersistence) FindByEmail(email string) (model.Account, error) {
 	), nil
 }
 
-func (p *accountPersistence) Create(acc model.Account) error {
+func (p *accountPersistence) Create(ctx context.Context, acc model.Account) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
 	entity := entity.ToAccountEntity(acc)
 
-	if err := p.db.Create(&entity).Error; err != nil {
+	if err := db.Create(&entity).Error; err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
 
-func (p *accountPersistence) FindByID(id model.AccountID) (model.Account, error) {
+func (p *accountPersistence) FindByID(ctx context.Context, id model.AccountID) (model.Account, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
 	var acc entity.Account
 
-	err := p.db.Where("id = ?", id).First(&acc).Error
+	err := db.Where("id = ?", id).First(&acc).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.Account{}, errors.WithStack(apperr.ErrDataNotFound)
@@ -62,16 +74,19 @@ func (p *accountPersistence) FindByID(id model.AccountID) (model.Account, error)
 	), nil
 }
 
-func (p *accountPersistence) Update(acc model.Account) error {
+func (p *accountPersistence) Update(ctx context.Context, acc model.Account) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
 	entity := entity.ToAccountEntity(acc)
 
-	if err := p.db.Save(&entity).Error; err != nil {
+	if err := db.Save(&entity).Error; err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
 
-func NewaccountPersistence(db *gorm.DB) repository.AccountRepository {
-	return &accountPersistence{db}
+func NewaccountPersistence(db *gorm.DB, timeout time.Duration) repository.AccountRepository {
+	return &accountPersistence{db, timeout}
 }
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+const defaultAuditEventLimit = 100
+
+type auditEventPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *auditEventPersistence) Create(ctx context.Context, ev model.AuditEvent) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e, err := entity.ToAuditEventEntity(ev)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *auditEventPersistence) Find(ctx context.Context, filter repository.AuditEventFilter) ([]model.AuditEvent, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	q := db.Model(&entity.AuditEvent{})
+
+	if filter.ActorID != "" {
+		q = q.Where("actor_id = ?", filter.ActorID.String())
+	}
+	if filter.Action != "" {
+		q = q.Where("action = ?", string(filter.Action))
+	}
+	if filter.TargetType != "" {
+		q = q.Where("target_type = ?", string(filter.TargetType))
+	}
+	if filter.TargetID != "" {
+		q = q.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.RequestID != "" {
+		q = q.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	}
+
+	var entities []entity.AuditEvent
+	err := q.Order("created_at DESC").Limit(limit).Offset(filter.Offset).Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.AuditEvent, 0, len(entities))
+	for _, e := range entities {
+		ev, err := e.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ev)
+	}
+
+	return res, nil
+}
+
+func NewAuditEventPersistence(db *gorm.DB, timeout time.Duration) repository.AuditEventRepository {
+	return &auditEventPersistence{db, timeout}
+}
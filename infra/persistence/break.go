@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+type breakPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *breakPersistence) Create(ctx context.Context, b model.Break) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToBreakEntity(b)
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *breakPersistence) FindAccountIDByID(ctx context.Context, id model.BreakID) (model.AccountID, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.Break
+	if err := db.Select("account_id").Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return "", errors.WithStack(err)
+	}
+
+	return model.AccountID(e.AccountID), nil
+}
+
+func (p *breakPersistence) FindCreatedSince(ctx context.Context, accID model.AccountID, since model.SyncPosition, limit int) ([]model.Break, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Break
+	err := db.Where("account_id = ? AND (created_at, id) > (?, ?)", accID.String(), since.At, since.ID).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Break, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func NewBreakPersistence(db *gorm.DB, timeout time.Duration) repository.BreakRepository {
+	return &breakPersistence{db, timeout}
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarFeedPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *calendarFeedPersistence) FindByTokenHash(ctx context.Context, tokenHash string) (model.CalendarFeed, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.CalendarFeed
+	if err := db.Where("token_hash = ?", tokenHash).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CalendarFeed{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.CalendarFeed{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *calendarFeedPersistence) Save(ctx context.Context, f model.CalendarFeed) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToCalendarFeedEntity(f)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *calendarFeedPersistence) DeleteByAccountID(ctx context.Context, accID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("account_id = ?", accID.String()).Delete(&entity.CalendarFeed{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewCalendarFeedPersistence(db *gorm.DB, timeout time.Duration) repository.CalendarFeedRepository {
+	return &calendarFeedPersistence{db, timeout}
+}
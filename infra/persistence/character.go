@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type characterPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *characterPersistence) FindByAccountIDForUpdate(ctx context.Context, accID model.AccountID) (model.Character, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db, accID, true)
+}
+
+func (p *characterPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) (model.Character, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db, accID, false)
+}
+
+func (p *characterPersistence) find(db *gorm.DB, accID model.AccountID, forUpdate bool) (model.Character, error) {
+	q := db
+	if forUpdate {
+		q = q.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var e entity.Character
+	if err := q.Where("account_id = ?", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Character{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Character{}, errors.WithStack(err)
+	}
+
+	var skills []string
+	err := db.Model(&entity.CharacterSkill{}).
+		Where("account_id = ?", accID.String()).Order("learned_at, skill_key").Pluck("skill_key", &skills).Error
+	if err != nil {
+		return model.Character{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(skills), nil
+}
+
+func (p *characterPersistence) Save(ctx context.Context, c model.Character) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToCharacterEntity(c)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"level", "exp", "gold", "class", "hp", "knocked_out", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *characterPersistence) AddSkill(ctx context.Context, accID model.AccountID, key string) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToCharacterSkillEntity(accID, key, time.Now())
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewCharacterPersistence(db *gorm.DB, timeout time.Duration) repository.CharacterRepository {
+	return &characterPersistence{db, timeout}
+}
@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type companionPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *companionPersistence) FindByID(ctx context.Context, id model.CompanionID) (model.Companion, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Where("id = ?", id.String()))
+}
+
+func (p *companionPersistence) FindByIDForUpdate(ctx context.Context, id model.CompanionID) (model.Companion, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id.String()))
+}
+
+func (p *companionPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Companion, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Companion
+	if err := db.Where("account_id = ?", accID.String()).Order("obtained_at, id").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Companion, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *companionPersistence) FindActive(ctx context.Context, accID model.AccountID) (model.Companion, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Where("account_id = ? AND active", accID.String()))
+}
+
+func (p *companionPersistence) FindActiveForUpdate(ctx context.Context, accID model.AccountID) (model.Companion, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("account_id = ? AND active", accID.String()))
+}
+
+func (p *companionPersistence) first(q *gorm.DB) (model.Companion, error) {
+	var e entity.Companion
+	if err := q.First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Companion{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Companion{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *companionPersistence) Create(ctx context.Context, c model.Companion) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToCompanionEntity(c)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *companionPersistence) Update(ctx context.Context, c model.Companion) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.Companion{}).Where("id = ?", c.ID.String()).
+		Updates(map[string]any{"nickname": c.Nickname, "exp": c.Exp, "stage": c.Stage}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *companionPersistence) Activate(ctx context.Context, accID model.AccountID, id model.CompanionID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	// 連れて歩けるのは1体までという一意制約に反しないよう、先に外してから連れて歩く
+	err := db.Model(&entity.Companion{}).Where("account_id = ? AND active", accID.String()).Update("active", false).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.Model(&entity.Companion{}).Where("id = ? AND account_id = ?", id.String(), accID.String()).Update("active", true).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewCompanionPersistence(db *gorm.DB, timeout time.Duration) repository.CompanionRepository {
+	return &companionPersistence{db, timeout}
+}
+
+type companionTrainingPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *companionTrainingPersistence) Create(ctx context.Context, t model.CompanionTraining) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToCompanionTrainingEntity(t)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *companionTrainingPersistence) FindByTimeID(ctx context.Context, timeID model.TimeID) (model.CompanionTraining, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.CompanionTraining
+	if err := db.Where("time_id = ?", timeID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CompanionTraining{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.CompanionTraining{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *companionTrainingPersistence) Update(ctx context.Context, t model.CompanionTraining) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.CompanionTraining{}).Where("time_id = ?", t.TimeID.String()).Update("minutes", t.Minutes).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *companionTrainingPersistence) Delete(ctx context.Context, timeID model.TimeID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("time_id = ?", timeID.String()).Delete(&entity.CompanionTraining{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewCompanionTrainingPersistence(db *gorm.DB, timeout time.Duration) repository.CompanionTrainingRepository {
+	return &companionTrainingPersistence{db, timeout}
+}
@@ -0,0 +1,19 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// conn はリクエストのcontextに呼び出し単位のタイムアウトを付与したDBを返す。
+// contextにトランザクションが含まれている場合はそちらを使う
+func conn(ctx context.Context, db *gorm.DB, timeout time.Duration) (*gorm.DB, context.CancelFunc) {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		db = tx
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return db.WithContext(ctx), cancel
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type cyclePersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *cyclePersistence) FindByAccountIDForUpdate(ctx context.Context, accID model.AccountID) (model.Cycle, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db.Clauses(clause.Locking{Strength: "UPDATE"}), accID)
+}
+
+func (p *cyclePersistence) FindByAccountID(ctx context.Context, accID model.AccountID) (model.Cycle, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db, accID)
+}
+
+func (p *cyclePersistence) find(db *gorm.DB, accID model.AccountID) (model.Cycle, error) {
+	var e entity.PomodoroCycle
+	if err := db.Where("account_id = ?", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Cycle{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Cycle{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *cyclePersistence) Save(ctx context.Context, c model.Cycle) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToPomodoroCycleEntity(c)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"focus_count", "completed_cycles", "last_kind", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewCyclePersistence(db *gorm.DB, timeout time.Duration) repository.CycleRepository {
+	return &cyclePersistence{db, timeout}
+}
@@ -0,0 +1,207 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type focusRoomPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *focusRoomPersistence) FindByID(ctx context.Context, id model.FocusRoomID) (model.FocusRoom, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Where("id = ?", id.String()))
+}
+
+func (p *focusRoomPersistence) FindByIDForUpdate(ctx context.Context, id model.FocusRoomID) (model.FocusRoom, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id.String()))
+}
+
+func (p *focusRoomPersistence) FindByCodeForUpdate(ctx context.Context, code string) (model.FocusRoom, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code))
+}
+
+func (p *focusRoomPersistence) first(q *gorm.DB) (model.FocusRoom, error) {
+	var e entity.FocusRoom
+	if err := q.First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.FocusRoom{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.FocusRoom{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *focusRoomPersistence) Create(ctx context.Context, r model.FocusRoom) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToFocusRoomEntity(r)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *focusRoomPersistence) Update(ctx context.Context, r model.FocusRoom) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToFocusRoomEntity(r)
+	err := db.Model(&entity.FocusRoom{}).Where("id = ?", e.ID).Updates(map[string]any{
+		"host_id":         e.HostID,
+		"status":          e.Status,
+		"elapsed_seconds": e.ElapsedSeconds,
+		"started_at":      e.StartedAt,
+		"resumed_at":      e.ResumedAt,
+		"updated_at":      e.UpdatedAt,
+	}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *focusRoomPersistence) Notify(ctx context.Context, id model.FocusRoomID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Exec("SELECT pg_notify(?, ?)", entity.FocusRoomChannel, id.String()).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewFocusRoomPersistence(db *gorm.DB, timeout time.Duration) repository.FocusRoomRepository {
+	return &focusRoomPersistence{db, timeout}
+}
+
+type focusRoomParticipantPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *focusRoomParticipantPersistence) FindByRoomID(ctx context.Context, roomID model.FocusRoomID) ([]model.FocusRoomParticipant, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.FocusRoomParticipant
+	if err := db.Where("room_id = ?", roomID.String()).Order("joined_at, account_id").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.FocusRoomParticipant, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *focusRoomParticipantPersistence) Find(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID) (model.FocusRoomParticipant, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.FocusRoomParticipant
+	if err := db.Where("room_id = ? AND account_id = ?", roomID.String(), accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.FocusRoomParticipant{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.FocusRoomParticipant{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *focusRoomParticipantPersistence) FindUncompletedByAccountID(ctx context.Context, accID model.AccountID) (model.FocusRoomParticipant, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.FocusRoomParticipant
+	if err := db.Where("account_id = ? AND completed_at IS NULL", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.FocusRoomParticipant{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.FocusRoomParticipant{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *focusRoomParticipantPersistence) Count(ctx context.Context, roomID model.FocusRoomID) (int, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var count int64
+	if err := db.Model(&entity.FocusRoomParticipant{}).Where("room_id = ?", roomID.String()).Count(&count).Error; err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return int(count), nil
+}
+
+func (p *focusRoomParticipantPersistence) Create(ctx context.Context, fp model.FocusRoomParticipant) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToFocusRoomParticipantEntity(fp)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *focusRoomParticipantPersistence) Complete(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID, at time.Time) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	res := db.Model(&entity.FocusRoomParticipant{}).
+		Where("room_id = ? AND account_id = ? AND completed_at IS NULL", roomID.String(), accID.String()).
+		Update("completed_at", at)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *focusRoomParticipantPersistence) Delete(ctx context.Context, roomID model.FocusRoomID, accID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("room_id = ? AND account_id = ?", roomID.String(), accID.String()).Delete(&entity.FocusRoomParticipant{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewFocusRoomParticipantPersistence(db *gorm.DB, timeout time.Duration) repository.FocusRoomParticipantRepository {
+	return &focusRoomParticipantPersistence{db, timeout}
+}
@@ -0,0 +1,326 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type friendRequestPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *friendRequestPersistence) FindByID(ctx context.Context, id model.FriendRequestID) (model.FriendRequest, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.FriendRequest
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.FriendRequest{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.FriendRequest{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *friendRequestPersistence) FindByRequester(ctx context.Context, requesterID model.AccountID) ([]model.FriendRequest, error) {
+	return p.find(ctx, "requester_id = ?", requesterID.String())
+}
+
+func (p *friendRequestPersistence) FindPendingByAddressee(ctx context.Context, addresseeID model.AccountID) ([]model.FriendRequest, error) {
+	return p.find(ctx, "addressee_id = ? AND status = ?", addresseeID.String(), string(model.FriendRequestPending))
+}
+
+func (p *friendRequestPersistence) FindPendingBetween(ctx context.Context, requesterID, addresseeID model.AccountID) (model.FriendRequest, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.FriendRequest
+	err := db.Where("requester_id = ? AND addressee_id = ? AND status = ?", requesterID.String(), addresseeID.String(), string(model.FriendRequestPending)).
+		First(&e).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.FriendRequest{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.FriendRequest{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *friendRequestPersistence) find(ctx context.Context, query string, args ...any) ([]model.FriendRequest, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.FriendRequest
+	if err := db.Where(query, args...).Order("created_at DESC").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.FriendRequest, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *friendRequestPersistence) Create(ctx context.Context, r model.FriendRequest) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToFriendRequestEntity(r)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *friendRequestPersistence) LinkAddressee(ctx context.Context, email string, addresseeID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.FriendRequest{}).
+		Where("addressee_email = ? AND addressee_id IS NULL AND status = ?", email, string(model.FriendRequestPending)).
+		Update("addressee_id", addresseeID.String()).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *friendRequestPersistence) Update(ctx context.Context, r model.FriendRequest) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.FriendRequest{}).Where("id = ?", r.ID.String()).Update("status", string(r.Status)).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *friendRequestPersistence) Delete(ctx context.Context, id model.FriendRequestID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("id = ?", id.String()).Delete(&entity.FriendRequest{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *friendRequestPersistence) DeleteBetween(ctx context.Context, a, b model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)", a.String(), b.String(), b.String(), a.String()).
+		Delete(&entity.FriendRequest{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewFriendRequestPersistence(db *gorm.DB, timeout time.Duration) repository.FriendRequestRepository {
+	return &friendRequestPersistence{db, timeout}
+}
+
+type friendshipPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *friendshipPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Friendship, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Friendship
+	if err := db.Where("account_id = ?", accID.String()).Order("created_at").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Friendship, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *friendshipPersistence) Count(ctx context.Context, accID model.AccountID) (int, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var count int64
+	if err := db.Model(&entity.Friendship{}).Where("account_id = ?", accID.String()).Count(&count).Error; err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return int(count), nil
+}
+
+func (p *friendshipPersistence) Exists(ctx context.Context, accID, friendID model.AccountID) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var count int64
+	err := db.Model(&entity.Friendship{}).Where("account_id = ? AND friend_id = ?", accID.String(), friendID.String()).Count(&count).Error
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return count > 0, nil
+}
+
+func (p *friendshipPersistence) Create(ctx context.Context, f model.Friendship) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	entities := []entity.Friendship{
+		{AccountID: f.AccountID.String(), FriendID: f.FriendID.String(), CreatedAt: f.CreatedAt},
+		{AccountID: f.FriendID.String(), FriendID: f.AccountID.String(), CreatedAt: f.CreatedAt},
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *friendshipPersistence) Delete(ctx context.Context, accID, friendID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("(account_id = ? AND friend_id = ?) OR (account_id = ? AND friend_id = ?)", accID.String(), friendID.String(), friendID.String(), accID.String()).
+		Delete(&entity.Friendship{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewFriendshipPersistence(db *gorm.DB, timeout time.Duration) repository.FriendshipRepository {
+	return &friendshipPersistence{db, timeout}
+}
+
+type blockPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *blockPersistence) FindByBlockerID(ctx context.Context, blockerID model.AccountID) ([]model.Block, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.AccountBlock
+	if err := db.Where("blocker_id = ?", blockerID.String()).Order("created_at DESC").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Block, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *blockPersistence) ExistsBetween(ctx context.Context, a, b model.AccountID) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var count int64
+	err := db.Model(&entity.AccountBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", a.String(), b.String(), b.String(), a.String()).
+		Count(&count).Error
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return count > 0, nil
+}
+
+func (p *blockPersistence) Create(ctx context.Context, b model.Block) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToAccountBlockEntity(b)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *blockPersistence) Delete(ctx context.Context, blockerID, blockedID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("blocker_id = ? AND blocked_id = ?", blockerID.String(), blockedID.String()).Delete(&entity.AccountBlock{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewBlockPersistence(db *gorm.DB, timeout time.Duration) repository.BlockRepository {
+	return &blockPersistence{db, timeout}
+}
+
+type privacyPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *privacyPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) (model.Privacy, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.PrivacySetting
+	if err := db.Where("account_id = ?", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Privacy{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Privacy{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *privacyPersistence) Save(ctx context.Context, privacy model.Privacy) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToPrivacySettingEntity(privacy)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"share_level", "share_streak", "share_weekly_focus", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewPrivacyPersistence(db *gorm.DB, timeout time.Duration) repository.PrivacyRepository {
+	return &privacyPersistence{db, timeout}
+}
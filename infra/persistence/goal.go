@@ -0,0 +1,255 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultGoalAchievementLimit = 50
+
+type goalPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *goalPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Goal, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Goal
+	if err := db.Where("account_id = ?", accID.String()).Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Goal, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *goalPersistence) Save(ctx context.Context, g model.Goal) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToGoalEntity(g)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "period"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_minutes", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *goalPersistence) Delete(ctx context.Context, accID model.AccountID, period model.GoalPeriod) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("account_id = ? AND period = ?", accID.String(), string(period)).Delete(&entity.Goal{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewGoalPersistence(db *gorm.DB, timeout time.Duration) repository.GoalRepository {
+	return &goalPersistence{db, timeout}
+}
+
+type goalAchievementPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *goalAchievementPersistence) Create(ctx context.Context, a model.GoalAchievement) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToGoalAchievementEntity(a)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *goalAchievementPersistence) FindByPeriodStart(ctx context.Context, accID model.AccountID, period model.GoalPeriod, start time.Time) (model.GoalAchievement, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.GoalAchievement
+	err := db.Where("account_id = ? AND period = ? AND period_start = ?", accID.String(), string(period), start.Format(time.DateOnly)).First(&e).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.GoalAchievement{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.GoalAchievement{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *goalAchievementPersistence) Delete(ctx context.Context, a model.GoalAchievement) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	res := db.Where("account_id = ? AND period = ? AND period_start = ?", a.AccountID.String(), string(a.Period), a.PeriodStart.Format(time.DateOnly)).
+		Delete(&entity.GoalAchievement{})
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *goalAchievementPersistence) Find(ctx context.Context, accID model.AccountID, filter repository.GoalAchievementFilter) ([]model.GoalAchievement, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	q := db.Where("account_id = ?", accID.String())
+	if filter.Period != "" {
+		q = q.Where("period = ?", string(filter.Period))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultGoalAchievementLimit
+	}
+
+	var entities []entity.GoalAchievement
+	if err := q.Order("achieved_at DESC").Limit(limit).Offset(filter.Offset).Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.GoalAchievement, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func NewGoalAchievementPersistence(db *gorm.DB, timeout time.Duration) repository.GoalAchievementRepository {
+	return &goalAchievementPersistence{db, timeout}
+}
+
+type goalMissPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *goalMissPersistence) Create(ctx context.Context, m model.GoalMiss) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToGoalMissEntity(m)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+type missedGoalRow struct {
+	AccountID     string
+	TargetMinutes int
+	CreatedAt     time.Time
+	Timezone      string
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	FocusMinutes  float64
+}
+
+func (p *goalMissPersistence) FindMissedDaily(ctx context.Context, now time.Time) ([]model.GoalProgress, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	// 前日の範囲は利用者のタイムゾーンごとに異なるため、目標ごとに前日の0時から24時間を求めて集計する
+	var rows []missedGoalRow
+	err := db.Raw(`
+		SELECT * FROM (
+			SELECT goals.account_id, goals.target_minutes, goals.created_at, local.timezone, days.period_start, days.period_end,
+				COALESCE((
+					SELECT SUM(times.focus_time)
+					FROM times
+					WHERE times.account_id = goals.account_id AND times.execution_date >= days.period_start
+						AND times.execution_date < days.period_end AND times.deleted_at IS NULL
+				), 0) AS focus_minutes
+			FROM goals
+			LEFT JOIN account_settings ON account_settings.account_id = goals.account_id
+			CROSS JOIN LATERAL (
+				SELECT COALESCE(account_settings.timezone, 'UTC') AS timezone,
+					(@now::timestamptz AT TIME ZONE COALESCE(account_settings.timezone, 'UTC'))::date - 1 AS day
+			) AS local
+			CROSS JOIN LATERAL (
+				SELECT local.day::timestamp AT TIME ZONE local.timezone AS period_start,
+					(local.day + 1)::timestamp AT TIME ZONE local.timezone AS period_end
+			) AS days
+			WHERE goals.period = @period AND goals.created_at <= days.period_start
+				AND NOT EXISTS (
+					SELECT 1 FROM goal_misses
+					WHERE goal_misses.account_id = goals.account_id AND goal_misses.period = goals.period AND goal_misses.period_start = local.day
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM goal_achievements
+					WHERE goal_achievements.account_id = goals.account_id AND goal_achievements.period = goals.period AND goal_achievements.period_start = local.day
+				)
+		) AS progress
+		WHERE progress.focus_minutes < progress.target_minutes
+		ORDER BY progress.account_id`,
+		map[string]any{
+			"now":    now,
+			"period": string(model.GoalDaily),
+		},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.GoalProgress, 0, len(rows))
+	for _, r := range rows {
+		loc, err := time.LoadLocation(r.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		res = append(res, model.GoalProgress{
+			Goal:         model.RecreateGoal(model.AccountID(r.AccountID), model.GoalDaily, r.TargetMinutes, r.CreatedAt),
+			Start:        r.PeriodStart.In(loc),
+			End:          r.PeriodEnd.In(loc),
+			FocusMinutes: r.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (p *goalMissPersistence) TryLock(ctx context.Context) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var locked bool
+	if err := db.Raw(`SELECT pg_try_advisory_xact_lock(hashtext('goal_misses'))`).Scan(&locked).Error; err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return locked, nil
+}
+
+func NewGoalMissPersistence(db *gorm.DB, timeout time.Duration) repository.GoalMissRepository {
+	return &goalMissPersistence{db, timeout}
+}
@@ -0,0 +1,395 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type guildPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *guildPersistence) FindByID(ctx context.Context, id model.GuildID) (model.Guild, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db, id)
+}
+
+func (p *guildPersistence) FindByIDForUpdate(ctx context.Context, id model.GuildID) (model.Guild, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.find(db.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (p *guildPersistence) find(db *gorm.DB, id model.GuildID) (model.Guild, error) {
+	var e entity.Guild
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Guild{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Guild{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+type guildListingRow struct {
+	entity.Guild
+	Members int
+}
+
+func (p *guildPersistence) Search(ctx context.Context, query string, limit, offset int) ([]model.GuildListing, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []guildListingRow
+	err := db.Raw(`
+		SELECT guilds.*, COUNT(guild_members.account_id) AS members
+		FROM guilds
+		LEFT JOIN guild_members ON guild_members.guild_id = guilds.id
+		WHERE guilds.name ILIKE ?
+		GROUP BY guilds.id
+		ORDER BY guilds.created_at DESC, guilds.id
+		LIMIT ? OFFSET ?`,
+		"%"+escapeLike(query)+"%", limit, offset,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.GuildListing, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.GuildListing{Guild: r.Guild.ToModel(), Members: r.Members})
+	}
+
+	return res, nil
+}
+
+func (p *guildPersistence) Create(ctx context.Context, g model.Guild) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToGuildEntity(g)
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *guildPersistence) Update(ctx context.Context, g model.Guild) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.Guild{}).Where("id = ?", g.ID.String()).
+		Updates(map[string]any{"name": g.Name, "description": g.Description}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *guildPersistence) Delete(ctx context.Context, id model.GuildID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	// メンバーとレイドの記録は外部キーのON DELETE CASCADEで削除される
+	if err := db.Where("id = ?", id.String()).Delete(&entity.Guild{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewGuildPersistence(db *gorm.DB, timeout time.Duration) repository.GuildRepository {
+	return &guildPersistence{db, timeout}
+}
+
+type guildMembershipPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *guildMembershipPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) (model.GuildMembership, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.GuildMember
+	if err := db.Where("account_id = ?", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.GuildMembership{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.GuildMembership{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *guildMembershipPersistence) FindByGuildID(ctx context.Context, guildID model.GuildID) ([]model.GuildMembership, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.GuildMember
+	err := db.Where("guild_id = ?", guildID.String()).
+		Order(clause.Expr{SQL: "CASE role WHEN ? THEN 0 WHEN ? THEN 1 ELSE 2 END, joined_at", Vars: []any{string(model.GuildLeader), string(model.GuildOfficer)}}).
+		Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.GuildMembership, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *guildMembershipPersistence) Count(ctx context.Context, guildID model.GuildID) (int, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var count int64
+	if err := db.Model(&entity.GuildMember{}).Where("guild_id = ?", guildID.String()).Count(&count).Error; err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return int(count), nil
+}
+
+func (p *guildMembershipPersistence) Create(ctx context.Context, m model.GuildMembership) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToGuildMemberEntity(m)
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *guildMembershipPersistence) Update(ctx context.Context, m model.GuildMembership) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.GuildMember{}).Where("guild_id = ? AND account_id = ?", m.GuildID.String(), m.AccountID.String()).
+		Update("role", string(m.Role)).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *guildMembershipPersistence) Delete(ctx context.Context, guildID model.GuildID, accID model.AccountID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("guild_id = ? AND account_id = ?", guildID.String(), accID.String()).Delete(&entity.GuildMember{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewGuildMembershipPersistence(db *gorm.DB, timeout time.Duration) repository.GuildMembershipRepository {
+	return &guildMembershipPersistence{db, timeout}
+}
+
+type raidPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *raidPersistence) FindByID(ctx context.Context, id model.RaidID) (model.Raid, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Where("id = ?", id.String()))
+}
+
+func (p *raidPersistence) FindLatestByGuildID(ctx context.Context, guildID model.GuildID) (model.Raid, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Where("guild_id = ?", guildID.String()).Order("started_at DESC"))
+}
+
+func (p *raidPersistence) FindActiveByGuildIDForUpdate(ctx context.Context, guildID model.GuildID) (model.Raid, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("guild_id = ? AND status = ?", guildID.String(), string(model.RaidActive)))
+}
+
+func (p *raidPersistence) FindByIDForUpdate(ctx context.Context, id model.RaidID) (model.Raid, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	return p.first(db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id.String()))
+}
+
+func (p *raidPersistence) first(q *gorm.DB) (model.Raid, error) {
+	var e entity.Raid
+	if err := q.First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Raid{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Raid{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *raidPersistence) FindByGuildID(ctx context.Context, guildID model.GuildID, limit, offset int) ([]model.Raid, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Raid
+	err := db.Where("guild_id = ?", guildID.String()).Order("started_at DESC").Limit(limit).Offset(offset).Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Raid, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *raidPersistence) Create(ctx context.Context, r model.Raid) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToRaidEntity(r)
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *raidPersistence) Update(ctx context.Context, r model.Raid) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.Raid{}).Where("id = ?", r.ID.String()).
+		Updates(map[string]any{"damage": r.Damage, "status": string(r.Status), "finished_at": r.FinishedAt}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewRaidPersistence(db *gorm.DB, timeout time.Duration) repository.RaidRepository {
+	return &raidPersistence{db, timeout}
+}
+
+type raidContributionPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *raidContributionPersistence) Create(ctx context.Context, c model.RaidContribution) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToRaidContributionEntity(c)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *raidContributionPersistence) FindByTimeID(ctx context.Context, timeID model.TimeID) ([]model.RaidContribution, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.RaidContribution
+	if err := db.Where("time_id = ?", timeID.String()).Order("raid_id").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.RaidContribution, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *raidContributionPersistence) Update(ctx context.Context, c model.RaidContribution) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.RaidContribution{}).Where("raid_id = ? AND time_id = ?", c.RaidID.String(), c.TimeID.String()).
+		Update("minutes", c.Minutes).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *raidContributionPersistence) Delete(ctx context.Context, c model.RaidContribution) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Where("raid_id = ? AND time_id = ?", c.RaidID.String(), c.TimeID.String()).Delete(&entity.RaidContribution{}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+type raidContributorRow struct {
+	AccountID string
+	Minutes   float64
+}
+
+func (p *raidContributionPersistence) Contributors(ctx context.Context, raidID model.RaidID) ([]model.RaidContributor, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []raidContributorRow
+	err := db.Model(&entity.RaidContribution{}).
+		Select("account_id, SUM(minutes) AS minutes").
+		Where("raid_id = ?", raidID.String()).
+		Group("account_id").
+		Order("minutes DESC, account_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.RaidContributor, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.RaidContributor{AccountID: model.AccountID(r.AccountID), Minutes: r.Minutes})
+	}
+
+	return res, nil
+}
+
+func NewRaidContributionPersistence(db *gorm.DB, timeout time.Duration) repository.RaidContributionRepository {
+	return &raidContributionPersistence{db, timeout}
+}
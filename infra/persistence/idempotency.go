@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *idempotencyPersistence) Reserve(ctx context.Context, r model.IdempotencyRecord) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	// 期限切れの記録は削除を待たずに新しいリクエストで上書きする
+	e := entity.ToIdempotencyKeyEntity(r)
+	res := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"request_hash",
+			"status_code",
+			"body",
+			"completed_at",
+			"expires_at",
+			"created_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "idempotency_keys.expires_at < NOW()"},
+		}},
+	}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *idempotencyPersistence) Find(ctx context.Context, scope, key string) (model.IdempotencyRecord, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.IdempotencyKey
+	if err := db.Where("scope = ? AND key = ?", scope, key).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.IdempotencyRecord{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.IdempotencyRecord{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *idempotencyPersistence) Complete(ctx context.Context, r model.IdempotencyRecord) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.IdempotencyKey{}).
+		Where("scope = ? AND key = ?", r.Scope, r.Key).
+		Updates(map[string]any{
+			"status_code":  r.StatusCode,
+			"body":         r.Body,
+			"completed_at": r.CompletedAt,
+		}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *idempotencyPersistence) Delete(ctx context.Context, scope, key string) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("scope = ? AND key = ?", scope, key).Delete(&entity.IdempotencyKey{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *idempotencyPersistence) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	res := db.Where("expires_at < ?", now).Delete(&entity.IdempotencyKey{})
+	if res.Error != nil {
+		return 0, errors.WithStack(res.Error)
+	}
+
+	return int(res.RowsAffected), nil
+}
+
+func NewIdempotencyPersistence(db *gorm.DB, timeout time.Duration) repository.IdempotencyRepository {
+	return &idempotencyPersistence{db, timeout}
+}
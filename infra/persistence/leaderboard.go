@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+// leaderboardScoreQueries はランキングごとに、期間内のスコアをアカウント単位で集計するクエリ。
+// フレンドに公開していない指標はランキングにも載せない
+var leaderboardScoreQueries = map[model.Leaderboard]string{
+	{Metric: model.LeaderboardFocus, Period: model.LeaderboardWeek}:    focusScoreQuery,
+	{Metric: model.LeaderboardFocus, Period: model.LeaderboardMonth}:   focusScoreQuery,
+	{Metric: model.LeaderboardFocus, Period: model.LeaderboardAllTime}: allTimeFocusScoreQuery,
+	{Metric: model.LeaderboardXP, Period: model.LeaderboardWeek}:       xpScoreQuery,
+	{Metric: model.LeaderboardXP, Period: model.LeaderboardMonth}:      xpScoreQuery,
+	{Metric: model.LeaderboardXP, Period: model.LeaderboardAllTime}:    allTimeXPScoreQuery,
+}
+
+const (
+	focusScoreQuery = `
+		SELECT times.account_id, SUM(times.focus_time) AS score
+		FROM times
+		LEFT JOIN privacy_settings ON privacy_settings.account_id = times.account_id
+		WHERE times.execution_date >= @start AND times.execution_date < @end AND times.deleted_at IS NULL
+			AND COALESCE(privacy_settings.share_weekly_focus, TRUE)
+		GROUP BY times.account_id`
+	allTimeFocusScoreQuery = `
+		SELECT times.account_id, SUM(times.focus_time) AS score
+		FROM times
+		LEFT JOIN privacy_settings ON privacy_settings.account_id = times.account_id
+		WHERE times.deleted_at IS NULL AND COALESCE(privacy_settings.share_weekly_focus, TRUE)
+		GROUP BY times.account_id`
+	xpScoreQuery = `
+		SELECT reward_ledger.account_id, SUM(reward_ledger.exp) AS score
+		FROM reward_ledger
+		LEFT JOIN privacy_settings ON privacy_settings.account_id = reward_ledger.account_id
+		WHERE reward_ledger.created_at >= @start AND reward_ledger.created_at < @end
+			AND COALESCE(privacy_settings.share_level, TRUE)
+		GROUP BY reward_ledger.account_id`
+	allTimeXPScoreQuery = `
+		SELECT characters.account_id, characters.exp AS score
+		FROM characters
+		LEFT JOIN privacy_settings ON privacy_settings.account_id = characters.account_id
+		WHERE COALESCE(privacy_settings.share_level, TRUE)`
+)
+
+type leaderboardPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+type leaderboardEntryRow struct {
+	AccountID string
+	Name      string
+	Image     string
+	Score     float64
+	Rank      int
+}
+
+func (p *leaderboardPersistence) Refresh(ctx context.Context, board model.Leaderboard, start, end time.Time) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	query, ok := leaderboardScoreQueries[board]
+	if !ok {
+		return errors.Newf("unsupported leaderboard: %s", board.Key())
+	}
+
+	params := map[string]any{
+		"board": board.Key(),
+		"start": start,
+		"end":   end,
+		"day":   start.Format(time.DateOnly),
+	}
+
+	// 集計と入れ替えを1つのトランザクションで行い、参照側には常に完全なスナップショットを見せる
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext(@board))`, params).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM leaderboard_entries WHERE board = @board AND period_start = @day::date`, params).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			INSERT INTO leaderboard_entries (board, period_start, account_id, score, rank, refreshed_at)
+			SELECT @board, @day::date, scores.account_id, scores.score, RANK() OVER (ORDER BY scores.score DESC), NOW()
+			FROM (`+query+`) AS scores
+			WHERE scores.score > 0`,
+			params,
+		).Error
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *leaderboardPersistence) FindTop(ctx context.Context, board model.Leaderboard, start time.Time, limit int) ([]model.LeaderboardEntry, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []leaderboardEntryRow
+	err := db.Raw(`
+		SELECT leaderboard_entries.account_id, accounts.name, accounts.image, leaderboard_entries.score, leaderboard_entries.rank
+		FROM leaderboard_entries
+		JOIN accounts ON accounts.id = leaderboard_entries.account_id
+		WHERE leaderboard_entries.board = ? AND leaderboard_entries.period_start = ?::date
+		ORDER BY leaderboard_entries.rank, leaderboard_entries.account_id
+		LIMIT ?`,
+		board.Key(), start.Format(time.DateOnly), limit,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return toLeaderboardEntries(rows), nil
+}
+
+func (p *leaderboardPersistence) FindByAccountIDs(ctx context.Context, board model.Leaderboard, start time.Time, accIDs []model.AccountID) ([]model.LeaderboardEntry, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if len(accIDs) == 0 {
+		return []model.LeaderboardEntry{}, nil
+	}
+
+	ids := make([]string, 0, len(accIDs))
+	for _, id := range accIDs {
+		ids = append(ids, id.String())
+	}
+
+	var rows []leaderboardEntryRow
+	err := db.Raw(`
+		SELECT leaderboard_entries.account_id, accounts.name, accounts.image, leaderboard_entries.score, leaderboard_entries.rank
+		FROM leaderboard_entries
+		JOIN accounts ON accounts.id = leaderboard_entries.account_id
+		WHERE leaderboard_entries.board = ? AND leaderboard_entries.period_start = ?::date AND leaderboard_entries.account_id IN ?
+		ORDER BY leaderboard_entries.score DESC, leaderboard_entries.account_id`,
+		board.Key(), start.Format(time.DateOnly), ids,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return toLeaderboardEntries(rows), nil
+}
+
+func toLeaderboardEntries(rows []leaderboardEntryRow) []model.LeaderboardEntry {
+	res := make([]model.LeaderboardEntry, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.LeaderboardEntry{
+			AccountID: model.AccountID(r.AccountID),
+			Name:      r.Name,
+			Image:     r.Image,
+			Score:     r.Score,
+			Rank:      r.Rank,
+		})
+	}
+	return res
+}
+
+func NewLeaderboardPersistence(db *gorm.DB, timeout time.Duration) repository.LeaderboardRepository {
+	return &leaderboardPersistence{db, timeout}
+}
@@ -0,0 +1,236 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type lootTableVersionPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *lootTableVersionPersistence) Save(ctx context.Context, t model.LootTable) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e, err := entity.ToLootTableVersionEntity(t)
+	if err != nil {
+		return err
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *lootTableVersionPersistence) Find(ctx context.Context, key, version string) (model.LootTable, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.LootTableVersion
+	if err := db.Where("table_key = ? AND version = ?", key, version).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.LootTable{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.LootTable{}, errors.WithStack(err)
+	}
+
+	return e.ToModel()
+}
+
+func NewLootTableVersionPersistence(db *gorm.DB, timeout time.Duration) repository.LootTableVersionRepository {
+	return &lootTableVersionPersistence{db, timeout}
+}
+
+type lootPityPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *lootPityPersistence) FindForUpdate(ctx context.Context, accID model.AccountID, tableKey string) (model.LootPity, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.LootPity
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ? AND table_key = ?", accID.String(), tableKey).First(&e).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.LootPity{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.LootPity{}, errors.WithStack(err)
+	}
+
+	return e.ToModel()
+}
+
+func (p *lootPityPersistence) Save(ctx context.Context, lp model.LootPity) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e, err := entity.ToLootPityEntity(lp)
+	if err != nil {
+		return err
+	}
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "table_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"misses", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewLootPityPersistence(db *gorm.DB, timeout time.Duration) repository.LootPityRepository {
+	return &lootPityPersistence{db, timeout}
+}
+
+type lootDropPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *lootDropPersistence) Create(ctx context.Context, d model.LootDrop) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e, err := entity.ToLootDropEntity(d)
+	if err != nil {
+		return err
+	}
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *lootDropPersistence) FindByID(ctx context.Context, id model.LootDropID) (model.LootDrop, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.LootDrop
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.LootDrop{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.LootDrop{}, errors.WithStack(err)
+	}
+
+	return e.ToModel()
+}
+
+func (p *lootDropPersistence) FindBySourceID(ctx context.Context, accID model.AccountID, sourceID string) ([]model.LootDrop, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.LootDrop
+	err := db.Where("account_id = ? AND source_id = ?", accID.String(), sourceID).
+		Order("created_at, id").Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return toLootDropModels(entities)
+}
+
+func (p *lootDropPersistence) FindLater(ctx context.Context, drop model.LootDrop) ([]model.LootDrop, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.LootDrop
+	err := db.Where("account_id = ? AND table_key = ? AND (created_at, id) > (?, ?)", drop.AccountID.String(), drop.TableKey, drop.CreatedAt, drop.ID.String()).
+		Order("created_at, id").Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return toLootDropModels(entities)
+}
+
+func (p *lootDropPersistence) Delete(ctx context.Context, id model.LootDropID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("id = ?", id.String()).Delete(&entity.LootDrop{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *lootDropPersistence) FindByAccountID(ctx context.Context, accID model.AccountID, limit, offset int) ([]model.LootDrop, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.LootDrop
+	err := db.Where("account_id = ?", accID.String()).
+		Order("created_at DESC, id").Limit(limit).Offset(offset).Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return toLootDropModels(entities)
+}
+
+func (p *lootDropPersistence) Inventory(ctx context.Context, accID model.AccountID) ([]model.InventoryItem, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []struct {
+		Item     string
+		ItemName string
+		Rarity   string
+		Count    int
+	}
+	// アイテム名はテーブルの更新で変わりうるため、最後に得たときの名前を使う
+	err := db.Model(&entity.LootDrop{}).
+		Select("item, (ARRAY_AGG(item_name ORDER BY created_at DESC))[1] AS item_name, (ARRAY_AGG(rarity ORDER BY created_at DESC))[1] AS rarity, COUNT(*) AS count").
+		Where("account_id = ?", accID.String()).
+		Group("item").
+		Order("item").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.InventoryItem, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.InventoryItem{
+			Item:   r.Item,
+			Name:   r.ItemName,
+			Rarity: model.Rarity(r.Rarity),
+			Count:  r.Count,
+		})
+	}
+
+	return res, nil
+}
+
+func toLootDropModels(entities []entity.LootDrop) ([]model.LootDrop, error) {
+	res := make([]model.LootDrop, 0, len(entities))
+	for _, e := range entities {
+		d, err := e.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+func NewLootDropPersistence(db *gorm.DB, timeout time.Duration) repository.LootDropRepository {
+	return &lootDropPersistence{db, timeout}
+}
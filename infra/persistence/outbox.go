@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type outboxPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *outboxPersistence) Save(ctx context.Context, events ...model.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	entities := make([]entity.Outbox, 0, len(events))
+	for _, ev := range events {
+		msg, err := model.NewOutboxMessage(uuid.NewString(), ev)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, entity.ToOutboxEntity(msg))
+	}
+
+	if err := db.Create(&entities).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *outboxPersistence) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	now := time.Now()
+
+	var entities []entity.Outbox
+	err := db.Raw(`
+		UPDATE outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE dispatched_at IS NULL AND attempts < ? AND next_attempt_at <= ?
+			ORDER BY occurred_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), model.OutboxMaxAttempts, now, limit,
+	).Scan(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].OccurredAt.Before(entities[j].OccurredAt)
+	})
+
+	res := make([]model.OutboxMessage, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *outboxPersistence) MarkDispatched(ctx context.Context, id string) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.Outbox{}).Where("id = ?", id).Update("dispatched_at", time.Now()).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *outboxPersistence) MarkFailed(ctx context.Context, msg model.OutboxMessage) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.Outbox{}).Where("id = ?", msg.ID).Updates(map[string]any{
+		"attempts":        msg.Attempts,
+		"next_attempt_at": msg.NextAttemptAt,
+		"last_error":      msg.LastError,
+	}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewOutboxPersistence(db *gorm.DB, timeout time.Duration) repository.OutboxRepository {
+	return &outboxPersistence{db, timeout}
+}
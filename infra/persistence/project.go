@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type projectPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *projectPersistence) FindByID(ctx context.Context, id model.ProjectID) (model.Project, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.Project
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Project{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Project{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *projectPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Project, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Project
+	if err := db.Where("account_id = ?", accID.String()).Order("created_at").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Project, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *projectPersistence) Save(ctx context.Context, project model.Project) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToProjectEntity(project)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *projectPersistence) Delete(ctx context.Context, id model.ProjectID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("id = ?", id.String()).Delete(&entity.Project{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewProjectPersistence(db *gorm.DB, timeout time.Duration) repository.ProjectRepository {
+	return &projectPersistence{db, timeout}
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+type rewardLedgerPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *rewardLedgerPersistence) Create(ctx context.Context, e model.RewardEntry) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	entry := entity.ToRewardLedgerEntity(e)
+	if err := db.Create(&entry).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *rewardLedgerPersistence) SumBySourceID(ctx context.Context, accID model.AccountID, sourceID string) (model.Reward, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var row struct {
+		Entries int
+		Exp     int
+		Gold    int
+	}
+	err := db.Model(&entity.RewardLedger{}).
+		Select("COUNT(*) AS entries, COALESCE(SUM(exp), 0) AS exp, COALESCE(SUM(gold), 0) AS gold").
+		Where("account_id = ? AND source_id = ?", accID.String(), sourceID).
+		Scan(&row).Error
+	if err != nil {
+		return model.Reward{}, errors.WithStack(err)
+	}
+	if row.Entries == 0 {
+		return model.Reward{}, errors.WithStack(apperr.ErrDataNotFound)
+	}
+
+	return model.Reward{Exp: row.Exp, Gold: row.Gold}, nil
+}
+
+func NewRewardLedgerPersistence(db *gorm.DB, timeout time.Duration) repository.RewardLedgerRepository {
+	return &rewardLedgerPersistence{db, timeout}
+}
@@ -0,0 +1,151 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type seasonActivityPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *seasonActivityPersistence) Create(ctx context.Context, a model.SeasonActivity) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToSeasonActivityEntity(a)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+type seasonTallyRow struct {
+	FocusMinutes  float64
+	FocusSessions int
+	GoalsAchieved int
+}
+
+func (p *seasonActivityPersistence) Tally(ctx context.Context, seasonKey string, accID model.AccountID) (model.SeasonTally, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	focus := string(model.SeasonActivityFocus)
+	goal := string(model.SeasonActivityGoal)
+
+	// 記録の修正や削除を反映するため、集中の活動は元の記録の集中時間で数え、目標の達成は取り消されていないものだけ数える。
+	// 短い記録を繰り返して回数を稼げないよう、集中の回数はドロップを抽選する最低限の長さに達したものだけ数える
+	var row seasonTallyRow
+	err := db.Model(&entity.SeasonActivity{}).
+		Select("COALESCE(SUM(times.focus_time) FILTER (WHERE season_activities.kind = ?), 0) AS focus_minutes, "+
+			"COUNT(*) FILTER (WHERE season_activities.kind = ? AND times.focus_time >= ?) AS focus_sessions, "+
+			"COUNT(*) FILTER (WHERE season_activities.kind = ?) AS goals_achieved",
+			focus, focus, model.MinFocusSessionMinutes, goal).
+		Joins("LEFT JOIN times ON season_activities.kind = ? AND times.id = season_activities.source_id", focus).
+		Where("season_activities.season_key = ? AND season_activities.account_id = ?", seasonKey, accID.String()).
+		Where("season_activities.kind <> ? OR (times.id IS NOT NULL AND times.deleted_at IS NULL)", focus).
+		Where("season_activities.kind <> ? OR EXISTS (SELECT 1 FROM goal_achievements WHERE goal_achievements.account_id = season_activities.account_id "+
+			"AND goal_achievements.period || ':' || to_char(goal_achievements.period_start, 'YYYY-MM-DD') = season_activities.source_id)", goal).
+		Scan(&row).Error
+	if err != nil {
+		return model.SeasonTally{}, errors.WithStack(err)
+	}
+
+	return model.SeasonTally{
+		FocusMinutes:  row.FocusMinutes,
+		FocusSessions: row.FocusSessions,
+		GoalsAchieved: row.GoalsAchieved,
+	}, nil
+}
+
+func NewSeasonActivityPersistence(db *gorm.DB, timeout time.Duration) repository.SeasonActivityRepository {
+	return &seasonActivityPersistence{db, timeout}
+}
+
+type seasonPassPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *seasonPassPersistence) Find(ctx context.Context, seasonKey string, accID model.AccountID) (model.SeasonPass, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.SeasonPass
+	if err := db.Where("season_key = ? AND account_id = ?", seasonKey, accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.SeasonPass{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.SeasonPass{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *seasonPassPersistence) Create(ctx context.Context, sp model.SeasonPass) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToSeasonPassEntity(sp)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func NewSeasonPassPersistence(db *gorm.DB, timeout time.Duration) repository.SeasonPassRepository {
+	return &seasonPassPersistence{db, timeout}
+}
+
+type seasonClaimPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *seasonClaimPersistence) Find(ctx context.Context, seasonKey string, accID model.AccountID) ([]model.SeasonClaim, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.SeasonClaim
+	err := db.Where("season_key = ? AND account_id = ?", seasonKey, accID.String()).Order("tier, track").Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.SeasonClaim, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *seasonClaimPersistence) Create(ctx context.Context, c model.SeasonClaim) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToSeasonClaimEntity(c)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&e)
+	if res.Error != nil {
+		return false, errors.WithStack(res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func NewSeasonClaimPersistence(db *gorm.DB, timeout time.Duration) repository.SeasonClaimRepository {
+	return &seasonClaimPersistence{db, timeout}
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type settingsPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *settingsPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) (model.Settings, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.AccountSettings
+	if err := db.Where("account_id = ?", accID.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Settings{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Settings{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *settingsPersistence) Save(ctx context.Context, s model.Settings) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToAccountSettingsEntity(s)
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"focus_minutes",
+			"short_break_minutes",
+			"long_break_minutes",
+			"long_break_interval",
+			"auto_start_breaks",
+			"auto_start_focus",
+			"notify_sound",
+			"notify_desktop",
+			"timezone",
+			"updated_at",
+		}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewSettingsPersistence(db *gorm.DB, timeout time.Duration) repository.SettingsRepository {
+	return &settingsPersistence{db, timeout}
+}
@@ -0,0 +1,249 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+type statisticsPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+type dailyStatRow struct {
+	Day          time.Time
+	FocusMinutes float64
+	BreakMinutes float64
+}
+
+func (p *statisticsPersistence) Daily(ctx context.Context, accID model.AccountID, from, to time.Time, loc *time.Location) ([]model.DailyStat, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []dailyStatRow
+	err := db.Raw(`
+		SELECT day, SUM(focus_minutes) AS focus_minutes, SUM(break_minutes) AS break_minutes
+		FROM (
+			SELECT (execution_date AT TIME ZONE @tz)::date AS day, focus_time AS focus_minutes, 0 AS break_minutes
+			FROM times
+			WHERE account_id = @account AND execution_date >= @from AND execution_date < @to AND deleted_at IS NULL
+			UNION ALL
+			SELECT (execution_date AT TIME ZONE @tz)::date AS day, 0 AS focus_minutes, minutes AS break_minutes
+			FROM breaks
+			WHERE account_id = @account AND execution_date >= @from AND execution_date < @to
+		) AS sessions
+		GROUP BY day
+		ORDER BY day`,
+		map[string]any{
+			"tz":      loc.String(),
+			"account": accID.String(),
+			"from":    from,
+			"to":      to,
+		},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.DailyStat, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.DailyStat{
+			Date:         time.Date(r.Day.Year(), r.Day.Month(), r.Day.Day(), 0, 0, 0, 0, loc),
+			FocusMinutes: r.FocusMinutes,
+			BreakMinutes: r.BreakMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (p *statisticsPersistence) DailyFocus(ctx context.Context, accID model.AccountID, from, to time.Time, loc *time.Location) ([]model.DailyStat, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []dailyStatRow
+	err := db.Raw(`
+		SELECT (execution_date AT TIME ZONE @tz)::date AS day, SUM(focus_time) AS focus_minutes
+		FROM times
+		WHERE account_id = @account AND execution_date >= @from AND execution_date < @to AND deleted_at IS NULL
+		GROUP BY day
+		ORDER BY day`,
+		map[string]any{
+			"tz":      loc.String(),
+			"account": accID.String(),
+			"from":    from,
+			"to":      to,
+		},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.DailyStat, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.DailyStat{
+			Date:         time.Date(r.Day.Year(), r.Day.Month(), r.Day.Day(), 0, 0, 0, 0, loc),
+			FocusMinutes: r.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (p *statisticsPersistence) FocusMinutes(ctx context.Context, accID model.AccountID, from, to time.Time) (float64, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var total float64
+	err := db.Raw(`
+		SELECT COALESCE(SUM(focus_time), 0)
+		FROM times
+		WHERE account_id = ? AND execution_date >= ? AND execution_date < ? AND deleted_at IS NULL`,
+		accID.String(), from, to,
+	).Scan(&total).Error
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return total, nil
+}
+
+type taskTotalRow struct {
+	TaskID             string
+	ProjectID          *string
+	Title              string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
+
+func (p *statisticsPersistence) TaskTotals(ctx context.Context, accID model.AccountID) ([]model.TaskTotal, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []taskTotalRow
+	err := db.Raw(`
+		SELECT
+			tasks.id AS task_id,
+			tasks.project_id,
+			tasks.title,
+			tasks.estimated_pomodoros,
+			COUNT(times.id) AS actual_pomodoros,
+			COALESCE(SUM(times.focus_time), 0) AS focus_minutes
+		FROM tasks
+		LEFT JOIN times ON times.task_id = tasks.id AND times.deleted_at IS NULL
+		WHERE tasks.account_id = ?
+		GROUP BY tasks.id
+		ORDER BY tasks.created_at`,
+		accID.String(),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.TaskTotal, 0, len(rows))
+	for _, r := range rows {
+		var projectID *model.ProjectID
+		if r.ProjectID != nil {
+			id := model.ProjectID(*r.ProjectID)
+			projectID = &id
+		}
+
+		res = append(res, model.TaskTotal{
+			TaskID:             model.TaskID(r.TaskID),
+			ProjectID:          projectID,
+			Title:              r.Title,
+			EstimatedPomodoros: r.EstimatedPomodoros,
+			ActualPomodoros:    r.ActualPomodoros,
+			FocusMinutes:       r.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+type projectTotalRow struct {
+	ProjectID          string
+	Name               string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
+
+func (p *statisticsPersistence) ProjectTotals(ctx context.Context, accID model.AccountID) ([]model.ProjectTotal, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	// タスク単位で先に集計してから結合し、見積もりが集中の回数分重複して合計されないようにする
+	var rows []projectTotalRow
+	err := db.Raw(`
+		SELECT
+			projects.id AS project_id,
+			projects.name,
+			COALESCE(SUM(tasks.estimated_pomodoros), 0) AS estimated_pomodoros,
+			COALESCE(SUM(totals.pomodoros), 0) AS actual_pomodoros,
+			COALESCE(SUM(totals.minutes), 0) AS focus_minutes
+		FROM projects
+		LEFT JOIN tasks ON tasks.project_id = projects.id
+		LEFT JOIN (
+			SELECT task_id, COUNT(*) AS pomodoros, SUM(focus_time) AS minutes
+			FROM times
+			WHERE account_id = @account AND task_id IS NOT NULL AND deleted_at IS NULL
+			GROUP BY task_id
+		) AS totals ON totals.task_id = tasks.id
+		WHERE projects.account_id = @account
+		GROUP BY projects.id
+		ORDER BY projects.created_at`,
+		map[string]any{"account": accID.String()},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.ProjectTotal, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, model.ProjectTotal{
+			ProjectID:          model.ProjectID(r.ProjectID),
+			Name:               r.Name,
+			EstimatedPomodoros: r.EstimatedPomodoros,
+			ActualPomodoros:    r.ActualPomodoros,
+			FocusMinutes:       r.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (p *statisticsPersistence) TagTotals(ctx context.Context, accID model.AccountID) ([]model.TagTotal, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var rows []model.TagTotal
+	err := db.Raw(`
+		SELECT
+			tags.name,
+			COUNT(times.id) AS actual_pomodoros,
+			COALESCE(SUM(times.focus_time), 0) AS focus_minutes
+		FROM tags
+		JOIN time_tags ON time_tags.tag_id = tags.id
+		JOIN times ON times.id = time_tags.time_id AND times.deleted_at IS NULL
+		WHERE tags.account_id = ?
+		GROUP BY tags.id
+		ORDER BY focus_minutes DESC, tags.name`,
+		accID.String(),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return rows, nil
+}
+
+func NewStatisticsPersistence(db *gorm.DB, timeout time.Duration) repository.StatisticsRepository {
+	return &statisticsPersistence{db, timeout}
+}
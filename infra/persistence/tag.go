@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+type tagPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *tagPersistence) Suggest(ctx context.Context, accID model.AccountID, prefix string, limit int) ([]model.Tag, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Tag
+	err := db.Model(&entity.Tag{}).
+		Select("tags.*").
+		Joins("LEFT JOIN time_tags ON time_tags.tag_id = tags.id").
+		Where("tags.account_id = ? AND tags.name LIKE ?", accID.String(), escapeLike(prefix)+"%").
+		Group("tags.id").
+		Order("COUNT(time_tags.time_id) DESC, tags.name").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Tag, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func NewTagPersistence(db *gorm.DB, timeout time.Duration) repository.TagRepository {
+	return &tagPersistence{db, timeout}
+}
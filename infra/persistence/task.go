@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taskPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *taskPersistence) FindByID(ctx context.Context, id model.TaskID) (model.Task, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.Task
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Task{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Task{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *taskPersistence) FindByAccountID(ctx context.Context, accID model.AccountID, projectID *model.ProjectID) ([]model.Task, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	q := db.Where("account_id = ?", accID.String())
+	if projectID != nil {
+		q = q.Where("project_id = ?", projectID.String())
+	}
+
+	var entities []entity.Task
+	if err := q.Order("created_at").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Task, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *taskPersistence) Save(ctx context.Context, t model.Task) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToTaskEntity(t)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"project_id", "title", "estimated_pomodoros", "completed", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *taskPersistence) Delete(ctx context.Context, id model.TaskID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("id = ?", id.String()).Delete(&entity.Task{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewTaskPersistence(db *gorm.DB, timeout time.Duration) repository.TaskRepository {
+	return &taskPersistence{db, timeout}
+}
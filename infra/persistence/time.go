@@ -1,41 +1,291 @@
 package persistence
 
 import (
+	"context"
 	"pomodoro-rpg-api/domain/model"
 	"pomodoro-rpg-api/domain/repository"
 	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const defaultTimeLimit = 50
+
 type timePersistence struct {
-	db *gorm.DB
+	db      *gorm.DB
+	timeout time.Duration
 }
 
-func (p *timePersistence) Create(t model.Time) error {
+func (p *timePersistence) Create(ctx context.Context, t model.Time) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
 	entity := entity.ToTimeEntity(t)
-	if err := p.db.Create(&entity).Error; err != nil {
+	if err := db.Create(&entity).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return p.attachTags(db, t)
+}
+
+func (p *timePersistence) Update(ctx context.Context, t model.Time) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToTimeEntity(t)
+	err := db.Model(&entity.Time{}).Where("id = ?", e.ID).Updates(map[string]any{
+		"focus_time": e.FocusTime,
+		"task_id":    e.TaskID,
+		"note":       e.Note,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := db.Where("time_id = ?", e.ID).Delete(&entity.TimeTag{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return p.attachTags(db, t)
+}
+
+func (p *timePersistence) Delete(ctx context.Context, t model.Time) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if t.DeletedAt == nil {
+		return errors.New("time is not deleted")
+	}
+
+	err := db.Model(&entity.Time{}).Where("id = ?", t.ID.String()).Update("deleted_at", *t.DeletedAt).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// attachTags はタグを(account_id, name)で一意に登録し、集中時間と紐付ける
+func (p *timePersistence) attachTags(db *gorm.DB, t model.Time) error {
+	if len(t.Tags) == 0 {
+		return nil
+	}
+
+	tags := make([]entity.Tag, 0, len(t.Tags))
+	for _, name := range t.Tags {
+		tags = append(tags, entity.Tag{
+			ID:        uuid.NewString(),
+			AccountID: t.AccountID.String(),
+			Name:      name,
+		})
+	}
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "name"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var ids []string
+	err = db.Model(&entity.Tag{}).
+		Where("account_id = ? AND name IN ?", t.AccountID.String(), t.Tags).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	timeTags := make([]entity.TimeTag, 0, len(ids))
+	for _, id := range ids {
+		timeTags = append(timeTags, entity.TimeTag{TimeID: t.ID.String(), TagID: id})
+	}
+	if err := db.Create(&timeTags).Error; err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
 
-func (p *timePersistence) GetAll(accID model.AccountID) ([]model.Time, error) {
-	var res []model.Time
+func (p *timePersistence) Find(ctx context.Context, accID model.AccountID, filter repository.TimeFilter) ([]model.Time, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	q := db.Where("account_id = ?", accID.String())
+	if filter.Tag != "" {
+		q = q.Where(`id IN (
+			SELECT time_tags.time_id FROM time_tags
+			JOIN tags ON tags.id = time_tags.tag_id
+			WHERE tags.account_id = ? AND tags.name = ?
+		)`, accID.String(), filter.Tag)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultTimeLimit
+	}
+
+	var entities []entity.Time
+	err := q.Order("execution_date DESC").Limit(limit).Offset(filter.Offset).Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	tags, err := p.findTags(db, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.Time, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel(tags[e.ID]))
+	}
+
+	return res, nil
+}
+
+func (p *timePersistence) FindByIDForUpdate(ctx context.Context, id model.TimeID) (model.Time, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
 
-	err := p.db.Where("account_id = ?", accID).Find(&res).Error
+	var e entity.Time
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id.String()).First(&e).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return []model.Time{}, nil
+			return model.Time{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Time{}, errors.WithStack(err)
+	}
+
+	tags, err := p.findTags(db, []entity.Time{e})
+	if err != nil {
+		return model.Time{}, err
+	}
+
+	return e.ToModel(tags[e.ID]), nil
+}
+
+func (p *timePersistence) FindAccountIDByID(ctx context.Context, id model.TimeID) (model.AccountID, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.Time
+	if err := db.Unscoped().Select("account_id").Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.WithStack(apperr.ErrDataNotFound)
 		}
-		return []model.Time{}, errors.WithStack(err)
+		return "", errors.WithStack(err)
+	}
+
+	return model.AccountID(e.AccountID), nil
+}
+
+func (p *timePersistence) ExistsOverlapping(ctx context.Context, t model.Time) (bool, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Exec(`SELECT pg_advisory_xact_lock(hashtext('times:' || ?))`, t.AccountID.String()).Error; err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	// 記録は終了日時を実施日時として保存しているため、集中時間から開始日時を求めて比べる
+	var count int64
+	err := db.Model(&entity.Time{}).
+		Where("account_id = ? AND id <> ? AND execution_date > ? AND execution_date - focus_time * INTERVAL '1 minute' < ?",
+			t.AccountID.String(), t.ID.String(), t.StartedAt(), t.ExecutionDate).
+		Limit(1).
+		Count(&count).Error
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return count > 0, nil
+}
+
+func (p *timePersistence) FindChangedSince(ctx context.Context, accID model.AccountID, since model.SyncPosition, limit int) ([]model.Time, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Time
+	err := db.Unscoped().
+		Where("account_id = ? AND (updated_at, id) > (?, ?)", accID.String(), since.At, since.ID).
+		Order("updated_at, id").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	tags, err := p.findTags(db, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.Time, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel(tags[e.ID]))
+	}
+
+	return res, nil
+}
+
+func (p *timePersistence) LatestUpdatedAt(ctx context.Context, accID model.AccountID) (time.Time, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var latest *time.Time
+	err := db.Unscoped().Model(&entity.Time{}).
+		Select("MAX(updated_at)").
+		Where("account_id = ?", accID.String()).
+		Scan(&latest).Error
+	if err != nil {
+		return time.Time{}, errors.WithStack(err)
+	}
+	if latest == nil {
+		return time.Time{}, nil
+	}
+
+	return *latest, nil
+}
+
+// findTags は集中時間ごとのタグ名をまとめて取得する
+func (p *timePersistence) findTags(db *gorm.DB, entities []entity.Time) (map[string][]string, error) {
+	res := make(map[string][]string, len(entities))
+	if len(entities) == 0 {
+		return res, nil
+	}
+
+	ids := make([]string, 0, len(entities))
+	for _, e := range entities {
+		ids = append(ids, e.ID)
+	}
+
+	var rows []struct {
+		TimeID string
+		Name   string
+	}
+	err := db.Table("time_tags").
+		Select("time_tags.time_id, tags.name").
+		Joins("JOIN tags ON tags.id = time_tags.tag_id").
+		Where("time_tags.time_id IN ?", ids).
+		Order("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, r := range rows {
+		res[r.TimeID] = append(res[r.TimeID], r.Name)
 	}
 
 	return res, nil
 }
 
-func NewTimePersistence(db *gorm.DB) repository.TimeRepository {
-	return &timePersistence{db}
+func NewTimePersistence(db *gorm.DB, timeout time.Duration) repository.TimeRepository {
+	return &timePersistence{db, timeout}
 }
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type timerSessionPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *timerSessionPersistence) FindActiveByAccountID(ctx context.Context, accID model.AccountID) (model.TimerSession, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.TimerSession
+	err := db.Where("account_id = ? AND status IN ?", accID.String(), []string{string(model.TimerRunning), string(model.TimerPaused)}).
+		First(&e).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.TimerSession{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.TimerSession{}, errors.WithStack(err)
+	}
+
+	return e.ToModel(), nil
+}
+
+func (p *timerSessionPersistence) Save(ctx context.Context, s model.TimerSession) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e := entity.ToTimerSessionEntity(s)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "elapsed_seconds", "resumed_at", "updated_at"}),
+	}).Create(&e).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *timerSessionPersistence) Notify(ctx context.Context, s model.TimerSession) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	payload, err := json.Marshal(entity.ToTimerSessionEntity(s))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := db.Exec("SELECT pg_notify(?, ?)", entity.TimerSessionChannel, string(payload)).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewTimerSessionPersistence(db *gorm.DB, timeout time.Duration) repository.TimerSessionRepository {
+	return &timerSessionPersistence{db, timeout}
+}
@@ -0,0 +1,28 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type transaction struct {
+	db *gorm.DB
+}
+
+func (t *transaction) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+func NewTransaction(db *gorm.DB) repository.Transaction {
+	return &transaction{db}
+}
@@ -0,0 +1,204 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testTimeout = 5 * time.Second
+
+// newTestDB はTEST_DB_DSNのPostgreSQLに接続し、テスト専用の一時テーブルを作る。
+// TEST_DB_DSNが未設定の場合はスキップする
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Exec("DROP TABLE IF EXISTS transaction_tests").Error; err != nil {
+		t.Fatalf("drop table: %v", err)
+	}
+	if err := db.Exec("CREATE TABLE transaction_tests (name TEXT PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("DROP TABLE IF EXISTS transaction_tests") })
+
+	return db
+}
+
+func insertTestRow(ctx context.Context, db *gorm.DB, name string) error {
+	db, cancel := conn(ctx, db, testTimeout)
+	defer cancel()
+
+	return db.Exec("INSERT INTO transaction_tests (name) VALUES (?)", name).Error
+}
+
+func countTestRows(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var n int64
+	if err := db.Table("transaction_tests").Count(&n).Error; err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	return n
+}
+
+func TestTransactionDo(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context, db *gorm.DB, tx *transaction) error
+		wantErr error
+		panics  bool
+		want    int64
+	}{
+		{
+			name: "fnが成功した場合はコミットする",
+			fn: func(ctx context.Context, db *gorm.DB, tx *transaction) error {
+				if err := insertTestRow(ctx, db, "a"); err != nil {
+					return err
+				}
+				return insertTestRow(ctx, db, "b")
+			},
+			want: 2,
+		},
+		{
+			name: "fnがエラーを返した場合はロールバックする",
+			fn: func(ctx context.Context, db *gorm.DB, tx *transaction) error {
+				if err := insertTestRow(ctx, db, "a"); err != nil {
+					return err
+				}
+				return errFn
+			},
+			wantErr: errFn,
+			want:    0,
+		},
+		{
+			name: "fnがpanicした場合はロールバックする",
+			fn: func(ctx context.Context, db *gorm.DB, tx *transaction) error {
+				if err := insertTestRow(ctx, db, "a"); err != nil {
+					return err
+				}
+				panic("fn panicked")
+			},
+			panics: true,
+			want:   0,
+		},
+		{
+			name: "内側のDoは外側のトランザクションに参加し、内側のエラーで外側もロールバックする",
+			fn: func(ctx context.Context, db *gorm.DB, tx *transaction) error {
+				if err := insertTestRow(ctx, db, "outer"); err != nil {
+					return err
+				}
+				return tx.Do(ctx, func(ctx context.Context) error {
+					if err := insertTestRow(ctx, db, "inner"); err != nil {
+						return err
+					}
+					return errFn
+				})
+			},
+			wantErr: errFn,
+			want:    0,
+		},
+		{
+			name: "内側のDoが成功した場合は外側と一緒にコミットする",
+			fn: func(ctx context.Context, db *gorm.DB, tx *transaction) error {
+				if err := insertTestRow(ctx, db, "outer"); err != nil {
+					return err
+				}
+				return tx.Do(ctx, func(ctx context.Context) error {
+					return insertTestRow(ctx, db, "inner")
+				})
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			tx := &transaction{db}
+
+			err := func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						if !tt.panics {
+							t.Fatalf("unexpected panic: %v", r)
+						}
+						err = nil
+						return
+					}
+					if tt.panics {
+						t.Fatal("expected panic")
+					}
+				}()
+				return tx.Do(context.Background(), func(ctx context.Context) error {
+					return tt.fn(ctx, db, tx)
+				})
+			}()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := countTestRows(t, db); got != tt.want {
+				t.Fatalf("rows = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// 内側のDoが外側と同じトランザクションを使っていれば、コミット前の行が外側から見える
+func TestTransactionDoNestedSharesTransaction(t *testing.T) {
+	db := newTestDB(t)
+	tx := &transaction{db}
+
+	err := tx.Do(context.Background(), func(ctx context.Context) error {
+		if err := tx.Do(ctx, func(ctx context.Context) error {
+			return insertTestRow(ctx, db, "inner")
+		}); err != nil {
+			return err
+		}
+
+		// トランザクション外からはまだ見えない
+		if got := countTestRows(t, db); got != 0 {
+			t.Errorf("rows outside transaction = %d, want 0", got)
+		}
+
+		var n int64
+		c, cancel := conn(ctx, db, testTimeout)
+		defer cancel()
+		if err := c.Table("transaction_tests").Count(&n).Error; err != nil {
+			return err
+		}
+		if n != 1 {
+			t.Errorf("rows inside transaction = %d, want 1", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got := countTestRows(t, db); got != 1 {
+		t.Fatalf("rows = %d, want 1", got)
+	}
+}
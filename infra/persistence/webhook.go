@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+)
+
+type webhookPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *webhookPersistence) FindByID(ctx context.Context, id model.WebhookID) (model.Webhook, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var e entity.Webhook
+	if err := db.Where("id = ?", id.String()).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Webhook{}, errors.WithStack(apperr.ErrDataNotFound)
+		}
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	return e.ToModel()
+}
+
+func (p *webhookPersistence) FindByAccountID(ctx context.Context, accID model.AccountID) ([]model.Webhook, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.Webhook
+	if err := db.Where("account_id = ?", accID.String()).Order("created_at").Find(&entities).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.Webhook, 0, len(entities))
+	for _, e := range entities {
+		w, err := e.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, w)
+	}
+
+	return res, nil
+}
+
+func (p *webhookPersistence) Create(ctx context.Context, w model.Webhook) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	e, err := entity.ToWebhookEntity(w)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Create(&e).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *webhookPersistence) Delete(ctx context.Context, id model.WebhookID) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	if err := db.Where("id = ?", id.String()).Delete(&entity.Webhook{}).Error; err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func NewWebhookPersistence(db *gorm.DB, timeout time.Duration) repository.WebhookRepository {
+	return &webhookPersistence{db, timeout}
+}
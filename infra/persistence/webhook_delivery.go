@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/entity"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookDeliveryPersistence struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+func (p *webhookDeliveryPersistence) Create(ctx context.Context, deliveries ...model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	entities := make([]entity.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		entities = append(entities, entity.ToWebhookDeliveryEntity(d))
+	}
+
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "webhook_id"}, {Name: "event_key"}},
+		DoNothing: true,
+	}).Create(&entities).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *webhookDeliveryPersistence) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	now := time.Now()
+
+	var entities []entity.WebhookDelivery
+	err := db.Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), string(model.WebhookDeliveryPending), now, limit,
+	).Scan(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].CreatedAt.Before(entities[j].CreatedAt)
+	})
+
+	res := make([]model.WebhookDelivery, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func (p *webhookDeliveryPersistence) Update(ctx context.Context, d model.WebhookDelivery) error {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	err := db.Model(&entity.WebhookDelivery{}).Where("id = ?", d.ID).Updates(map[string]any{
+		"status":          string(d.Status),
+		"attempts":        d.Attempts,
+		"next_attempt_at": d.NextAttemptAt,
+		"response_code":   d.ResponseCode,
+		"last_error":      d.LastError,
+		"delivered_at":    d.DeliveredAt,
+	}).Error
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *webhookDeliveryPersistence) FindByWebhookID(ctx context.Context, id model.WebhookID, limit int) ([]model.WebhookDelivery, error) {
+	db, cancel := conn(ctx, p.db, p.timeout)
+	defer cancel()
+
+	var entities []entity.WebhookDelivery
+	err := db.Where("webhook_id = ?", id.String()).Order("created_at DESC").Limit(limit).Find(&entities).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res := make([]model.WebhookDelivery, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.ToModel())
+	}
+
+	return res, nil
+}
+
+func NewWebhookDeliveryPersistence(db *gorm.DB, timeout time.Duration) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryPersistence{db, timeout}
+}
@@ -0,0 +1,60 @@
+package realtime
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"sync"
+)
+
+// subscriberBuffer を超えて溜まった通知は、遅いクライアントを待たないよう破棄する
+const subscriberBuffer = 8
+
+// Hub はプロセス内でアカウントごとの購読者にタイマーの状態を配信する
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[model.AccountID]map[chan model.TimerSession]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[model.AccountID]map[chan model.TimerSession]struct{}{},
+	}
+}
+
+// Subscribe は購読用のチャネルと購読を解除する関数を返す
+func (h *Hub) Subscribe(accID model.AccountID) (<-chan model.TimerSession, func()) {
+	ch := make(chan model.TimerSession, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[accID] == nil {
+		h.subscribers[accID] = map[chan model.TimerSession]struct{}{}
+	}
+	h.subscribers[accID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[accID], ch)
+			if len(h.subscribers[accID]) == 0 {
+				delete(h.subscribers, accID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *Hub) Broadcast(s model.TimerSession) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[s.AccountID] {
+		select {
+		case ch <- s:
+		default:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/infra/entity"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+const reconnectInterval = 5 * time.Second
+
+// Listener はPostgresのLISTEN/NOTIFYで他のレプリカを含むタイマーと部屋の状態変更を受け取り、各Hubに流す
+type Listener struct {
+	dsn     string
+	hub     *Hub
+	roomHub *RoomHub
+}
+
+func NewListener(dsn string, hub *Hub, roomHub *RoomHub) *Listener {
+	return &Listener{dsn, hub, roomHub}
+}
+
+// Run はctxがキャンセルされるまで通知を待ち受け、接続が切れた場合は再接続する
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("timer listener disconnected: %+v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer conn.Close(context.Background())
+
+	for _, channel := range []string{entity.TimerSessionChannel, entity.FocusRoomChannel} {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if n.Channel == entity.FocusRoomChannel {
+			l.roomHub.Broadcast(model.FocusRoomID(n.Payload))
+			continue
+		}
+
+		var e entity.TimerSession
+		if err := json.Unmarshal([]byte(n.Payload), &e); err != nil {
+			log.Printf("invalid timer notification: %v", err)
+			continue
+		}
+
+		l.hub.Broadcast(e.ToModel())
+	}
+}
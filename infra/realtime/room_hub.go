@@ -0,0 +1,58 @@
+package realtime
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"sync"
+)
+
+// RoomHub はプロセス内で部屋ごとの購読者に状態の変更を知らせる。
+// 変更の内容は購読者がそれぞれ読み直すため、通知が溜まっている場合は1つにまとめる
+type RoomHub struct {
+	mu          sync.RWMutex
+	subscribers map[model.FocusRoomID]map[chan struct{}]struct{}
+}
+
+func NewRoomHub() *RoomHub {
+	return &RoomHub{
+		subscribers: map[model.FocusRoomID]map[chan struct{}]struct{}{},
+	}
+}
+
+// Subscribe は変更を知らせるチャネルと購読を解除する関数を返す
+func (h *RoomHub) Subscribe(roomID model.FocusRoomID) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.subscribers[roomID] == nil {
+		h.subscribers[roomID] = map[chan struct{}]struct{}{}
+	}
+	h.subscribers[roomID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[roomID], ch)
+			if len(h.subscribers[roomID]) == 0 {
+				delete(h.subscribers, roomID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *RoomHub) Broadcast(roomID model.FocusRoomID) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[roomID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"pomodoro-rpg-api/pkg/apperr"
 	"pomodoro-rpg-api/usecase/output"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
@@ -28,15 +29,15 @@ var (
 )
 
 type CognitoService interface {
-	SignIn(email, password string) (output.SignIn, error)
-	SignUp(email, password string) (string, error)
-	SignOut(token string) error
-	ConfirmSignUp(email, code string) error
-	GetEmail(token string) (string, error)
-	ChangePassword(token, previousPass, proposedPass string) error
-	ForgotPassword(email string) error
-	ConfirmForgotPassword(email, code, password string) error
-	GetJSONWebKeys() (*jose.JSONWebKeySet, error)
+	SignIn(ctx context.Context, email, password string) (output.SignIn, error)
+	SignUp(ctx context.Context, email, password string) (string, error)
+	SignOut(ctx context.Context, token string) error
+	ConfirmSignUp(ctx context.Context, email, code string) error
+	GetEmail(ctx context.Context, token string) (string, error)
+	ChangePassword(ctx context.Context, token, previousPass, proposedPass string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ConfirmForgotPassword(ctx context.Context, email, code, password string) error
+	GetJSONWebKeys(ctx context.Context) (*jose.JSONWebKeySet, error)
 }
 
 type cognitoService struct {
@@ -44,9 +45,13 @@ type cognitoService struct {
 	ClientID     string
 	ClientSecret string
 	UserPoolID   string
+	Timeout      time.Duration
 }
 
-func (c *cognitoService) ConfirmForgotPassword(email string, code string, password string) error {
+func (c *cognitoService) ConfirmForgotPassword(ctx context.Context, email string, code string, password string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	input := cognitoidentityprovider.ConfirmForgotPasswordInput{
 		ClientId:         aws.String(c.ClientID),
 		ConfirmationCode: aws.String(code),
@@ -55,7 +60,7 @@ func (c *cognitoService) ConfirmForgotPassword(email string, code string, passwo
 		SecretHash:       aws.String(secretHash(email, c.ClientID, c.ClientSecret)),
 	}
 
-	_, err := c.Client.ConfirmForgotPassword(context.TODO(), &input)
+	_, err := c.Client.ConfirmForgotPassword(ctx, &input)
 	if err != nil {
 		if errors.Is(err, invalidParameterException) || errors.Is(err, codeMismatchException) {
 			return errors.WithStack(apperr.ErrInvalidParameter)
@@ -66,14 +71,17 @@ func (c *cognitoService) ConfirmForgotPassword(email string, code string, passwo
 	return nil
 }
 
-func (c *cognitoService) ForgotPassword(email string) error {
+func (c *cognitoService) ForgotPassword(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	input := cognitoidentityprovider.ForgotPasswordInput{
 		ClientId:   aws.String(c.ClientID),
 		Username:   aws.String(email),
 		SecretHash: aws.String(secretHash(email, c.ClientID, c.ClientSecret)),
 	}
 
-	_, err := c.Client.ForgotPassword(context.TODO(), &input)
+	_, err := c.Client.ForgotPassword(ctx, &input)
 	if err != nil {
 		if errors.Is(err, invalidParameterException) {
 			return errors.WithStack(apperr.ErrInvalidParameter)
@@ -84,14 +92,17 @@ func (c *cognitoService) ForgotPassword(email string) error {
 	return nil
 }
 
-func (c *cognitoService) ChangePassword(token string, previousPass string, proposedPass string) error {
+func (c *cognitoService) ChangePassword(ctx context.Context, token string, previousPass string, proposedPass string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	input := cognitoidentityprovider.ChangePasswordInput{
 		AccessToken:      aws.String(token),
 		PreviousPassword: aws.String(previousPass),
 		ProposedPassword: aws.String(proposedPass),
 	}
 
-	_, err := c.Client.ChangePassword(context.TODO(), &input)
+	_, err := c.Client.ChangePassword(ctx, &input)
 	if err != nil {
 		if errors.Is(err, unauthorizedException) {
 			return errors.WithStack(apperr.ErrUnautorizedExeption)
@@ -106,12 +117,15 @@ func (c *cognitoService) ChangePassword(token string, previousPass string, propo
 	return nil
 }
 
-func (c *cognitoService) GetEmail(token string) (string, error) {
+func (c *cognitoService) GetEmail(ctx context.Context, token string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	input := cognitoidentityprovider.GetUserInput{
 		AccessToken: aws.String(token),
 	}
 
-	output, err := c.Client.GetUser(context.TODO(), &input)
+	output, err := c.Client.GetUser(ctx, &input)
 	if err != nil {
 		if errors.Is(err, userNotFoundException) {
 			return "", errors.WithStack(apperr.ErrDataNotFound)
@@ -133,7 +147,10 @@ func (c *cognitoService) GetEmail(token string) (string, error) {
 	return email, nil
 }
 
-func (c *cognitoService) ConfirmSignUp(email string, code string) error {
+func (c *cognitoService) ConfirmSignUp(ctx context.Context, email string, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	hash := secretHash(email, c.ClientID, c.ClientSecret)
 	input := cognitoidentityprovider.ConfirmSignUpInput{
 		ClientId:         &c.ClientID,
@@ -142,7 +159,7 @@ func (c *cognitoService) ConfirmSignUp(email string, code string) error {
 		SecretHash:       &hash,
 	}
 
-	_, err := c.Client.ConfirmSignUp(context.TODO(), &input)
+	_, err := c.Client.ConfirmSignUp(ctx, &input)
 	if err != nil {
 		if errors.Is(err, codeMismatchException) || errors.Is(err, invalidParameterException) {
 			return errors.WithStack(apperr.ErrInvalidParameter)
@@ -153,8 +170,11 @@ func (c *cognitoService) ConfirmSignUp(email string, code string) error {
 	return nil
 }
 
-func (c *cognitoService) SignIn(email string, password string) (output.SignIn, error) {
-	result, err := c.Client.InitiateAuth(context.TODO(), &cognitoidentityprovider.InitiateAuthInput{
+func (c *cognitoService) SignIn(ctx context.Context, email string, password string) (output.SignIn, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	result, err := c.Client.InitiateAuth(ctx, &cognitoidentityprovider.InitiateAuthInput{
 		AuthFlow: types.AuthFlowTypeUserPasswordAuth,
 		ClientId: aws.String(c.ClientID),
 		AuthParameters: map[string]string{
@@ -182,9 +202,12 @@ func (c *cognitoService) SignIn(email string, password string) (output.SignIn, e
 	}, nil
 }
 
-func (c *cognitoService) SignUp(email string, password string) (string, error) {
+func (c *cognitoService) SignUp(ctx context.Context, email string, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	hash := secretHash(email, c.ClientID, c.ClientSecret)
-	result, err := c.Client.SignUp(context.TODO(), &cognitoidentityprovider.SignUpInput{
+	result, err := c.Client.SignUp(ctx, &cognitoidentityprovider.SignUpInput{
 		ClientId:   aws.String(c.ClientID),
 		Password:   aws.String(password),
 		Username:   aws.String(email),
@@ -207,10 +230,18 @@ func (c *cognitoService) SignUp(email string, password string) (string, error) {
 	return *result.UserSub, nil
 }
 
-func (c *cognitoService) GetJSONWebKeys() (*jose.JSONWebKeySet, error) {
+func (c *cognitoService) GetJSONWebKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	url := fmt.Sprintf("https://cognito-idp.ap-northeast-1.amazonaws.com/%s/.well-known/jwks.json", c.UserPoolID)
 
-	res, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -224,12 +255,15 @@ func (c *cognitoService) GetJSONWebKeys() (*jose.JSONWebKeySet, error) {
 	return &jwks, nil
 }
 
-func (c *cognitoService) SignOut(token string) error {
+func (c *cognitoService) SignOut(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
 	input := &cognitoidentityprovider.GlobalSignOutInput{
 		AccessToken: aws.String(token),
 	}
 
-	_, err := c.Client.GlobalSignOut(context.TODO(), input)
+	_, err := c.Client.GlobalSignOut(ctx, input)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -243,7 +277,7 @@ func secretHash(email string, clientID string, clientSecret string) string {
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
 }
 
-func NewCognitoService(clientID, clientSecret, userPoolID string) (CognitoService, error) {
+func NewCognitoService(clientID, clientSecret, userPoolID string, timeout time.Duration) (CognitoService, error) {
 	cfg, err := config.LoadDefaultConfig(context.TODO(),
 		config.WithRegion("ap-northeast-1"),
 	)
@@ -256,5 +290,6 @@ func NewCognitoService(clientID, clientSecret, userPoolID string) (CognitoServic
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		UserPoolID:   userPoolID,
+		Timeout:      timeout,
 	}, nil
 }
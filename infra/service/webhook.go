@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	WebhookSignatureHeader = "X-Pomodoro-Signature"
+	WebhookTimestampHeader = "X-Pomodoro-Timestamp"
+)
+
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, payload []byte) (int, error)
+}
+
+type webhookSender struct {
+	client *http.Client
+}
+
+// Send はペイロードに署名してPOSTし、レスポンスのステータスコードを返す。
+// 受信側は timestamp + "." + body をシークレットでHMAC-SHA256した値と署名ヘッダを比較して検証する
+func (s *webhookSender) Send(ctx context.Context, url, secret string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pomodoro-rpg-webhook")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+signWebhook(secret, timestamp, payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, errors.Newf("unexpected status: %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+func signWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 利用者が登録したURLから内部ネットワークへアクセスされないよう、プライベートアドレスへの接続を拒否する
+func denyPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook destination %s is not allowed", host)
+	}
+
+	return nil
+}
+
+func NewWebhookSender(timeout time.Duration) WebhookSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: denyPrivateAddress,
+	}
+
+	return &webhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:       nil,
+				DialContext: dialer.DialContext,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE audit_events (
+    id VARCHAR(255) PRIMARY KEY,
+    actor_id VARCHAR(255),
+    action VARCHAR(255) NOT NULL,
+    target_type VARCHAR(255) NOT NULL,
+    target_id VARCHAR(255),
+    request_id VARCHAR(255),
+    ip VARCHAR(255),
+    diff JSONB,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, created_at);
+CREATE INDEX idx_audit_events_target ON audit_events (target_type, target_id, created_at);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+
+-- +migrate StatementBegin
+CREATE FUNCTION reject_audit_events_modification() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_events_modification();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS reject_audit_events_modification();
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE characters (
+    account_id VARCHAR(255) PRIMARY KEY,
+    level INT NOT NULL DEFAULT 1,
+    exp INT NOT NULL DEFAULT 0,
+    gold INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE outbox (
+    id VARCHAR(255) PRIMARY KEY,
+    event_name VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    dispatched_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at) WHERE dispatched_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS characters;
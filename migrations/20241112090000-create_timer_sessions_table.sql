@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE timer_sessions (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    planned_seconds INT NOT NULL,
+    elapsed_seconds INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    resumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE UNIQUE INDEX uq_timer_sessions_active ON timer_sessions (account_id) WHERE status IN ('running', 'paused');
+
+-- +migrate Down
+DROP TABLE IF EXISTS timer_sessions;
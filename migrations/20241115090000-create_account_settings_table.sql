@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE account_settings (
+    account_id VARCHAR(255) PRIMARY KEY,
+    focus_minutes INT NOT NULL,
+    short_break_minutes INT NOT NULL,
+    long_break_minutes INT NOT NULL,
+    long_break_interval INT NOT NULL,
+    auto_start_breaks BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_start_focus BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_sound BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_desktop BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS account_settings;
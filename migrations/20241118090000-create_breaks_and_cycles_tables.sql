@@ -0,0 +1,32 @@
+-- +migrate Up
+ALTER TABLE timer_sessions ADD COLUMN kind VARCHAR(32) NOT NULL DEFAULT 'focus';
+
+CREATE TABLE breaks (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    minutes DOUBLE PRECISION NOT NULL,
+    execution_date TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_breaks_account_id_execution_date ON breaks (account_id, execution_date);
+CREATE INDEX idx_times_account_id_execution_date ON times (account_id, execution_date);
+
+CREATE TABLE pomodoro_cycles (
+    account_id VARCHAR(255) PRIMARY KEY,
+    focus_count INT NOT NULL DEFAULT 0,
+    completed_cycles INT NOT NULL DEFAULT 0,
+    last_kind VARCHAR(32) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS pomodoro_cycles;
+DROP INDEX IF EXISTS idx_times_account_id_execution_date;
+DROP TABLE IF EXISTS breaks;
+ALTER TABLE timer_sessions DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,38 @@
+-- +migrate Up
+CREATE TABLE projects (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_projects_account_id ON projects (account_id);
+
+CREATE TABLE tasks (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    project_id VARCHAR(255),
+    title VARCHAR(255) NOT NULL,
+    estimated_pomodoros INT NOT NULL DEFAULT 0,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id),
+    CONSTRAINT fk_project_id FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_tasks_account_id ON tasks (account_id);
+CREATE INDEX idx_tasks_project_id ON tasks (project_id);
+
+ALTER TABLE times ADD COLUMN task_id VARCHAR(255);
+ALTER TABLE times ADD CONSTRAINT fk_task_id FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL;
+CREATE INDEX idx_times_task_id ON times (task_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_times_task_id;
+ALTER TABLE times DROP CONSTRAINT IF EXISTS fk_task_id;
+ALTER TABLE times DROP COLUMN IF EXISTS task_id;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,28 @@
+-- +migrate Up
+ALTER TABLE times ADD COLUMN note TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE tags (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id),
+    CONSTRAINT uq_tags_account_id_name UNIQUE (account_id, name)
+);
+
+CREATE INDEX idx_tags_account_id_name_pattern ON tags (account_id, name text_pattern_ops);
+
+CREATE TABLE time_tags (
+    time_id VARCHAR(255) NOT NULL,
+    tag_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (time_id, tag_id),
+    CONSTRAINT fk_time_id FOREIGN KEY (time_id) REFERENCES times(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tag_id FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_time_tags_tag_id ON time_tags (tag_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS time_tags;
+DROP TABLE IF EXISTS tags;
+ALTER TABLE times DROP COLUMN IF EXISTS note;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE times ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- +migrate Down
+ALTER TABLE times DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+-- +migrate Up
+CREATE INDEX idx_times_account_id_updated_at_id ON times (account_id, updated_at, id);
+CREATE INDEX idx_breaks_account_id_created_at_id ON breaks (account_id, created_at, id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_breaks_account_id_created_at_id;
+DROP INDEX IF EXISTS idx_times_account_id_updated_at_id;
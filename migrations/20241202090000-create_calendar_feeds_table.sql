@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE calendar_feeds (
+    account_id VARCHAR(255) PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE UNIQUE INDEX uq_calendar_feeds_token_hash ON calendar_feeds (token_hash);
+
+-- +migrate Down
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE account_settings ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE goals (
+    account_id VARCHAR(255) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    target_minutes INT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    PRIMARY KEY (account_id, period),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE goal_achievements (
+    account_id VARCHAR(255) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    period_start DATE NOT NULL,
+    target_minutes INT NOT NULL,
+    focus_minutes DOUBLE PRECISION NOT NULL,
+    achieved_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (account_id, period, period_start),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_goal_achievements_account_id_achieved_at ON goal_achievements (account_id, achieved_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS goal_achievements;
+DROP TABLE IF EXISTS goals;
+ALTER TABLE account_settings DROP COLUMN IF EXISTS timezone;
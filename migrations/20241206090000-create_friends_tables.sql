@@ -0,0 +1,50 @@
+-- +migrate Up
+CREATE TABLE friend_requests (
+    id VARCHAR(255) PRIMARY KEY,
+    requester_id VARCHAR(255) NOT NULL,
+    addressee_email VARCHAR(255) NOT NULL,
+    addressee_id VARCHAR(255),
+    status VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_requester_id FOREIGN KEY (requester_id) REFERENCES accounts(id),
+    CONSTRAINT fk_addressee_id FOREIGN KEY (addressee_id) REFERENCES accounts(id)
+);
+
+-- 断られた後は同じ宛先に申請し直せるよう、応答待ちの申請のみ重複させない
+CREATE UNIQUE INDEX uq_friend_requests_requester_email ON friend_requests (requester_id, addressee_email) WHERE status = 'pending';
+CREATE INDEX idx_friend_requests_addressee_id ON friend_requests (addressee_id) WHERE status = 'pending';
+
+CREATE TABLE friendships (
+    account_id VARCHAR(255) NOT NULL,
+    friend_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (account_id, friend_id),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id),
+    CONSTRAINT fk_friend_id FOREIGN KEY (friend_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE account_blocks (
+    blocker_id VARCHAR(255) NOT NULL,
+    blocked_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT fk_blocker_id FOREIGN KEY (blocker_id) REFERENCES accounts(id),
+    CONSTRAINT fk_blocked_id FOREIGN KEY (blocked_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE privacy_settings (
+    account_id VARCHAR(255) PRIMARY KEY,
+    share_level BOOLEAN NOT NULL DEFAULT TRUE,
+    share_streak BOOLEAN NOT NULL DEFAULT TRUE,
+    share_weekly_focus BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS privacy_settings;
+DROP TABLE IF EXISTS account_blocks;
+DROP TABLE IF EXISTS friendships;
+DROP TABLE IF EXISTS friend_requests;
@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE reward_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    exp INT NOT NULL,
+    gold INT NOT NULL,
+    -- 集中の記録や目標の達成など、後から取り消しうる報酬の付与元
+    source_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_reward_ledger_created_at ON reward_ledger (created_at);
+CREATE INDEX idx_reward_ledger_source_id ON reward_ledger (account_id, source_id) WHERE source_id IS NOT NULL;
+
+CREATE TABLE leaderboard_entries (
+    board VARCHAR(32) NOT NULL,
+    period_start DATE NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    rank INT NOT NULL,
+    refreshed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (board, period_start, account_id)
+);
+
+CREATE INDEX idx_leaderboard_entries_rank ON leaderboard_entries (board, period_start, rank);
+CREATE INDEX idx_times_execution_date ON times (execution_date) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_times_execution_date;
+DROP TABLE IF EXISTS leaderboard_entries;
+DROP TABLE IF EXISTS reward_ledger;
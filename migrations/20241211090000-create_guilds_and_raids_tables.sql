@@ -0,0 +1,61 @@
+-- +migrate Up
+CREATE TABLE guilds (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE guild_members (
+    guild_id VARCHAR(255) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (guild_id, account_id),
+    CONSTRAINT fk_guild_id FOREIGN KEY (guild_id) REFERENCES guilds(id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- アカウントは1つのギルドにのみ所属できる
+CREATE UNIQUE INDEX uq_guild_members_account_id ON guild_members (account_id);
+
+CREATE TABLE raids (
+    id VARCHAR(255) PRIMARY KEY,
+    guild_id VARCHAR(255) NOT NULL,
+    boss VARCHAR(64) NOT NULL,
+    max_hp INT NOT NULL,
+    damage DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reward_exp INT NOT NULL,
+    reward_gold INT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_guild_id FOREIGN KEY (guild_id) REFERENCES guilds(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_raids_guild_id_started_at ON raids (guild_id, started_at);
+-- ギルドごとに同時に挑戦できるレイドは1つまで
+CREATE UNIQUE INDEX uq_raids_guild_id_active ON raids (guild_id) WHERE status = 'active';
+
+CREATE TABLE raid_contributions (
+    raid_id VARCHAR(255) NOT NULL,
+    time_id VARCHAR(255) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    minutes DOUBLE PRECISION NOT NULL,
+    executed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (raid_id, time_id),
+    CONSTRAINT fk_raid_id FOREIGN KEY (raid_id) REFERENCES raids(id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_raid_contributions_time_id ON raid_contributions (time_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS raid_contributions;
+DROP TABLE IF EXISTS raids;
+DROP TABLE IF EXISTS guild_members;
+DROP TABLE IF EXISTS guilds;
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE focus_rooms (
+    id VARCHAR(255) PRIMARY KEY,
+    code VARCHAR(16) NOT NULL,
+    host_id VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    planned_seconds INT NOT NULL,
+    elapsed_seconds INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ,
+    resumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_host_id FOREIGN KEY (host_id) REFERENCES accounts(id)
+);
+
+CREATE UNIQUE INDEX uq_focus_rooms_code ON focus_rooms (code);
+
+CREATE TABLE focus_room_participants (
+    room_id VARCHAR(255) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    PRIMARY KEY (room_id, account_id),
+    CONSTRAINT fk_room_id FOREIGN KEY (room_id) REFERENCES focus_rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- 同時に最後まで終えていない部屋は1つのみ
+CREATE UNIQUE INDEX uq_focus_room_participants_uncompleted ON focus_room_participants (account_id) WHERE completed_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS focus_room_participants;
+DROP TABLE IF EXISTS focus_rooms;
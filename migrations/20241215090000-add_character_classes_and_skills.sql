@@ -0,0 +1,14 @@
+-- +migrate Up
+ALTER TABLE characters ADD COLUMN class VARCHAR(32) NOT NULL DEFAULT '';
+
+CREATE TABLE character_skills (
+    account_id VARCHAR(255) NOT NULL,
+    skill_key VARCHAR(64) NOT NULL,
+    learned_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (account_id, skill_key),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS character_skills;
+ALTER TABLE characters DROP COLUMN IF EXISTS class;
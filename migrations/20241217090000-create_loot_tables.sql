@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE loot_table_versions (
+    table_key VARCHAR(64) NOT NULL,
+    version VARCHAR(64) NOT NULL,
+    definition JSONB NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (table_key, version)
+);
+
+CREATE TABLE loot_pity (
+    account_id VARCHAR(255) NOT NULL,
+    table_key VARCHAR(64) NOT NULL,
+    misses JSONB NOT NULL,
+    updated_at TIMESTAMPTZ,
+    PRIMARY KEY (account_id, table_key),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE loot_drops (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    table_key VARCHAR(64) NOT NULL,
+    table_version VARCHAR(64) NOT NULL,
+    seed BIGINT NOT NULL,
+    pity_before JSONB NOT NULL,
+    item VARCHAR(64) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    rarity VARCHAR(32) NOT NULL,
+    source_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id),
+    CONSTRAINT fk_table_version FOREIGN KEY (table_key, table_version) REFERENCES loot_table_versions(table_key, version)
+);
+
+CREATE INDEX idx_loot_drops_account_id_created_at ON loot_drops (account_id, created_at);
+CREATE INDEX idx_loot_drops_source_id ON loot_drops (account_id, source_id) WHERE source_id IS NOT NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS loot_drops;
+DROP TABLE IF EXISTS loot_pity;
+DROP TABLE IF EXISTS loot_table_versions;
@@ -0,0 +1,22 @@
+-- +migrate Up
+ALTER TABLE characters ADD COLUMN hp INT;
+ALTER TABLE characters ADD COLUMN knocked_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE goal_misses (
+    account_id VARCHAR(255) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    period_start DATE NOT NULL,
+    target_minutes INT NOT NULL,
+    focus_minutes DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (account_id, period, period_start),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX idx_goals_period ON goals (period);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_goals_period;
+DROP TABLE IF EXISTS goal_misses;
+ALTER TABLE characters DROP COLUMN IF EXISTS knocked_out;
+ALTER TABLE characters DROP COLUMN IF EXISTS hp;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE companions (
+    id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    species VARCHAR(64) NOT NULL,
+    nickname VARCHAR(255) NOT NULL,
+    exp INT NOT NULL DEFAULT 0,
+    stage INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    obtained_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (account_id, species),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE UNIQUE INDEX idx_companions_active ON companions (account_id) WHERE active;
+
+CREATE TABLE companion_trainings (
+    time_id VARCHAR(255) PRIMARY KEY,
+    companion_id VARCHAR(255) NOT NULL,
+    minutes DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_companion_id FOREIGN KEY (companion_id) REFERENCES companions(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS companion_trainings;
+DROP TABLE IF EXISTS companions;
@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE season_activities (
+    season_key VARCHAR(64) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    source_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    minutes DOUBLE PRECISION NOT NULL DEFAULT 0,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (season_key, account_id, source_id),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE season_passes (
+    season_key VARCHAR(64) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    cost INT NOT NULL,
+    purchased_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (season_key, account_id),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE season_claims (
+    season_key VARCHAR(64) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    tier INT NOT NULL,
+    track VARCHAR(16) NOT NULL,
+    exp INT NOT NULL,
+    gold INT NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (season_key, account_id, tier, track),
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS season_claims;
+DROP TABLE IF EXISTS season_passes;
+DROP TABLE IF EXISTS season_activities;
@@ -6,6 +6,8 @@ const (
 	ErrBadRequest ErrorCode = iota
 	ErrNotFound
 	ErrUnautorized
+	ErrConflict
+	ErrForbidden
 )
 
 func (c ErrorCode) String() string {
@@ -16,6 +18,10 @@ func (c ErrorCode) String() string {
 		return "NotFound"
 	case ErrUnautorized:
 		return "Unautorized"
+	case ErrConflict:
+		return "Conflict"
+	case ErrForbidden:
+		return "Forbidden"
 	default:
 		return "InternalServerError"
 	}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+type Admin struct {
+	Emails []string
+}
+
+func newAdminConfig() *Admin {
+	var emails []string
+	for _, e := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			emails = append(emails, e)
+		}
+	}
+
+	return &Admin{
+		Emails: emails,
+	}
+}
@@ -1,11 +1,15 @@
 package config
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 type AWS struct {
 	UserPoolID   string
 	ClientID     string
 	ClientSecret string
+	Timeout      time.Duration
 }
 
 func newAWSConfig() *AWS {
@@ -13,5 +17,6 @@ func newAWSConfig() *AWS {
 		UserPoolID:   os.Getenv("COGNITO_USER_POOL_ID"),
 		ClientID:     os.Getenv("COGNITO_CLIENT_ID"),
 		ClientSecret: os.Getenv("COGNITO_CLIENT_SECRET"),
+		Timeout:      durationEnv("COGNITO_TIMEOUT", 10*time.Second),
 	}
 }
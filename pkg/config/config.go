@@ -2,6 +2,8 @@ package config
 
 import (
 	"log"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,13 +15,39 @@ func init() {
 }
 
 type Config struct {
-	DB  *DBConfig
-	AWS *AWS
+	DB          *DBConfig
+	AWS         *AWS
+	Admin       *Admin
+	Worker      *Worker
+	Idempotency *Idempotency
+	Loot        *Loot
+	Season      *Season
+	Proxy       *Proxy
 }
 
 func NewConfig() *Config {
 	return &Config{
-		DB:  newDBConfig(),
-		AWS: newAWSConfig(),
+		DB:          newDBConfig(),
+		AWS:         newAWSConfig(),
+		Admin:       newAdminConfig(),
+		Worker:      newWorkerConfig(),
+		Idempotency: newIdempotencyConfig(),
+		Loot:        newLootConfig(),
+		Season:      newSeasonConfig(),
+		Proxy:       newProxyConfig(),
 	}
 }
+
+func durationEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("invalid %s: %q, fallback to %s", key, v, def)
+		return def
+	}
+	return d
+}
@@ -1,6 +1,9 @@
 package config
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 type DBConfig struct {
 	Port     string
@@ -9,6 +12,7 @@ type DBConfig struct {
 	User     string
 	Password string
 	SSLMode  string
+	Timeout  time.Duration
 }
 
 func newDBConfig() *DBConfig {
@@ -19,5 +23,6 @@ func newDBConfig() *DBConfig {
 		User:     os.Getenv("DB_USER"),
 		Password: os.Getenv("DB_PASSWORD"),
 		SSLMode:  os.Getenv("SSL_MODE"),
+		Timeout:  durationEnv("DB_TIMEOUT", 5*time.Second),
 	}
 }
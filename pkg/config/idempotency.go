@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type Idempotency struct {
+	// TTL は同じIdempotency-Keyのリクエストに保存したレスポンスを返す期間
+	TTL           time.Duration
+	PurgeInterval time.Duration
+}
+
+func newIdempotencyConfig() *Idempotency {
+	return &Idempotency{
+		TTL:           durationEnv("IDEMPOTENCY_TTL", 24*time.Hour),
+		PurgeInterval: durationEnv("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+	}
+}
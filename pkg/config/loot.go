@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+type Loot struct {
+	// TablesDir はドロップテーブルのデータファイルを置くディレクトリ
+	TablesDir string
+}
+
+func newLootConfig() *Loot {
+	dir := os.Getenv("LOOT_TABLES_DIR")
+	if dir == "" {
+		dir = "data/loot"
+	}
+
+	return &Loot{
+		TablesDir: dir,
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+type Proxy struct {
+	// TrustedHops はX-Forwarded-Forに接続元を追記する信頼できるプロキシの段数。0の場合はヘッダーを使わない
+	TrustedHops int
+}
+
+func newProxyConfig() *Proxy {
+	hops := 0
+	if v := os.Getenv("TRUSTED_PROXY_HOPS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Printf("invalid TRUSTED_PROXY_HOPS: %q, fallback to %d", v, hops)
+		} else {
+			hops = n
+		}
+	}
+
+	return &Proxy{
+		TrustedHops: hops,
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+type Season struct {
+	// Dir はシーズンのデータファイルを置くディレクトリ
+	Dir string
+}
+
+func newSeasonConfig() *Season {
+	dir := os.Getenv("SEASONS_DIR")
+	if dir == "" {
+		dir = "data/seasons"
+	}
+
+	return &Season{
+		Dir: dir,
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+type Worker struct {
+	OutboxInterval  time.Duration
+	WebhookInterval time.Duration
+	WebhookTimeout  time.Duration
+	// LeaderboardInterval はランキングのスナップショットを更新する間隔
+	LeaderboardInterval time.Duration
+	// GoalPenaltyInterval は日次目標の未達成を判定する間隔
+	GoalPenaltyInterval time.Duration
+}
+
+func newWorkerConfig() *Worker {
+	return &Worker{
+		OutboxInterval:      durationEnv("OUTBOX_INTERVAL", 5*time.Second),
+		WebhookInterval:     durationEnv("WEBHOOK_INTERVAL", 5*time.Second),
+		WebhookTimeout:      durationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+		LeaderboardInterval: durationEnv("LEADERBOARD_INTERVAL", 5*time.Minute),
+		GoalPenaltyInterval: durationEnv("GOAL_PENALTY_INTERVAL", 15*time.Minute),
+	}
+}
@@ -6,4 +6,5 @@ const (
 	Email     ContextKey = "email"
 	UserID    ContextKey = "userID"
 	RequestID ContextKey = "requestID"
+	ClientIP  ContextKey = "clientIP"
 )
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type AuditEventResponse struct {
+	ID         string    `json:"id"`
+	ActorID    string    `json:"actorId"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"targetType"`
+	TargetID   string    `json:"targetId"`
+	RequestID  string    `json:"requestId"`
+	IP         string    `json:"ip"`
+	Diff       any       `json:"diff"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type BreakRequest struct {
+	Kind    string  `json:"kind"`
+	Minutes float64 `json:"minutes"`
+}
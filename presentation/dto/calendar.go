@@ -0,0 +1,6 @@
+package dto
+
+type CalendarFeedResponse struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
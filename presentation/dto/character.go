@@ -0,0 +1,43 @@
+package dto
+
+type CharacterClassRequest struct {
+	Class string `json:"class"`
+}
+
+type CharacterResponse struct {
+	Level        int           `json:"level"`
+	Exp          int           `json:"exp"`
+	NextLevelExp int           `json:"nextLevelExp"`
+	Gold         int           `json:"gold"`
+	HP           int           `json:"hp"`
+	MaxHP        int           `json:"maxHp"`
+	KnockedOut   bool          `json:"knockedOut"`
+	Class        string        `json:"class"`
+	Stats        StatsResponse `json:"stats"`
+	SkillPoints  int           `json:"skillPoints"`
+	Skills       []string      `json:"skills"`
+}
+
+type StatsResponse struct {
+	Strength  int `json:"strength"`
+	Intellect int `json:"intellect"`
+	Wisdom    int `json:"wisdom"`
+}
+
+type CharacterClassResponse struct {
+	Class       string        `json:"class"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Growth      StatsResponse `json:"growth"`
+}
+
+type SkillResponse struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Cost        int      `json:"cost"`
+	Requires    []string `json:"requires"`
+	Class       string   `json:"class"`
+	Learned     bool     `json:"learned"`
+	Learnable   bool     `json:"learnable"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type RenameCompanionRequest struct {
+	Nickname string `json:"nickname"`
+}
+
+type CompanionSpeciesResponse struct {
+	Key              string                   `json:"key"`
+	Name             string                   `json:"name"`
+	Egg              string                   `json:"egg,omitempty"`
+	MilestoneMinutes int                      `json:"milestoneMinutes,omitempty"`
+	Stages           []CompanionStageResponse `json:"stages"`
+}
+
+type CompanionStageResponse struct {
+	Name        string `json:"name"`
+	MinExp      int    `json:"minExp"`
+	Description string `json:"description"`
+}
+
+type CompanionResponse struct {
+	ID           string    `json:"id"`
+	Species      string    `json:"species"`
+	SpeciesName  string    `json:"speciesName"`
+	Nickname     string    `json:"nickname"`
+	Exp          int       `json:"exp"`
+	Stage        int       `json:"stage"`
+	StageName    string    `json:"stageName"`
+	Bonus        string    `json:"bonus"`
+	NextStageExp *int      `json:"nextStageExp"`
+	Active       bool      `json:"active"`
+	ObtainedAt   time.Time `json:"obtainedAt"`
+}
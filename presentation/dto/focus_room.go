@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type CreateFocusRoomRequest struct {
+	PlannedSeconds int `json:"plannedSeconds"`
+}
+
+type JoinFocusRoomRequest struct {
+	Code string `json:"code"`
+}
+
+type FocusRoomResponse struct {
+	ID               string                         `json:"id"`
+	Code             string                         `json:"code"`
+	HostID           string                         `json:"hostId"`
+	Status           string                         `json:"status"`
+	PlannedSeconds   int                            `json:"plannedSeconds"`
+	ElapsedSeconds   int                            `json:"elapsedSeconds"`
+	RemainingSeconds int                            `json:"remainingSeconds"`
+	StartedAt        *time.Time                     `json:"startedAt"`
+	Participants     []FocusRoomParticipantResponse `json:"participants"`
+	ServerTime       time.Time                      `json:"serverTime"`
+}
+
+type FocusRoomParticipantResponse struct {
+	AccountID   string     `json:"accountId"`
+	Name        string     `json:"name"`
+	Image       string     `json:"image"`
+	JoinedAt    time.Time  `json:"joinedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
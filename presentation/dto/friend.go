@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+type FriendRequestRequest struct {
+	Email string `json:"email"`
+}
+
+type FriendRequestsResponse struct {
+	Incoming []IncomingFriendRequestResponse `json:"incoming"`
+	Outgoing []OutgoingFriendRequestResponse `json:"outgoing"`
+}
+
+type IncomingFriendRequestResponse struct {
+	ID          string    `json:"id"`
+	RequesterID string    `json:"requesterId"`
+	Name        string    `json:"name"`
+	Image       string    `json:"image"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type OutgoingFriendRequestResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type FriendResponse struct {
+	AccountID          string    `json:"accountId"`
+	Name               string    `json:"name"`
+	Image              string    `json:"image"`
+	Level              *int      `json:"level"`
+	Streak             *int      `json:"streak"`
+	WeeklyFocusMinutes *float64  `json:"weeklyFocusMinutes"`
+	Since              time.Time `json:"since"`
+}
+
+type BlockRequest struct {
+	AccountID string `json:"accountId"`
+}
+
+type BlockedAccountResponse struct {
+	AccountID string    `json:"accountId"`
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
+type PrivacyRequest struct {
+	ShareLevel       bool `json:"shareLevel"`
+	ShareStreak      bool `json:"shareStreak"`
+	ShareWeeklyFocus bool `json:"shareWeeklyFocus"`
+}
+
+type PrivacyResponse struct {
+	ShareLevel       bool `json:"shareLevel"`
+	ShareStreak      bool `json:"shareStreak"`
+	ShareWeeklyFocus bool `json:"shareWeeklyFocus"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type GoalRequest struct {
+	TargetMinutes int `json:"targetMinutes"`
+}
+
+type GoalStatusResponse struct {
+	Period        string    `json:"period"`
+	TargetMinutes int       `json:"targetMinutes"`
+	FocusMinutes  float64   `json:"focusMinutes"`
+	Achieved      bool      `json:"achieved"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+}
+
+type GoalAchievementResponse struct {
+	Period        string    `json:"period"`
+	PeriodStart   string    `json:"periodStart"`
+	TargetMinutes int       `json:"targetMinutes"`
+	FocusMinutes  float64   `json:"focusMinutes"`
+	AchievedAt    time.Time `json:"achievedAt"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+type GuildRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type GuildRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type GuildSummaryResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Members     int       `json:"members"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type GuildResponse struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Role        string                `json:"role"`
+	Members     []GuildMemberResponse `json:"members"`
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+type GuildMemberResponse struct {
+	AccountID string    `json:"accountId"`
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+type RaidBossResponse struct {
+	Key            string `json:"key"`
+	Name           string `json:"name"`
+	HP             int    `json:"hp"`
+	TimeLimitHours int    `json:"timeLimitHours"`
+	RewardExp      int    `json:"rewardExp"`
+	RewardGold     int    `json:"rewardGold"`
+	Season         string `json:"season,omitempty"`
+}
+
+type RaidRequest struct {
+	Boss string `json:"boss"`
+}
+
+type RaidResponse struct {
+	ID           string                    `json:"id"`
+	Boss         string                    `json:"boss"`
+	BossName     string                    `json:"bossName"`
+	MaxHP        int                       `json:"maxHp"`
+	HP           float64                   `json:"hp"`
+	Status       string                    `json:"status"`
+	RewardExp    int                       `json:"rewardExp"`
+	RewardGold   int                       `json:"rewardGold"`
+	StartedAt    time.Time                 `json:"startedAt"`
+	EndsAt       time.Time                 `json:"endsAt"`
+	FinishedAt   *time.Time                `json:"finishedAt"`
+	Contributors []RaidContributorResponse `json:"contributors"`
+}
+
+type RaidContributorResponse struct {
+	AccountID string  `json:"accountId"`
+	Name      string  `json:"name"`
+	Image     string  `json:"image"`
+	Minutes   float64 `json:"minutes"`
+	Share     float64 `json:"share"`
+}
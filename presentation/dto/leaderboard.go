@@ -0,0 +1,18 @@
+package dto
+
+type LeaderboardResponse struct {
+	Metric      string                     `json:"metric"`
+	Period      string                     `json:"period"`
+	Scope       string                     `json:"scope"`
+	PeriodStart string                     `json:"periodStart,omitempty"`
+	Entries     []LeaderboardEntryResponse `json:"entries"`
+	Me          *LeaderboardEntryResponse  `json:"me"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank      int     `json:"rank"`
+	AccountID string  `json:"accountId"`
+	Name      string  `json:"name"`
+	Image     string  `json:"image"`
+	Score     float64 `json:"score"`
+}
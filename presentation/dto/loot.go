@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+type LootTableResponse struct {
+	Key     string                 `json:"key"`
+	Name    string                 `json:"name"`
+	Source  string                 `json:"source"`
+	Version string                 `json:"version"`
+	Entries []LootEntryResponse    `json:"entries"`
+	Pity    []LootPityRuleResponse `json:"pity"`
+}
+
+type LootEntryResponse struct {
+	Item   string  `json:"item"`
+	Name   string  `json:"name"`
+	Rarity string  `json:"rarity"`
+	Weight int     `json:"weight"`
+	Rate   float64 `json:"rate"`
+}
+
+type LootPityRuleResponse struct {
+	Rarity string `json:"rarity"`
+	After  int    `json:"after"`
+}
+
+type LootDropResponse struct {
+	ID           string         `json:"id"`
+	TableKey     string         `json:"tableKey"`
+	TableVersion string         `json:"tableVersion"`
+	Seed         string         `json:"seed"`
+	PityBefore   map[string]int `json:"pityBefore"`
+	Item         string         `json:"item"`
+	ItemName     string         `json:"itemName"`
+	Rarity       string         `json:"rarity"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+type InventoryItemResponse struct {
+	Item   string `json:"item"`
+	Name   string `json:"name"`
+	Rarity string `json:"rarity"`
+	Count  int    `json:"count"`
+}
+
+type LootVerificationResponse struct {
+	Drop         LootDropResponse `json:"drop"`
+	ReplayedItem string           `json:"replayedItem"`
+	Match        bool             `json:"match"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type ProjectRequest struct {
+	Name string `json:"name"`
+}
+
+type ProjectResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type TaskRequest struct {
+	ProjectID          string `json:"projectId"`
+	Title              string `json:"title"`
+	EstimatedPomodoros int    `json:"estimatedPomodoros"`
+	Completed          bool   `json:"completed"`
+}
+
+type TaskResponse struct {
+	ID                 string    `json:"id"`
+	ProjectID          *string   `json:"projectId"`
+	Title              string    `json:"title"`
+	EstimatedPomodoros int       `json:"estimatedPomodoros"`
+	Completed          bool      `json:"completed"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
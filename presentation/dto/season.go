@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+type SeasonResponse struct {
+	Key         string                `json:"key"`
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	StartsAt    time.Time             `json:"startsAt"`
+	EndsAt      time.Time             `json:"endsAt"`
+	ClaimEndsAt time.Time             `json:"claimEndsAt"`
+	PremiumCost int                   `json:"premiumCost"`
+	Tiers       []SeasonTierResponse  `json:"tiers"`
+	Quests      []SeasonQuestResponse `json:"quests"`
+	Bosses      []RaidBossResponse    `json:"bosses"`
+}
+
+type SeasonTierResponse struct {
+	Tier    int                  `json:"tier"`
+	Exp     int                  `json:"exp"`
+	Free    SeasonRewardResponse `json:"free"`
+	Premium SeasonRewardResponse `json:"premium"`
+}
+
+type SeasonRewardResponse struct {
+	Exp  int `json:"exp"`
+	Gold int `json:"gold"`
+}
+
+type SeasonQuestResponse struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Target int    `json:"target"`
+	Exp    int    `json:"exp"`
+}
+
+type SeasonProgressResponse struct {
+	Season      SeasonResponse                `json:"season"`
+	Exp         int                           `json:"exp"`
+	Tier        int                           `json:"tier"`
+	NextTierExp *int                          `json:"nextTierExp"`
+	Premium     bool                          `json:"premium"`
+	Quests      []SeasonQuestProgressResponse `json:"quests"`
+	Claimed     []SeasonClaimResponse         `json:"claimed"`
+	Claimable   []SeasonClaimResponse         `json:"claimable"`
+}
+
+type SeasonQuestProgressResponse struct {
+	Key       string `json:"key"`
+	Progress  int    `json:"progress"`
+	Completed bool   `json:"completed"`
+}
+
+type SeasonClaimResponse struct {
+	Tier      int        `json:"tier"`
+	Track     string     `json:"track"`
+	Exp       int        `json:"exp"`
+	Gold      int        `json:"gold"`
+	ClaimedAt *time.Time `json:"claimedAt"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type SettingsRequest struct {
+	FocusMinutes      int    `json:"focusMinutes"`
+	ShortBreakMinutes int    `json:"shortBreakMinutes"`
+	LongBreakMinutes  int    `json:"longBreakMinutes"`
+	LongBreakInterval int    `json:"longBreakInterval"`
+	AutoStartBreaks   bool   `json:"autoStartBreaks"`
+	AutoStartFocus    bool   `json:"autoStartFocus"`
+	NotifySound       bool   `json:"notifySound"`
+	NotifyDesktop     bool   `json:"notifyDesktop"`
+	Timezone          string `json:"timezone"`
+}
+
+type SettingsResponse struct {
+	FocusMinutes      int    `json:"focusMinutes"`
+	ShortBreakMinutes int    `json:"shortBreakMinutes"`
+	LongBreakMinutes  int    `json:"longBreakMinutes"`
+	LongBreakInterval int    `json:"longBreakInterval"`
+	AutoStartBreaks   bool   `json:"autoStartBreaks"`
+	AutoStartFocus    bool   `json:"autoStartFocus"`
+	NotifySound       bool   `json:"notifySound"`
+	NotifyDesktop     bool   `json:"notifyDesktop"`
+	Timezone          string `json:"timezone"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+type DailyStatResponse struct {
+	Date         string  `json:"date"`
+	FocusMinutes float64 `json:"focusMinutes"`
+	BreakMinutes float64 `json:"breakMinutes"`
+	FocusRatio   float64 `json:"focusRatio"`
+}
+
+type CycleResponse struct {
+	FocusCount        int    `json:"focusCount"`
+	CompletedCycles   int    `json:"completedCycles"`
+	LongBreakInterval int    `json:"longBreakInterval"`
+	NextKind          string `json:"nextKind"`
+}
+
+type TaskTotalResponse struct {
+	TaskID             string  `json:"taskId"`
+	ProjectID          *string `json:"projectId"`
+	Title              string  `json:"title"`
+	EstimatedPomodoros int     `json:"estimatedPomodoros"`
+	ActualPomodoros    int     `json:"actualPomodoros"`
+	FocusMinutes       float64 `json:"focusMinutes"`
+}
+
+type ProjectTotalResponse struct {
+	ProjectID          string  `json:"projectId"`
+	Name               string  `json:"name"`
+	EstimatedPomodoros int     `json:"estimatedPomodoros"`
+	ActualPomodoros    int     `json:"actualPomodoros"`
+	FocusMinutes       float64 `json:"focusMinutes"`
+}
+
+type HeatmapDayResponse struct {
+	Date         string  `json:"date"`
+	FocusMinutes float64 `json:"focusMinutes"`
+	Level        int     `json:"level"`
+}
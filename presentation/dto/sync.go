@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type SyncRequest struct {
+	Token    string               `json:"token"`
+	Sessions []SyncSessionRequest `json:"sessions"`
+}
+
+type SyncSessionRequest struct {
+	ID      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Minutes float64   `json:"minutes"`
+	TaskID  string    `json:"taskId"`
+	Note    string    `json:"note"`
+	Tags    []string  `json:"tags"`
+	EndedAt time.Time `json:"endedAt"`
+}
+
+type SyncResponse struct {
+	Token          string               `json:"token"`
+	HasMore        bool                 `json:"hasMore"`
+	Results        []SyncResultResponse `json:"results"`
+	Times          []TimeResponse       `json:"times"`
+	DeletedTimeIDs []string             `json:"deletedTimeIds"`
+	Breaks         []BreakResponse      `json:"breaks"`
+}
+
+type SyncResultResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type BreakResponse struct {
+	ID            string    `json:"id"`
+	Kind          string    `json:"kind"`
+	Minutes       float64   `json:"minutes"`
+	ExecutionDate time.Time `json:"executionDate"`
+}
@@ -1,5 +1,25 @@
 package dto
 
+import "time"
+
 type TimeRequest struct {
-	FocusTime float64 `json:"focusTime"`
+	FocusTime float64  `json:"focusTime"`
+	TaskID    string   `json:"taskId"`
+	Note      string   `json:"note"`
+	Tags      []string `json:"tags"`
+}
+
+type TimeResponse struct {
+	ID            string    `json:"id"`
+	FocusTime     float64   `json:"focusTime"`
+	TaskID        *string   `json:"taskId"`
+	Note          string    `json:"note"`
+	Tags          []string  `json:"tags"`
+	ExecutionDate time.Time `json:"executionDate"`
+}
+
+type TagTotalResponse struct {
+	Name            string  `json:"name"`
+	ActualPomodoros int     `json:"actualPomodoros"`
+	FocusMinutes    float64 `json:"focusMinutes"`
 }
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type StartTimerRequest struct {
+	Kind           string `json:"kind"`
+	PlannedSeconds int    `json:"plannedSeconds"`
+}
+
+type TimerResponse struct {
+	ID               string     `json:"id"`
+	Kind             string     `json:"kind"`
+	Status           string     `json:"status"`
+	PlannedSeconds   int        `json:"plannedSeconds"`
+	ElapsedSeconds   int        `json:"elapsedSeconds"`
+	RemainingSeconds int        `json:"remainingSeconds"`
+	StartedAt        *time.Time `json:"startedAt"`
+	ServerTime       time.Time  `json:"serverTime"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID           string     `json:"id"`
+	Event        string     `json:"event"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	ResponseCode int        `json:"responseCode"`
+	LastError    string     `json:"lastError"`
+	DeliveredAt  *time.Time `json:"deliveredAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type AuditHandler interface {
+	Search(w http.ResponseWriter, r *http.Request)
+}
+
+type auditHandler struct {
+	au usecase.AuditUsecase
+}
+
+func (a *auditHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	in, err := parseAuditSearch(r.URL.Query())
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	events, err := a.au.Search(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.AuditEventResponse, 0, len(events))
+	for _, ev := range events {
+		res = append(res, dto.AuditEventResponse{
+			ID:         ev.ID,
+			ActorID:    ev.ActorID,
+			Action:     ev.Action,
+			TargetType: ev.TargetType,
+			TargetID:   ev.TargetID,
+			RequestID:  ev.RequestID,
+			IP:         ev.IP,
+			Diff:       ev.Diff,
+			CreatedAt:  ev.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func parseAuditSearch(q url.Values) (input.AuditSearch, error) {
+	in := input.AuditSearch{
+		ActorID:    q.Get("actorId"),
+		Action:     q.Get("action"),
+		TargetType: q.Get("targetType"),
+		TargetID:   q.Get("targetId"),
+		RequestID:  q.Get("requestId"),
+	}
+
+	var err error
+	if in.From, err = parseTimeQuery(q, "from"); err != nil {
+		return input.AuditSearch{}, err
+	}
+	if in.To, err = parseTimeQuery(q, "to"); err != nil {
+		return input.AuditSearch{}, err
+	}
+	if in.Limit, err = parseIntQuery(q, "limit"); err != nil {
+		return input.AuditSearch{}, err
+	}
+	if in.Offset, err = parseIntQuery(q, "offset"); err != nil {
+		return input.AuditSearch{}, err
+	}
+
+	return in, nil
+}
+
+func parseTimeQuery(q url.Values, key string) (*time.Time, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", key)
+	}
+	return &t, nil
+}
+
+func parseIntQuery(q url.Values, key string) (int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", key)
+	}
+	return n, nil
+}
+
+func NewAuditHandler(au usecase.AuditUsecase) AuditHandler {
+	return &auditHandler{au}
+}
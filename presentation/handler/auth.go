@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
 	"pomodoro-rpg-api/pkg/formatter"
 	"pomodoro-rpg-api/pkg/logger"
 	"pomodoro-rpg-api/presentation/dto"
@@ -104,7 +105,8 @@ func (a *authHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.au.ChangePassword(ctx, cookie.Value, req.PreviousPass, req.ProposedPass)
+	email := ctx.Value(contextkey.Email).(string)
+	err = a.au.ChangePassword(ctx, email, cookie.Value, req.PreviousPass, req.ProposedPass)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -185,7 +187,8 @@ func (a *authHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.au.SignOut(ctx, cookie.Value); err != nil {
+	email := ctx.Value(contextkey.Email).(string)
+	if err := a.au.SignOut(ctx, email, cookie.Value); err != nil {
 		response.Error(w, err)
 		return
 	}
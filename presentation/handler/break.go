@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+
+	"github.com/cockroachdb/errors"
+)
+
+type BreakHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+}
+
+type breakHandler struct {
+	bu usecase.BreakUsecase
+}
+
+func (b *breakHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.BreakRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := b.bu.Create(ctx, email, req.Kind, req.Minutes); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, nil)
+}
+
+func NewBreakHandler(bu usecase.BreakUsecase) BreakHandler {
+	return &breakHandler{bu}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/output"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	icsTimeLayout = "20060102T150405Z"
+	// icsLineOctets はRFC 5545で1行に収めるべきオクテット数
+	icsLineOctets = 75
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+type CalendarHandler interface {
+	Issue(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+	Feed(w http.ResponseWriter, r *http.Request)
+}
+
+type calendarHandler struct {
+	cu usecase.CalendarUsecase
+}
+
+func (c *calendarHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	token, err := c.cu.Issue(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, dto.CalendarFeedResponse{
+		Token: token,
+		Path:  fmt.Sprintf("/calendar/%s.ics", token),
+	})
+}
+
+func (c *calendarHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := c.cu.Revoke(ctx, email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (c *calendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := strings.TrimSuffix(chi.URLParam(r, "file"), ".ics")
+
+	events, err := c.cu.Feed(ctx, token)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	response.ICS(w, http.StatusOK, encodeCalendar(events, time.Now()))
+}
+
+// encodeCalendar はRFC 5545形式のカレンダーを生成する
+func encodeCalendar(events []output.CalendarEvent, now time.Time) []byte {
+	var b bytes.Buffer
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//pomodoro-rpg//focus sessions//JA")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:ポモドーロ")
+
+	stamp := now.UTC().Format(icsTimeLayout)
+	for _, ev := range events {
+		summary := "ポモドーロ"
+		if ev.TaskTitle != "" {
+			summary += ": " + ev.TaskTitle
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+ev.ID+"@pomodoro-rpg")
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART:"+ev.Start.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "DTEND:"+ev.End.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "SUMMARY:"+icsEscaper.Replace(summary))
+		if ev.Note != "" {
+			writeICSLine(&b, "DESCRIPTION:"+icsEscaper.Replace(ev.Note))
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+// writeICSLine は長い行を、マルチバイト文字を分断しないように折り返して書き込む
+func writeICSLine(b *bytes.Buffer, line string) {
+	limit := icsLineOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		// 折り返した行は先頭の空白の分だけ短くする
+		limit = icsLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func NewCalendarHandler(cu usecase.CalendarUsecase) CalendarHandler {
+	return &calendarHandler{cu}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type CharacterHandler interface {
+	Classes(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	ChooseClass(w http.ResponseWriter, r *http.Request)
+	Skills(w http.ResponseWriter, r *http.Request)
+	LearnSkill(w http.ResponseWriter, r *http.Request)
+}
+
+type characterHandler struct {
+	cu usecase.CharacterUsecase
+}
+
+func (h *characterHandler) Classes(w http.ResponseWriter, r *http.Request) {
+	classes := h.cu.Classes(r.Context())
+
+	res := make([]dto.CharacterClassResponse, 0, len(classes))
+	for _, c := range classes {
+		res = append(res, dto.CharacterClassResponse{
+			Class:       c.Class,
+			Name:        c.Name,
+			Description: c.Description,
+			Growth:      toStatsResponse(c.Growth),
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *characterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	char, err := h.cu.Get(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCharacterResponse(char))
+}
+
+func (h *characterHandler) ChooseClass(w http.ResponseWriter, r *http.Request) {
+	var req dto.CharacterClassRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	char, err := h.cu.ChooseClass(ctx, email, req.Class)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCharacterResponse(char))
+}
+
+func (h *characterHandler) Skills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	skills, err := h.cu.Skills(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.SkillResponse, 0, len(skills))
+	for _, s := range skills {
+		res = append(res, dto.SkillResponse{
+			Key:         s.Key,
+			Name:        s.Name,
+			Description: s.Description,
+			Cost:        s.Cost,
+			Requires:    s.Requires,
+			Class:       s.Class,
+			Learned:     s.Learned,
+			Learnable:   s.Learnable,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *characterHandler) LearnSkill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	char, err := h.cu.LearnSkill(ctx, email, chi.URLParam(r, "key"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCharacterResponse(char))
+}
+
+func toCharacterResponse(c output.Character) dto.CharacterResponse {
+	return dto.CharacterResponse{
+		Level:        c.Level,
+		Exp:          c.Exp,
+		NextLevelExp: c.NextLevelExp,
+		Gold:         c.Gold,
+		HP:           c.HP,
+		MaxHP:        c.MaxHP,
+		KnockedOut:   c.KnockedOut,
+		Class:        c.Class,
+		Stats:        toStatsResponse(c.Stats),
+		SkillPoints:  c.SkillPoints,
+		Skills:       c.Skills,
+	}
+}
+
+func toStatsResponse(s output.Stats) dto.StatsResponse {
+	return dto.StatsResponse{
+		Strength:  s.Strength,
+		Intellect: s.Intellect,
+		Wisdom:    s.Wisdom,
+	}
+}
+
+func NewCharacterHandler(cu usecase.CharacterUsecase) CharacterHandler {
+	return &characterHandler{cu}
+}
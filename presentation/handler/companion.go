@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type CompanionHandler interface {
+	Species(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Activate(w http.ResponseWriter, r *http.Request)
+	Rename(w http.ResponseWriter, r *http.Request)
+}
+
+type companionHandler struct {
+	cu usecase.CompanionUsecase
+}
+
+func (h *companionHandler) Species(w http.ResponseWriter, r *http.Request) {
+	species := h.cu.Species(r.Context())
+
+	res := make([]dto.CompanionSpeciesResponse, 0, len(species))
+	for _, s := range species {
+		stages := make([]dto.CompanionStageResponse, 0, len(s.Stages))
+		for _, st := range s.Stages {
+			stages = append(stages, dto.CompanionStageResponse{
+				Name:        st.Name,
+				MinExp:      st.MinExp,
+				Description: st.Description,
+			})
+		}
+
+		res = append(res, dto.CompanionSpeciesResponse{
+			Key:              s.Key,
+			Name:             s.Name,
+			Egg:              s.Egg,
+			MilestoneMinutes: s.MilestoneMinutes,
+			Stages:           stages,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *companionHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	companions, err := h.cu.List(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.CompanionResponse, 0, len(companions))
+	for _, c := range companions {
+		res = append(res, toCompanionResponse(c))
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *companionHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	companion, err := h.cu.Activate(ctx, email, chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCompanionResponse(companion))
+}
+
+func (h *companionHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	var req dto.RenameCompanionRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	companion, err := h.cu.Rename(ctx, email, chi.URLParam(r, "id"), input.RenameCompanion{Nickname: req.Nickname})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCompanionResponse(companion))
+}
+
+func toCompanionResponse(c output.Companion) dto.CompanionResponse {
+	return dto.CompanionResponse{
+		ID:           c.ID,
+		Species:      c.Species,
+		SpeciesName:  c.SpeciesName,
+		Nickname:     c.Nickname,
+		Exp:          c.Exp,
+		Stage:        c.Stage,
+		StageName:    c.StageName,
+		Bonus:        c.Bonus,
+		NextStageExp: c.NextStageExp,
+		Active:       c.Active,
+		ObtainedAt:   c.ObtainedAt,
+	}
+}
+
+func NewCompanionHandler(cu usecase.CompanionUsecase) CompanionHandler {
+	return &companionHandler{cu}
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type FocusRoomHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Join(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	Start(w http.ResponseWriter, r *http.Request)
+	Pause(w http.ResponseWriter, r *http.Request)
+	Resume(w http.ResponseWriter, r *http.Request)
+	Complete(w http.ResponseWriter, r *http.Request)
+	Leave(w http.ResponseWriter, r *http.Request)
+	Stream(w http.ResponseWriter, r *http.Request)
+}
+
+type focusRoomHandler struct {
+	fu usecase.FocusRoomUsecase
+}
+
+func (f *focusRoomHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateFocusRoomRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	room, err := f.fu.Create(ctx, email, req.PlannedSeconds)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toFocusRoomResponse(room))
+}
+
+func (f *focusRoomHandler) Join(w http.ResponseWriter, r *http.Request) {
+	var req dto.JoinFocusRoomRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	room, err := f.fu.Join(ctx, email, req.Code)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toFocusRoomResponse(room))
+}
+
+func (f *focusRoomHandler) Get(w http.ResponseWriter, r *http.Request) {
+	f.respond(w, r, f.fu.Get)
+}
+
+func (f *focusRoomHandler) Start(w http.ResponseWriter, r *http.Request) {
+	f.respond(w, r, f.fu.Start)
+}
+
+func (f *focusRoomHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	f.respond(w, r, f.fu.Pause)
+}
+
+func (f *focusRoomHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	f.respond(w, r, f.fu.Resume)
+}
+
+func (f *focusRoomHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	f.respond(w, r, f.fu.Complete)
+}
+
+func (f *focusRoomHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Leave(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+// Stream はServer-Sent Eventsで部屋の状態変更を配信する
+func (f *focusRoomHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := errors.New("streaming unsupported")
+		logger.Event(ctx, logger.ERROR, err.Error(), err)
+		response.Error(w, err)
+		return
+	}
+
+	rooms, err := f.fu.Subscribe(ctx, email, chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case room, ok := <-rooms:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(toFocusRoomResponse(room))
+			if err != nil {
+				logger.Event(ctx, logger.ERROR, "marshal focus room failed", errors.WithStack(err))
+				return
+			}
+			fmt.Fprintf(w, "event: room\ndata: %s\n\n", data)
+		}
+		flusher.Flush()
+	}
+}
+
+func (f *focusRoomHandler) respond(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, email string, id string) (output.FocusRoom, error)) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	room, err := fn(ctx, email, chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toFocusRoomResponse(room))
+}
+
+func toFocusRoomResponse(room output.FocusRoom) dto.FocusRoomResponse {
+	participants := make([]dto.FocusRoomParticipantResponse, 0, len(room.Participants))
+	for _, p := range room.Participants {
+		participants = append(participants, dto.FocusRoomParticipantResponse{
+			AccountID:   p.AccountID,
+			Name:        p.Name,
+			Image:       p.Image,
+			JoinedAt:    p.JoinedAt,
+			CompletedAt: p.CompletedAt,
+		})
+	}
+
+	return dto.FocusRoomResponse{
+		ID:               room.ID,
+		Code:             room.Code,
+		HostID:           room.HostID,
+		Status:           room.Status,
+		PlannedSeconds:   room.PlannedSeconds,
+		ElapsedSeconds:   room.ElapsedSeconds,
+		RemainingSeconds: room.RemainingSeconds,
+		StartedAt:        room.StartedAt,
+		Participants:     participants,
+		ServerTime:       room.ServerTime,
+	}
+}
+
+func NewFocusRoomHandler(fu usecase.FocusRoomUsecase) FocusRoomHandler {
+	return &focusRoomHandler{fu}
+}
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type FriendHandler interface {
+	SendRequest(w http.ResponseWriter, r *http.Request)
+	Requests(w http.ResponseWriter, r *http.Request)
+	Accept(w http.ResponseWriter, r *http.Request)
+	Decline(w http.ResponseWriter, r *http.Request)
+	Cancel(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Remove(w http.ResponseWriter, r *http.Request)
+	Block(w http.ResponseWriter, r *http.Request)
+	Unblock(w http.ResponseWriter, r *http.Request)
+	Blocks(w http.ResponseWriter, r *http.Request)
+	GetPrivacy(w http.ResponseWriter, r *http.Request)
+	UpdatePrivacy(w http.ResponseWriter, r *http.Request)
+}
+
+type friendHandler struct {
+	fu usecase.FriendUsecase
+}
+
+func (f *friendHandler) SendRequest(w http.ResponseWriter, r *http.Request) {
+	var req dto.FriendRequestRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := f.fu.SendRequest(ctx, email, req.Email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	// 宛先のアカウントが存在するかどうかに関わらず同じレスポンスを返す
+	response.JSON(w, http.StatusAccepted, nil)
+}
+
+func (f *friendHandler) Requests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	requests, err := f.fu.Requests(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := dto.FriendRequestsResponse{
+		Incoming: make([]dto.IncomingFriendRequestResponse, 0, len(requests.Incoming)),
+		Outgoing: make([]dto.OutgoingFriendRequestResponse, 0, len(requests.Outgoing)),
+	}
+	for _, req := range requests.Incoming {
+		res.Incoming = append(res.Incoming, dto.IncomingFriendRequestResponse{
+			ID:          req.ID,
+			RequesterID: req.RequesterID,
+			Name:        req.Name,
+			Image:       req.Image,
+			CreatedAt:   req.CreatedAt,
+		})
+	}
+	for _, req := range requests.Outgoing {
+		res.Outgoing = append(res.Outgoing, dto.OutgoingFriendRequestResponse{
+			ID:        req.ID,
+			Email:     req.Email,
+			CreatedAt: req.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (f *friendHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Accept(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (f *friendHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Decline(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (f *friendHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Cancel(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (f *friendHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	friends, err := f.fu.List(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.FriendResponse, 0, len(friends))
+	for _, friend := range friends {
+		res = append(res, dto.FriendResponse{
+			AccountID:          friend.AccountID,
+			Name:               friend.Name,
+			Image:              friend.Image,
+			Level:              friend.Level,
+			Streak:             friend.Streak,
+			WeeklyFocusMinutes: friend.WeeklyFocusMinutes,
+			Since:              friend.Since,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (f *friendHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Remove(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (f *friendHandler) Block(w http.ResponseWriter, r *http.Request) {
+	var req dto.BlockRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := f.fu.Block(ctx, email, req.AccountID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, nil)
+}
+
+func (f *friendHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := f.fu.Unblock(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (f *friendHandler) Blocks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	blocks, err := f.fu.Blocks(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.BlockedAccountResponse, 0, len(blocks))
+	for _, b := range blocks {
+		res = append(res, dto.BlockedAccountResponse{
+			AccountID: b.AccountID,
+			Name:      b.Name,
+			Image:     b.Image,
+			BlockedAt: b.BlockedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (f *friendHandler) GetPrivacy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	privacy, err := f.fu.GetPrivacy(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toPrivacyResponse(privacy))
+}
+
+func (f *friendHandler) UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	var req dto.PrivacyRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	privacy, err := f.fu.UpdatePrivacy(ctx, email, input.Privacy{
+		ShareLevel:       req.ShareLevel,
+		ShareStreak:      req.ShareStreak,
+		ShareWeeklyFocus: req.ShareWeeklyFocus,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toPrivacyResponse(privacy))
+}
+
+func toPrivacyResponse(p output.Privacy) dto.PrivacyResponse {
+	return dto.PrivacyResponse{
+		ShareLevel:       p.ShareLevel,
+		ShareStreak:      p.ShareStreak,
+		ShareWeeklyFocus: p.ShareWeeklyFocus,
+	}
+}
+
+func NewFriendHandler(fu usecase.FriendUsecase) FriendHandler {
+	return &friendHandler{fu}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type GoalHandler interface {
+	List(w http.ResponseWriter, r *http.Request)
+	Set(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	History(w http.ResponseWriter, r *http.Request)
+}
+
+type goalHandler struct {
+	gu usecase.GoalUsecase
+}
+
+func (g *goalHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	goals, err := g.gu.List(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.GoalStatusResponse, 0, len(goals))
+	for _, goal := range goals {
+		res = append(res, dto.GoalStatusResponse{
+			Period:        goal.Period,
+			TargetMinutes: goal.TargetMinutes,
+			FocusMinutes:  goal.FocusMinutes,
+			Achieved:      goal.Achieved,
+			Start:         goal.Start,
+			End:           goal.End,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (g *goalHandler) Set(w http.ResponseWriter, r *http.Request) {
+	var req dto.GoalRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := g.gu.Set(ctx, email, chi.URLParam(r, "period"), req.TargetMinutes); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *goalHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := g.gu.Delete(ctx, email, chi.URLParam(r, "period")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *goalHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	in := input.GoalHistory{Period: q.Get("period")}
+	var err error
+	if in.Limit, err = parseIntQuery(q, "limit"); err == nil {
+		in.Offset, err = parseIntQuery(q, "offset")
+	}
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	achievements, err := g.gu.History(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.GoalAchievementResponse, 0, len(achievements))
+	for _, a := range achievements {
+		res = append(res, dto.GoalAchievementResponse{
+			Period:        a.Period,
+			PeriodStart:   a.PeriodStart,
+			TargetMinutes: a.TargetMinutes,
+			FocusMinutes:  a.FocusMinutes,
+			AchievedAt:    a.AchievedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func NewGoalHandler(gu usecase.GoalUsecase) GoalHandler {
+	return &goalHandler{gu}
+}
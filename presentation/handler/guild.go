@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type GuildHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Search(w http.ResponseWriter, r *http.Request)
+	Join(w http.ResponseWriter, r *http.Request)
+	Mine(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Leave(w http.ResponseWriter, r *http.Request)
+	ChangeRole(w http.ResponseWriter, r *http.Request)
+	Kick(w http.ResponseWriter, r *http.Request)
+	Disband(w http.ResponseWriter, r *http.Request)
+}
+
+type guildHandler struct {
+	gu usecase.GuildUsecase
+}
+
+func (g *guildHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.GuildRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	guild, err := g.gu.Create(ctx, email, input.Guild{Name: req.Name, Description: req.Description})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toGuildResponse(guild))
+}
+
+func (g *guildHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	in := input.GuildSearch{Query: q.Get("q")}
+	var err error
+	if in.Limit, err = parseIntQuery(q, "limit"); err == nil {
+		in.Offset, err = parseIntQuery(q, "offset")
+	}
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	guilds, err := g.gu.Search(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.GuildSummaryResponse, 0, len(guilds))
+	for _, guild := range guilds {
+		res = append(res, dto.GuildSummaryResponse{
+			ID:          guild.ID,
+			Name:        guild.Name,
+			Description: guild.Description,
+			Members:     guild.Members,
+			CreatedAt:   guild.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (g *guildHandler) Join(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := g.gu.Join(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *guildHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	guild, err := g.gu.Mine(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toGuildResponse(guild))
+}
+
+func (g *guildHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req dto.GuildRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	guild, err := g.gu.Update(ctx, email, input.Guild{Name: req.Name, Description: req.Description})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toGuildResponse(guild))
+}
+
+func (g *guildHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := g.gu.Leave(ctx, email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *guildHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	var req dto.GuildRoleRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := g.gu.ChangeRole(ctx, email, chi.URLParam(r, "id"), req.Role); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *guildHandler) Kick(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := g.gu.Kick(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (g *guildHandler) Disband(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := g.gu.Disband(ctx, email); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func toGuildResponse(guild output.Guild) dto.GuildResponse {
+	members := make([]dto.GuildMemberResponse, 0, len(guild.Members))
+	for _, m := range guild.Members {
+		members = append(members, dto.GuildMemberResponse{
+			AccountID: m.AccountID,
+			Name:      m.Name,
+			Image:     m.Image,
+			Role:      m.Role,
+			JoinedAt:  m.JoinedAt,
+		})
+	}
+
+	return dto.GuildResponse{
+		ID:          guild.ID,
+		Name:        guild.Name,
+		Description: guild.Description,
+		Role:        guild.Role,
+		Members:     members,
+		CreatedAt:   guild.CreatedAt,
+	}
+}
+
+func NewGuildHandler(gu usecase.GuildUsecase) GuildHandler {
+	return &guildHandler{gu}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type LeaderboardHandler interface {
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+type leaderboardHandler struct {
+	lu usecase.LeaderboardUsecase
+}
+
+func (l *leaderboardHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	limit, err := parseIntQuery(q, "limit")
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	board, err := l.lu.Get(ctx, email, input.Leaderboard{
+		Metric: q.Get("metric"),
+		Period: q.Get("period"),
+		Scope:  q.Get("scope"),
+		Limit:  limit,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := dto.LeaderboardResponse{
+		Metric:      board.Metric,
+		Period:      board.Period,
+		Scope:       board.Scope,
+		PeriodStart: board.PeriodStart,
+		Entries:     make([]dto.LeaderboardEntryResponse, 0, len(board.Entries)),
+	}
+	for _, e := range board.Entries {
+		res.Entries = append(res.Entries, toLeaderboardEntryResponse(e))
+	}
+	if board.Me != nil {
+		me := toLeaderboardEntryResponse(*board.Me)
+		res.Me = &me
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func toLeaderboardEntryResponse(e output.LeaderboardEntry) dto.LeaderboardEntryResponse {
+	return dto.LeaderboardEntryResponse{
+		Rank:      e.Rank,
+		AccountID: e.AccountID,
+		Name:      e.Name,
+		Image:     e.Image,
+		Score:     e.Score,
+	}
+}
+
+func NewLeaderboardHandler(lu usecase.LeaderboardUsecase) LeaderboardHandler {
+	return &leaderboardHandler{lu}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type LootHandler interface {
+	Tables(w http.ResponseWriter, r *http.Request)
+	Reload(w http.ResponseWriter, r *http.Request)
+	Drops(w http.ResponseWriter, r *http.Request)
+	Inventory(w http.ResponseWriter, r *http.Request)
+	Verify(w http.ResponseWriter, r *http.Request)
+}
+
+type lootHandler struct {
+	lu usecase.LootUsecase
+}
+
+func (h *lootHandler) Tables(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, toLootTableResponses(h.lu.Tables(r.Context())))
+}
+
+func (h *lootHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	tables, err := h.lu.Reload(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toLootTableResponses(tables))
+}
+
+func (h *lootHandler) Drops(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	var in input.LootDrops
+	var err error
+	if in.Limit, err = parseIntQuery(q, "limit"); err == nil {
+		in.Offset, err = parseIntQuery(q, "offset")
+	}
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	drops, err := h.lu.Drops(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.LootDropResponse, 0, len(drops))
+	for _, d := range drops {
+		res = append(res, toLootDropResponse(d))
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *lootHandler) Inventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	items, err := h.lu.Inventory(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.InventoryItemResponse, 0, len(items))
+	for _, i := range items {
+		res = append(res, dto.InventoryItemResponse{
+			Item:   i.Item,
+			Name:   i.Name,
+			Rarity: i.Rarity,
+			Count:  i.Count,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *lootHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	v, err := h.lu.Verify(ctx, email, chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.LootVerificationResponse{
+		Drop:         toLootDropResponse(v.Drop),
+		ReplayedItem: v.ReplayedItem,
+		Match:        v.Match,
+	})
+}
+
+func toLootTableResponses(tables []output.LootTable) []dto.LootTableResponse {
+	res := make([]dto.LootTableResponse, 0, len(tables))
+	for _, t := range tables {
+		entries := make([]dto.LootEntryResponse, 0, len(t.Entries))
+		for _, e := range t.Entries {
+			entries = append(entries, dto.LootEntryResponse{
+				Item:   e.Item,
+				Name:   e.Name,
+				Rarity: e.Rarity,
+				Weight: e.Weight,
+				Rate:   e.Rate,
+			})
+		}
+
+		pity := make([]dto.LootPityRuleResponse, 0, len(t.Pity))
+		for _, p := range t.Pity {
+			pity = append(pity, dto.LootPityRuleResponse{
+				Rarity: p.Rarity,
+				After:  p.After,
+			})
+		}
+
+		res = append(res, dto.LootTableResponse{
+			Key:     t.Key,
+			Name:    t.Name,
+			Source:  t.Source,
+			Version: t.Version,
+			Entries: entries,
+			Pity:    pity,
+		})
+	}
+	return res
+}
+
+func toLootDropResponse(d output.LootDrop) dto.LootDropResponse {
+	return dto.LootDropResponse{
+		ID:           d.ID,
+		TableKey:     d.TableKey,
+		TableVersion: d.TableVersion,
+		Seed:         d.Seed,
+		PityBefore:   d.PityBefore,
+		Item:         d.Item,
+		ItemName:     d.ItemName,
+		Rarity:       d.Rarity,
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func NewLootHandler(lu usecase.LootUsecase) LootHandler {
+	return &lootHandler{lu}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type RaidHandler interface {
+	Bosses(w http.ResponseWriter, r *http.Request)
+	Start(w http.ResponseWriter, r *http.Request)
+	Current(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	History(w http.ResponseWriter, r *http.Request)
+}
+
+type raidHandler struct {
+	ru usecase.RaidUsecase
+}
+
+func (h *raidHandler) Bosses(w http.ResponseWriter, r *http.Request) {
+	bosses := h.ru.Bosses(r.Context())
+
+	res := make([]dto.RaidBossResponse, 0, len(bosses))
+	for _, b := range bosses {
+		res = append(res, dto.RaidBossResponse{
+			Key:            b.Key,
+			Name:           b.Name,
+			HP:             b.HP,
+			TimeLimitHours: b.TimeLimitHours,
+			RewardExp:      b.RewardExp,
+			RewardGold:     b.RewardGold,
+			Season:         b.Season,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (h *raidHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var req dto.RaidRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	raid, err := h.ru.Start(ctx, email, req.Boss)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toRaidResponse(raid))
+}
+
+func (h *raidHandler) Current(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	raid, err := h.ru.Current(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toRaidResponse(raid))
+}
+
+func (h *raidHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	raid, err := h.ru.Get(ctx, email, chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toRaidResponse(raid))
+}
+
+func (h *raidHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	var in input.RaidHistory
+	var err error
+	if in.Limit, err = parseIntQuery(q, "limit"); err == nil {
+		in.Offset, err = parseIntQuery(q, "offset")
+	}
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	raids, err := h.ru.History(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.RaidResponse, 0, len(raids))
+	for _, raid := range raids {
+		res = append(res, toRaidResponse(raid))
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func toRaidResponse(raid output.Raid) dto.RaidResponse {
+	res := dto.RaidResponse{
+		ID:         raid.ID,
+		Boss:       raid.Boss,
+		BossName:   raid.BossName,
+		MaxHP:      raid.MaxHP,
+		HP:         raid.HP,
+		Status:     raid.Status,
+		RewardExp:  raid.RewardExp,
+		RewardGold: raid.RewardGold,
+		StartedAt:  raid.StartedAt,
+		EndsAt:     raid.EndsAt,
+		FinishedAt: raid.FinishedAt,
+	}
+	if raid.Contributors != nil {
+		res.Contributors = make([]dto.RaidContributorResponse, 0, len(raid.Contributors))
+		for _, c := range raid.Contributors {
+			res.Contributors = append(res.Contributors, dto.RaidContributorResponse{
+				AccountID: c.AccountID,
+				Name:      c.Name,
+				Image:     c.Image,
+				Minutes:   c.Minutes,
+				Share:     c.Share,
+			})
+		}
+	}
+
+	return res
+}
+
+func NewRaidHandler(ru usecase.RaidUsecase) RaidHandler {
+	return &raidHandler{ru}
+}
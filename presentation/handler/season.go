@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SeasonHandler interface {
+	List(w http.ResponseWriter, r *http.Request)
+	Reload(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	PurchasePremium(w http.ResponseWriter, r *http.Request)
+	Claim(w http.ResponseWriter, r *http.Request)
+}
+
+type seasonHandler struct {
+	su usecase.SeasonUsecase
+}
+
+func (h *seasonHandler) List(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, toSeasonResponses(h.su.List(r.Context())))
+}
+
+func (h *seasonHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	seasons, err := h.su.Reload(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSeasonResponses(seasons))
+}
+
+func (h *seasonHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	progress, err := h.su.Get(ctx, email, chi.URLParam(r, "key"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSeasonProgressResponse(progress))
+}
+
+func (h *seasonHandler) PurchasePremium(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	progress, err := h.su.PurchasePremium(ctx, email, chi.URLParam(r, "key"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSeasonProgressResponse(progress))
+}
+
+func (h *seasonHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	claims, err := h.su.Claim(ctx, email, chi.URLParam(r, "key"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSeasonClaimResponses(claims))
+}
+
+func toSeasonResponses(seasons []output.Season) []dto.SeasonResponse {
+	res := make([]dto.SeasonResponse, 0, len(seasons))
+	for _, s := range seasons {
+		res = append(res, toSeasonResponse(s))
+	}
+	return res
+}
+
+func toSeasonResponse(s output.Season) dto.SeasonResponse {
+	tiers := make([]dto.SeasonTierResponse, 0, len(s.Tiers))
+	for _, t := range s.Tiers {
+		tiers = append(tiers, dto.SeasonTierResponse{
+			Tier:    t.Tier,
+			Exp:     t.Exp,
+			Free:    dto.SeasonRewardResponse{Exp: t.Free.Exp, Gold: t.Free.Gold},
+			Premium: dto.SeasonRewardResponse{Exp: t.Premium.Exp, Gold: t.Premium.Gold},
+		})
+	}
+
+	quests := make([]dto.SeasonQuestResponse, 0, len(s.Quests))
+	for _, q := range s.Quests {
+		quests = append(quests, dto.SeasonQuestResponse{
+			Key:    q.Key,
+			Name:   q.Name,
+			Kind:   q.Kind,
+			Target: q.Target,
+			Exp:    q.Exp,
+		})
+	}
+
+	bosses := make([]dto.RaidBossResponse, 0, len(s.Bosses))
+	for _, b := range s.Bosses {
+		bosses = append(bosses, dto.RaidBossResponse{
+			Key:            b.Key,
+			Name:           b.Name,
+			HP:             b.HP,
+			TimeLimitHours: b.TimeLimitHours,
+			RewardExp:      b.RewardExp,
+			RewardGold:     b.RewardGold,
+			Season:         b.Season,
+		})
+	}
+
+	return dto.SeasonResponse{
+		Key:         s.Key,
+		Name:        s.Name,
+		Status:      s.Status,
+		StartsAt:    s.StartsAt,
+		EndsAt:      s.EndsAt,
+		ClaimEndsAt: s.ClaimEndsAt,
+		PremiumCost: s.PremiumCost,
+		Tiers:       tiers,
+		Quests:      quests,
+		Bosses:      bosses,
+	}
+}
+
+func toSeasonProgressResponse(p output.SeasonProgress) dto.SeasonProgressResponse {
+	quests := make([]dto.SeasonQuestProgressResponse, 0, len(p.Quests))
+	for _, q := range p.Quests {
+		quests = append(quests, dto.SeasonQuestProgressResponse{
+			Key:       q.Key,
+			Progress:  q.Progress,
+			Completed: q.Completed,
+		})
+	}
+
+	return dto.SeasonProgressResponse{
+		Season:      toSeasonResponse(p.Season),
+		Exp:         p.Exp,
+		Tier:        p.Tier,
+		NextTierExp: p.NextTierExp,
+		Premium:     p.Premium,
+		Quests:      quests,
+		Claimed:     toSeasonClaimResponses(p.Claimed),
+		Claimable:   toSeasonClaimResponses(p.Claimable),
+	}
+}
+
+func toSeasonClaimResponses(claims []output.SeasonClaim) []dto.SeasonClaimResponse {
+	res := make([]dto.SeasonClaimResponse, 0, len(claims))
+	for _, c := range claims {
+		res = append(res, dto.SeasonClaimResponse{
+			Tier:      c.Tier,
+			Track:     c.Track,
+			Exp:       c.Exp,
+			Gold:      c.Gold,
+			ClaimedAt: c.ClaimedAt,
+		})
+	}
+	return res
+}
+
+func NewSeasonHandler(su usecase.SeasonUsecase) SeasonHandler {
+	return &seasonHandler{su}
+}
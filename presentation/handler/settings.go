@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type SettingsHandler interface {
+	Get(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+}
+
+type settingsHandler struct {
+	su usecase.SettingsUsecase
+}
+
+func (s *settingsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	settings, err := s.su.Get(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+func (s *settingsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req dto.SettingsRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	settings, err := s.su.Update(ctx, email, input.Settings{
+		FocusMinutes:      req.FocusMinutes,
+		ShortBreakMinutes: req.ShortBreakMinutes,
+		LongBreakMinutes:  req.LongBreakMinutes,
+		LongBreakInterval: req.LongBreakInterval,
+		AutoStartBreaks:   req.AutoStartBreaks,
+		AutoStartFocus:    req.AutoStartFocus,
+		NotifySound:       req.NotifySound,
+		NotifyDesktop:     req.NotifyDesktop,
+		Timezone:          req.Timezone,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+func toSettingsResponse(s output.Settings) dto.SettingsResponse {
+	return dto.SettingsResponse{
+		FocusMinutes:      s.FocusMinutes,
+		ShortBreakMinutes: s.ShortBreakMinutes,
+		LongBreakMinutes:  s.LongBreakMinutes,
+		LongBreakInterval: s.LongBreakInterval,
+		AutoStartBreaks:   s.AutoStartBreaks,
+		AutoStartFocus:    s.AutoStartFocus,
+		NotifySound:       s.NotifySound,
+		NotifyDesktop:     s.NotifyDesktop,
+		Timezone:          s.Timezone,
+	}
+}
+
+func NewSettingsHandler(su usecase.SettingsUsecase) SettingsHandler {
+	return &settingsHandler{su}
+}
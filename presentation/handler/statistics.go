@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+)
+
+type StatisticsHandler interface {
+	Daily(w http.ResponseWriter, r *http.Request)
+	Cycle(w http.ResponseWriter, r *http.Request)
+	Tasks(w http.ResponseWriter, r *http.Request)
+	Projects(w http.ResponseWriter, r *http.Request)
+	Tags(w http.ResponseWriter, r *http.Request)
+	Heatmap(w http.ResponseWriter, r *http.Request)
+}
+
+type statisticsHandler struct {
+	su usecase.StatisticsUsecase
+}
+
+func (s *statisticsHandler) Daily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	stats, err := s.su.Daily(ctx, email, input.StatisticsPeriod{
+		From:     q.Get("from"),
+		To:       q.Get("to"),
+		Timezone: q.Get("tz"),
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.DailyStatResponse, 0, len(stats))
+	for _, st := range stats {
+		res = append(res, dto.DailyStatResponse{
+			Date:         st.Date,
+			FocusMinutes: st.FocusMinutes,
+			BreakMinutes: st.BreakMinutes,
+			FocusRatio:   st.FocusRatio,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (s *statisticsHandler) Cycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	cycle, err := s.su.Cycle(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dto.CycleResponse{
+		FocusCount:        cycle.FocusCount,
+		CompletedCycles:   cycle.CompletedCycles,
+		LongBreakInterval: cycle.LongBreakInterval,
+		NextKind:          cycle.NextKind,
+	})
+}
+
+func (s *statisticsHandler) Tasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	totals, err := s.su.Tasks(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.TaskTotalResponse, 0, len(totals))
+	for _, t := range totals {
+		res = append(res, dto.TaskTotalResponse{
+			TaskID:             t.TaskID,
+			ProjectID:          t.ProjectID,
+			Title:              t.Title,
+			EstimatedPomodoros: t.EstimatedPomodoros,
+			ActualPomodoros:    t.ActualPomodoros,
+			FocusMinutes:       t.FocusMinutes,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (s *statisticsHandler) Projects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	totals, err := s.su.Projects(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.ProjectTotalResponse, 0, len(totals))
+	for _, p := range totals {
+		res = append(res, dto.ProjectTotalResponse{
+			ProjectID:          p.ProjectID,
+			Name:               p.Name,
+			EstimatedPomodoros: p.EstimatedPomodoros,
+			ActualPomodoros:    p.ActualPomodoros,
+			FocusMinutes:       p.FocusMinutes,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (s *statisticsHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	totals, err := s.su.Tags(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.TagTotalResponse, 0, len(totals))
+	for _, t := range totals {
+		res = append(res, dto.TagTotalResponse{
+			Name:            t.Name,
+			ActualPomodoros: t.ActualPomodoros,
+			FocusMinutes:    t.FocusMinutes,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (s *statisticsHandler) Heatmap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	days, err := s.su.Heatmap(ctx, email, input.Heatmap{
+		Year:     q.Get("year"),
+		Timezone: q.Get("tz"),
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.HeatmapDayResponse, 0, len(days))
+	for _, d := range days {
+		res = append(res, dto.HeatmapDayResponse{
+			Date:         d.Date,
+			FocusMinutes: d.FocusMinutes,
+			Level:        d.Level,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func NewStatisticsHandler(su usecase.StatisticsUsecase) StatisticsHandler {
+	return &statisticsHandler{su}
+}
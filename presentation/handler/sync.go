@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+
+	"github.com/cockroachdb/errors"
+)
+
+type SyncHandler interface {
+	Sync(w http.ResponseWriter, r *http.Request)
+}
+
+type syncHandler struct {
+	su usecase.SyncUsecase
+}
+
+func (s *syncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	var req dto.SyncRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	in := input.Sync{Token: req.Token, Sessions: make([]input.SyncSession, 0, len(req.Sessions))}
+	for _, session := range req.Sessions {
+		in.Sessions = append(in.Sessions, input.SyncSession{
+			ID:      session.ID,
+			Kind:    session.Kind,
+			Minutes: session.Minutes,
+			TaskID:  session.TaskID,
+			Note:    session.Note,
+			Tags:    session.Tags,
+			EndedAt: session.EndedAt,
+		})
+	}
+
+	out, err := s.su.Sync(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := dto.SyncResponse{
+		Token:          out.Token,
+		HasMore:        out.HasMore,
+		Results:        make([]dto.SyncResultResponse, 0, len(out.Results)),
+		Times:          make([]dto.TimeResponse, 0, len(out.Times)),
+		DeletedTimeIDs: out.DeletedTimeIDs,
+		Breaks:         make([]dto.BreakResponse, 0, len(out.Breaks)),
+	}
+	for _, result := range out.Results {
+		res.Results = append(res.Results, dto.SyncResultResponse{ID: result.ID, Status: result.Status, Reason: result.Reason})
+	}
+	for _, tm := range out.Times {
+		res.Times = append(res.Times, dto.TimeResponse{
+			ID:            tm.ID,
+			FocusTime:     tm.FocusTime,
+			TaskID:        tm.TaskID,
+			Note:          tm.Note,
+			Tags:          tm.Tags,
+			ExecutionDate: tm.ExecutionDate,
+		})
+	}
+	for _, b := range out.Breaks {
+		res.Breaks = append(res.Breaks, dto.BreakResponse{
+			ID:            b.ID,
+			Kind:          b.Kind,
+			Minutes:       b.Minutes,
+			ExecutionDate: b.ExecutionDate,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func NewSyncHandler(su usecase.SyncUsecase) SyncHandler {
+	return &syncHandler{su}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+type TaskHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type taskHandler struct {
+	tu usecase.TaskUsecase
+}
+
+func (t *taskHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	task, err := t.tu.Create(ctx, email, toTaskInput(req))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toTaskResponse(task))
+}
+
+func (t *taskHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	tasks, err := t.tu.List(ctx, email, r.URL.Query().Get("projectId"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		res = append(res, toTaskResponse(task))
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (t *taskHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	task, err := t.tu.Update(ctx, email, chi.URLParam(r, "id"), toTaskInput(req))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toTaskResponse(task))
+}
+
+func (t *taskHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := t.tu.Delete(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func toTaskInput(req dto.TaskRequest) input.Task {
+	return input.Task{
+		ProjectID:          req.ProjectID,
+		Title:              req.Title,
+		EstimatedPomodoros: req.EstimatedPomodoros,
+		Completed:          req.Completed,
+	}
+}
+
+func toTaskResponse(t output.Task) dto.TaskResponse {
+	return dto.TaskResponse{
+		ID:                 t.ID,
+		ProjectID:          t.ProjectID,
+		Title:              t.Title,
+		EstimatedPomodoros: t.EstimatedPomodoros,
+		Completed:          t.Completed,
+		CreatedAt:          t.CreatedAt,
+	}
+}
+
+func NewTaskHandler(tu usecase.TaskUsecase) TaskHandler {
+	return &taskHandler{tu}
+}
@@ -9,12 +9,18 @@ import (
 	"pomodoro-rpg-api/presentation/dto"
 	"pomodoro-rpg-api/presentation/response"
 	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/input"
 
 	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
 )
 
 type TimeHandler interface {
 	Create(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	SuggestTags(w http.ResponseWriter, r *http.Request)
 }
 
 type timeHandler struct {
@@ -32,7 +38,7 @@ func (t *timeHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := t.tu.Create(ctx, email, req.FocusTime); err != nil {
+	if err := t.tu.Create(ctx, email, toTimeInput(req)); err != nil {
 		response.Error(w, err)
 		return
 	}
@@ -40,6 +46,96 @@ func (t *timeHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusCreated, nil)
 }
 
+func (t *timeHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+	q := r.URL.Query()
+
+	in := input.TimeSearch{Tag: q.Get("tag")}
+	var err error
+	if in.Limit, err = parseIntQuery(q, "limit"); err == nil {
+		in.Offset, err = parseIntQuery(q, "offset")
+	}
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid query", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	times, err := t.tu.List(ctx, email, in)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	res := make([]dto.TimeResponse, 0, len(times))
+	for _, tm := range times {
+		res = append(res, dto.TimeResponse{
+			ID:            tm.ID,
+			FocusTime:     tm.FocusTime,
+			TaskID:        tm.TaskID,
+			Note:          tm.Note,
+			Tags:          tm.Tags,
+			ExecutionDate: tm.ExecutionDate,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, res)
+}
+
+func (t *timeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req dto.TimeRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	if err := t.tu.Update(ctx, email, chi.URLParam(r, "id"), toTimeInput(req)); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (t *timeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := t.tu.Delete(ctx, email, chi.URLParam(r, "id")); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, nil)
+}
+
+func (t *timeHandler) SuggestTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	tags, err := t.tu.SuggestTags(ctx, email, r.URL.Query().Get("q"))
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tags)
+}
+
+func toTimeInput(req dto.TimeRequest) input.Time {
+	return input.Time{
+		FocusTime: req.FocusTime,
+		TaskID:    req.TaskID,
+		Note:      req.Note,
+		Tags:      req.Tags,
+	}
+}
+
 func NewTimeHandler(tu usecase.TimeUsecase) TimeHandler {
 	return &timeHandler{tu}
 }
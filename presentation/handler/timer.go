@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/dto"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// プロキシやロードバランサにアイドル接続を切断されないよう定期的にコメントを送る
+const streamHeartbeatInterval = 25 * time.Second
+
+type TimerHandler interface {
+	Get(w http.ResponseWriter, r *http.Request)
+	Start(w http.ResponseWriter, r *http.Request)
+	Pause(w http.ResponseWriter, r *http.Request)
+	Resume(w http.ResponseWriter, r *http.Request)
+	Complete(w http.ResponseWriter, r *http.Request)
+	Abandon(w http.ResponseWriter, r *http.Request)
+	Stream(w http.ResponseWriter, r *http.Request)
+}
+
+type timerHandler struct {
+	tu usecase.TimerUsecase
+}
+
+func (t *timerHandler) Get(w http.ResponseWriter, r *http.Request) {
+	t.respond(w, r, t.tu.Get)
+}
+
+func (t *timerHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var req dto.StartTimerRequest
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Event(ctx, logger.INFO, "invalid request", errors.WithStack(err))
+		response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+		return
+	}
+
+	timer, err := t.tu.Start(ctx, email, req.Kind, req.PlannedSeconds)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toTimerResponse(timer))
+}
+
+func (t *timerHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	t.respond(w, r, t.tu.Pause)
+}
+
+func (t *timerHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	t.respond(w, r, t.tu.Resume)
+}
+
+func (t *timerHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	t.respond(w, r, t.tu.Complete)
+}
+
+func (t *timerHandler) Abandon(w http.ResponseWriter, r *http.Request) {
+	t.respond(w, r, t.tu.Abandon)
+}
+
+// Stream はServer-Sent Eventsでタイマーの状態変更を配信する
+func (t *timerHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := errors.New("streaming unsupported")
+		logger.Event(ctx, logger.ERROR, err.Error(), err)
+		response.Error(w, err)
+		return
+	}
+
+	timers, err := t.tu.Subscribe(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case timer, ok := <-timers:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(toTimerResponse(timer))
+			if err != nil {
+				logger.Event(ctx, logger.ERROR, "marshal timer failed", errors.WithStack(err))
+				return
+			}
+			fmt.Fprintf(w, "event: timer\ndata: %s\n\n", data)
+		}
+		flusher.Flush()
+	}
+}
+
+func (t *timerHandler) respond(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, email string) (output.Timer, error)) {
+	ctx := r.Context()
+	email := ctx.Value(contextkey.Email).(string)
+
+	timer, err := fn(ctx, email)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toTimerResponse(timer))
+}
+
+func toTimerResponse(timer output.Timer) dto.TimerResponse {
+	return dto.TimerResponse{
+		ID:               timer.ID,
+		Kind:             timer.Kind,
+		Status:           timer.Status,
+		PlannedSeconds:   timer.PlannedSeconds,
+		ElapsedSeconds:   timer.ElapsedSeconds,
+		RemainingSeconds: timer.RemainingSeconds,
+		StartedAt:        timer.StartedAt,
+		ServerTime:       timer.ServerTime,
+	}
+}
+
+func NewTimerHandler(tu usecase.TimerUsecase) TimerHandler {
+	return &timerHandler{tu}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"slices"
+)
+
+type AdminAuthorizer struct {
+	emails []string
+}
+
+func NewAdminAuthorizer(emails []string) *AdminAuthorizer {
+	return &AdminAuthorizer{emails: emails}
+}
+
+// Authenticator.Middlewareの後に適用する
+func (a *AdminAuthorizer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email, ok := r.Context().Value(contextkey.Email).(string)
+		if !ok || !slices.Contains(a.emails, email) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -43,7 +43,7 @@ func (a *Authenticator) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		sub, email, err := a.validateToken(token)
+		sub, email, err := a.validateToken(r.Context(), token)
 		if err != nil {
 			http.Error(w, "Unauthorized: invalid token", http.StatusUnauthorized)
 			return
@@ -63,9 +63,9 @@ func (a *Authenticator) getToken(r *http.Request) (string, error) {
 	return cookie.Value, nil
 }
 
-func (a *Authenticator) validateToken(tokenStr string) (string, string, error) {
+func (a *Authenticator) validateToken(ctx context.Context, tokenStr string) (string, string, error) {
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		return a.lookupKey(token)
+		return a.lookupKey(ctx, token)
 	})
 
 	if err != nil || !token.Valid {
@@ -82,7 +82,7 @@ func (a *Authenticator) validateToken(tokenStr string) (string, string, error) {
 		return "", "", errors.New("sub claim missing")
 	}
 
-	email, err := a.CognitoService.GetEmail(tokenStr)
+	email, err := a.CognitoService.GetEmail(ctx, tokenStr)
 	if err != nil {
 		return "", "", err
 	}
@@ -90,8 +90,8 @@ func (a *Authenticator) validateToken(tokenStr string) (string, string, error) {
 	return sub, email, nil
 }
 
-func (a *Authenticator) lookupKey(token *jwt.Token) (interface{}, error) {
-	jwks, err := a.getJSONWebKeys()
+func (a *Authenticator) lookupKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	jwks, err := a.getJSONWebKeys(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -106,14 +106,19 @@ func (a *Authenticator) lookupKey(token *jwt.Token) (interface{}, error) {
 	return nil, errors.New("key not found")
 }
 
-func (a *Authenticator) getJSONWebKeys() (*jose.JSONWebKeySet, error) {
+func (a *Authenticator) getJSONWebKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
 	if a.jwkCache.jwks != nil && time.Since(a.jwkCache.timestamp).Minutes() < 10.0 {
 		return a.jwkCache.jwks, nil
 	}
 
 	url := fmt.Sprintf("https://cognito-idp.ap-northeast-1.amazonaws.com/%s/.well-known/jwks.json", a.UserPoolID)
 
-	res, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
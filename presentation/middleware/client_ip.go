@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"strings"
+)
+
+type ClientIPResolver struct {
+	trustedHops int
+}
+
+// trustedHopsにはX-Forwarded-Forに接続元を追記する信頼できるプロキシの段数を指定する
+func NewClientIPResolver(trustedHops int) *ClientIPResolver {
+	return &ClientIPResolver{trustedHops: trustedHops}
+}
+
+func (c *ClientIPResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), contextkey.ClientIP, c.clientIP(r))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// X-Forwarded-Forの左側は接続元が自由に書き換えられるため、信頼できるプロキシが右から追記した分だけを辿る。
+// 段数が足りない場合や、プロキシを経由しない設定の場合は直接の接続元とみなす
+func (c *ClientIPResolver) clientIP(r *http.Request) string {
+	if c.trustedHops > 0 {
+		var ips []string
+		for _, h := range r.Header.Values("X-Forwarded-For") {
+			for _, ip := range strings.Split(h, ",") {
+				ips = append(ips, strings.TrimSpace(ip))
+			}
+		}
+
+		if i := len(ips) - c.trustedHops; i >= 0 && net.ParseIP(ips[i]) != nil {
+			return ips[i]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
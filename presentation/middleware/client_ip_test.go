@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPResolverClientIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		trustedHops int
+		forwarded   []string
+		want        string
+	}{
+		{name: "プロキシを経由しない設定ではヘッダーを使わない", trustedHops: 0, forwarded: []string{"203.0.113.1"}, want: "192.0.2.10"},
+		{name: "1段のプロキシでは右端を接続元とする", trustedHops: 1, forwarded: []string{"198.51.100.7, 203.0.113.1"}, want: "203.0.113.1"},
+		{name: "2段のプロキシでは右から2番目を接続元とする", trustedHops: 2, forwarded: []string{"198.51.100.7, 203.0.113.1, 10.0.0.2"}, want: "203.0.113.1"},
+		{name: "複数のヘッダーは連結して数える", trustedHops: 2, forwarded: []string{"198.51.100.7", "203.0.113.1, 10.0.0.2"}, want: "203.0.113.1"},
+		{name: "段数が足りない場合は直接の接続元とする", trustedHops: 2, forwarded: []string{"203.0.113.1"}, want: "192.0.2.10"},
+		{name: "ヘッダーがない場合は直接の接続元とする", trustedHops: 1, want: "192.0.2.10"},
+		{name: "IPアドレスでない場合は直接の接続元とする", trustedHops: 1, forwarded: []string{"unknown"}, want: "192.0.2.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "192.0.2.10:54321"
+			for _, f := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+
+			if got := NewClientIPResolver(tt.trustedHops).clientIP(r); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/presentation/response"
+	"pomodoro-rpg-api/usecase"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+type Idempotency struct {
+	iu usecase.IdempotencyUsecase
+}
+
+func NewIdempotency(iu usecase.IdempotencyUsecase) *Idempotency {
+	return &Idempotency{iu}
+}
+
+// Middleware はIdempotency-Keyが指定されたリクエストの再送に最初のレスポンスを返す。
+// Authenticator.Middlewareの後に適用する
+func (i *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		email := ctx.Value(contextkey.Email).(string)
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+		if err != nil {
+			logger.Event(ctx, logger.INFO, "read request body failed", errors.WithStack(err))
+			response.Error(w, apperr.NewApplicationError(apperr.ErrBadRequest, "不正なリクエストです", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := email + " " + r.Method + " " + r.URL.Path
+		sum := sha256.Sum256(body)
+
+		replay, err := i.iu.Begin(ctx, scope, key, hex.EncodeToString(sum[:]))
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		if replay != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(replay.StatusCode)
+			w.Write(replay.Body)
+			return
+		}
+
+		// クライアントが切断しても結果を保存し、再送時に二重に処理されないようにする
+		ctx = context.WithoutCancel(ctx)
+
+		// ハンドラーがpanicした場合や結果を保存できなかった場合も、予約が有効期限まで残って再送できなくならないよう取り消す
+		completed := false
+		defer func() {
+			if !completed {
+				i.iu.Release(ctx, scope, key)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// 保存に失敗した場合のエラーはユースケースでログに残す
+		if rec.status < http.StatusInternalServerError {
+			completed = i.iu.Complete(ctx, scope, key, rec.status, rec.body.Bytes()) == nil
+		}
+	})
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/usecase/output"
+	"testing"
+)
+
+type fakeIdempotencyUsecase struct {
+	completeErr error
+	completed   bool
+	released    bool
+}
+
+func (f *fakeIdempotencyUsecase) Begin(ctx context.Context, scope, key, requestHash string) (*output.IdempotentResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeIdempotencyUsecase) Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error {
+	if f.completeErr != nil {
+		return f.completeErr
+	}
+	f.completed = true
+	return nil
+}
+
+func (f *fakeIdempotencyUsecase) Release(ctx context.Context, scope, key string) error {
+	f.released = true
+	return nil
+}
+
+func (f *fakeIdempotencyUsecase) Purge(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func TestIdempotencyMiddlewareReleasesKeyWithoutResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		completeErr  error
+		wantComplete bool
+		wantRelease  bool
+	}{
+		{
+			name:         "成功したレスポンスは保存する",
+			handler:      func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) },
+			wantComplete: true,
+		},
+		{
+			name:        "サーバーエラーは予約を取り消す",
+			handler:     func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+			wantRelease: true,
+		},
+		{
+			name:        "ハンドラーがpanicした場合は予約を取り消す",
+			handler:     func(w http.ResponseWriter, r *http.Request) { panic("boom") },
+			wantRelease: true,
+		},
+		{
+			name:        "保存に失敗した場合は予約を取り消す",
+			handler:     func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) },
+			completeErr: errors.New("db is down"),
+			wantRelease: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iu := &fakeIdempotencyUsecase{completeErr: tt.completeErr}
+			h := NewIdempotency(iu).Middleware(tt.handler)
+
+			r := httptest.NewRequest(http.MethodPost, "/times", nil)
+			r.Header.Set(IdempotencyKeyHeader, "key")
+			r = r.WithContext(context.WithValue(r.Context(), contextkey.Email, "user@example.com"))
+
+			func() {
+				defer func() { recover() }()
+				h.ServeHTTP(httptest.NewRecorder(), r)
+			}()
+
+			if iu.completed != tt.wantComplete || iu.released != tt.wantRelease {
+				t.Errorf("completed = %v, released = %v, want %v, %v", iu.completed, iu.released, tt.wantComplete, tt.wantRelease)
+			}
+		})
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"net/http"
 	"pomodoro-rpg-api/pkg/contextkey"
 	"pomodoro-rpg-api/pkg/logger"
+	"strings"
 )
 
+// calendarFeedPrefix 以降はカレンダーフィードの認証トークンのため、ログに残さない
+const calendarFeedPrefix = "/calendar/"
+
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		next.ServeHTTP(w, r)
@@ -20,7 +24,7 @@ func Logger(next http.Handler) http.Handler {
 			slog.String("userID", userID),
 			slog.String("method", r.Method),
 			slog.String("host", r.Host),
-			slog.String("uri", r.RequestURI),
+			slog.String("uri", redactURI(r.RequestURI)),
 			slog.String("remote", r.RemoteAddr),
 		)
 	})
@@ -39,3 +43,10 @@ func getUserID(ctx context.Context) string {
 	}
 	return ""
 }
+
+func redactURI(uri string) string {
+	if strings.HasPrefix(uri, calendarFeedPrefix) {
+		return calendarFeedPrefix + "[REDACTED]"
+	}
+	return uri
+}
@@ -25,6 +25,18 @@ func Error(w http.ResponseWriter, err error) {
 				Message: appErr.Message(),
 			})
 			return
+		case apperr.ErrForbidden:
+			JSON(w, http.StatusForbidden, errorResponse{
+				Code:    appErr.Code().String(),
+				Message: appErr.Message(),
+			})
+			return
+		case apperr.ErrConflict:
+			JSON(w, http.StatusConflict, errorResponse{
+				Code:    appErr.Code().String(),
+				Message: appErr.Message(),
+			})
+			return
 		default:
 			JSON(w, http.StatusInternalServerError, errorResponse{
 				Code: "InternalServerError",
@@ -0,0 +1,9 @@
+package response
+
+import "net/http"
+
+func ICS(w http.ResponseWriter, statusCode int, body []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
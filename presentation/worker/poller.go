@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Job は1バッチ分の処理を行い、処理した件数を返す
+type Job func(ctx context.Context) (int, error)
+
+type Poller struct {
+	job      Job
+	interval time.Duration
+}
+
+func NewPoller(job Job, interval time.Duration) *Poller {
+	return &Poller{job, interval}
+}
+
+// Run はctxがキャンセルされるまでjobを定期的に実行する
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		// 処理対象があった場合は残りがある可能性があるため待たずに続ける
+		n, err := p.job(ctx)
+		if err == nil && n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"pomodoro-rpg-api/domain/model"
 	"pomodoro-rpg-api/domain/repository"
 	"pomodoro-rpg-api/pkg/apperr"
 	"pomodoro-rpg-api/pkg/logger"
@@ -17,11 +18,12 @@ type AccountUsecase interface {
 }
 
 type accountUsecase struct {
-	ar repository.AccountRepository
+	ar    repository.AccountRepository
+	audit AuditUsecase
 }
 
 func (a *accountUsecase) GetByEmail(ctx context.Context, email string) (output.Account, error) {
-	acc, err := a.ar.FindByEmail(email)
+	acc, err := a.ar.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, apperr.ErrDataNotFound) {
 			logger.Event(ctx, logger.INFO, "account not found", err)
@@ -40,8 +42,8 @@ func (a *accountUsecase) GetByEmail(ctx context.Context, email string) (output.A
 	return output, nil
 }
 
-func (a *accountUsecase) Update(ctx context.Context, input input.Account) error {
-	acc, err := a.ar.FindByEmail(input.Email)
+func (a *accountUsecase) Update(ctx context.Context, in input.Account) error {
+	acc, err := a.ar.FindByEmail(ctx, in.Email)
 	if err != nil {
 		if errors.Is(err, apperr.ErrDataNotFound) {
 			logger.Event(ctx, logger.INFO, "account not found", err)
@@ -51,20 +53,32 @@ func (a *accountUsecase) Update(ctx context.Context, input input.Account) error
 		return err
 	}
 
-	acc.UpdateImage(input.Image)
-	if err := acc.UpdateName(input.Name); err != nil {
+	diff := model.AuditDiff{}
+	diff.Add("name", acc.Name, in.Name)
+	diff.Add("image", acc.Image, in.Image)
+
+	acc.UpdateImage(in.Image)
+	if err := acc.UpdateName(in.Name); err != nil {
 		logger.Event(ctx, logger.INFO, "update name failed", err)
 		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が不正です", err)
 	}
 
-	if err := a.ar.Update(acc); err != nil {
+	if err := a.ar.Update(ctx, acc); err != nil {
 		logger.Event(ctx, logger.ERROR, "account update failed", err)
 		return err
 	}
 
+	a.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionUpdateAccount,
+		TargetType: model.AuditTargetAccount,
+		TargetID:   acc.ID.String(),
+		Diff:       diff,
+	})
+
 	return nil
 }
 
-func NewAccountUsecase(ar repository.AccountRepository) AccountUsecase {
-	return &accountUsecase{ar}
+func NewAccountUsecase(ar repository.AccountRepository, audit AuditUsecase) AccountUsecase {
+	return &accountUsecase{ar, audit}
 }
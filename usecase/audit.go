@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/contextkey"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxAuditSearchLimit = 500
+
+type AuditUsecase interface {
+	Record(ctx context.Context, input input.AuditEvent)
+	Search(ctx context.Context, email string, input input.AuditSearch) ([]output.AuditEvent, error)
+}
+
+type auditUsecase struct {
+	ar  repository.AccountRepository
+	aer repository.AuditEventRepository
+}
+
+// 監査ログの書き込み失敗で本来の処理を失敗させないため、エラーはログ出力のみ行う
+func (a *auditUsecase) Record(ctx context.Context, input input.AuditEvent) {
+	requestID, _ := ctx.Value(contextkey.RequestID).(string)
+	ip, _ := ctx.Value(contextkey.ClientIP).(string)
+
+	ev, err := model.NewAuditEvent(
+		model.GenerateAuditEventID(),
+		input.ActorID,
+		input.Action,
+		input.TargetType,
+		input.TargetID,
+		requestID,
+		ip,
+		input.Diff,
+	)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "invalid audit event", err)
+		return
+	}
+
+	if err := a.aer.Create(ctx, ev); err != nil {
+		logger.Event(ctx, logger.ERROR, "audit event create failed", err)
+	}
+}
+
+func (a *auditUsecase) Search(ctx context.Context, email string, in input.AuditSearch) ([]output.AuditEvent, error) {
+	admin, err := a.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return nil, err
+	}
+
+	if in.Limit < 0 || in.Limit > maxAuditSearchLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, "invalid paging", err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	filter := repository.AuditEventFilter{
+		Action:     model.AuditAction(in.Action),
+		TargetType: model.AuditTargetType(in.TargetType),
+		TargetID:   in.TargetID,
+		RequestID:  in.RequestID,
+		From:       in.From,
+		To:         in.To,
+		Limit:      in.Limit,
+		Offset:     in.Offset,
+	}
+
+	if in.ActorID != "" {
+		actorID, err := model.NewAccountID(in.ActorID)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, "invalid actor id", err)
+			return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+		}
+		filter.ActorID = actorID
+	}
+
+	events, err := a.aer.Find(ctx, filter)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "audit event find failed", err)
+		return nil, err
+	}
+
+	a.Record(ctx, input.AuditEvent{
+		ActorID:    admin.ID,
+		Action:     model.AuditActionSearchAuditEvents,
+		TargetType: model.AuditTargetAuditEvent,
+	})
+
+	res := make([]output.AuditEvent, 0, len(events))
+	for _, ev := range events {
+		res = append(res, output.AuditEvent{
+			ID:         ev.ID.String(),
+			ActorID:    ev.ActorID.String(),
+			Action:     string(ev.Action),
+			TargetType: string(ev.TargetType),
+			TargetID:   ev.TargetID,
+			RequestID:  ev.RequestID,
+			IP:         ev.IP,
+			Diff:       ev.Diff,
+			CreatedAt:  ev.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func NewAuditUsecase(ar repository.AccountRepository, aer repository.AuditEventRepository) AuditUsecase {
+	return &auditUsecase{ar, aer}
+}
@@ -18,21 +18,24 @@ import (
 type AuthUsecase interface {
 	SignIn(ctx context.Context, email, password string) (output.SignIn, error)
 	SignUp(ctx context.Context, input input.SignUp) error
-	SignOut(ctx context.Context, token string) error
+	SignOut(ctx context.Context, email, token string) error
 	ConfirmSignUp(ctx context.Context, email, code string) error
-	ChangePassword(ctx context.Context, token string, previousPass string, proposedPass string) error
+	ChangePassword(ctx context.Context, email, token string, previousPass string, proposedPass string) error
 	ForgotPassword(ctx context.Context, email string) error
 	ConfirmForgotPassword(ctx context.Context, email, code, password string) error
 	VerifyToken(ctx context.Context, tokenStr string) (bool, error)
 }
 
 type authUsecase struct {
-	cs service.CognitoService
-	ar repository.AccountRepository
+	cs    service.CognitoService
+	ar    repository.AccountRepository
+	or    repository.OutboxRepository
+	tx    repository.Transaction
+	audit AuditUsecase
 }
 
 func (a *authUsecase) ConfirmForgotPassword(ctx context.Context, email, code, password string) error {
-	if err := a.cs.ConfirmForgotPassword(email, code, password); err != nil {
+	if err := a.cs.ConfirmForgotPassword(ctx, email, code, password); err != nil {
 		if errors.Is(err, apperr.ErrInvalidParameter) {
 			logger.Event(ctx, logger.INFO, "invalid input", err)
 			return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
@@ -41,11 +44,12 @@ func (a *authUsecase) ConfirmForgotPassword(ctx context.Context, email, code, pa
 		return err
 	}
 
+	a.recordAccountEvent(ctx, email, model.AuditActionResetPassword)
 	return nil
 }
 
 func (a *authUsecase) ForgotPassword(ctx context.Context, email string) error {
-	if err := a.cs.ForgotPassword(email); err != nil {
+	if err := a.cs.ForgotPassword(ctx, email); err != nil {
 		if errors.Is(err, apperr.ErrInvalidParameter) {
 			logger.Event(ctx, logger.INFO, "invalid email", err)
 			return apperr.NewApplicationError(apperr.ErrBadRequest, "ユーザーが見つかりませんでした", err)
@@ -57,8 +61,8 @@ func (a *authUsecase) ForgotPassword(ctx context.Context, email string) error {
 	return nil
 }
 
-func (a *authUsecase) ChangePassword(ctx context.Context, token string, previousPass string, proposedPass string) error {
-	if err := a.cs.ChangePassword(token, previousPass, proposedPass); err != nil {
+func (a *authUsecase) ChangePassword(ctx context.Context, email, token string, previousPass string, proposedPass string) error {
+	if err := a.cs.ChangePassword(ctx, token, previousPass, proposedPass); err != nil {
 		if errors.Is(err, apperr.ErrUnautorizedExeption) {
 			logger.Event(ctx, logger.INFO, "unauthorized", err)
 			return apperr.NewApplicationError(apperr.ErrUnautorized, "アクセストークンが不正です", err)
@@ -71,11 +75,12 @@ func (a *authUsecase) ChangePassword(ctx context.Context, token string, previous
 		return err
 	}
 
+	a.recordAccountEvent(ctx, email, model.AuditActionChangePassword)
 	return nil
 }
 
 func (a *authUsecase) ConfirmSignUp(ctx context.Context, email string, code string) error {
-	if err := a.cs.ConfirmSignUp(email, code); err != nil {
+	if err := a.cs.ConfirmSignUp(ctx, email, code); err != nil {
 		if errors.Is(err, apperr.ErrInvalidParameter) {
 			logger.Event(ctx, logger.INFO, err.Error(), err)
 			return apperr.NewApplicationError(apperr.ErrBadRequest, "入力が間違っています", err)
@@ -88,17 +93,18 @@ func (a *authUsecase) ConfirmSignUp(ctx context.Context, email string, code stri
 }
 
 func (a *authUsecase) SignIn(ctx context.Context, email string, password string) (output.SignIn, error) {
-	res, err := a.cs.SignIn(email, password)
+	res, err := a.cs.SignIn(ctx, email, password)
 	if err != nil {
 		logger.Event(ctx, logger.INFO, "signin failed", err)
 		return output.SignIn{}, apperr.NewApplicationError(apperr.ErrUnautorized, "signin failed", err)
 	}
 
+	a.recordAccountEvent(ctx, email, model.AuditActionSignIn)
 	return res, nil
 }
 
 func (a *authUsecase) SignUp(ctx context.Context, input input.SignUp) error {
-	cognitoUID, err := a.cs.SignUp(input.Email, input.Password)
+	cognitoUID, err := a.cs.SignUp(ctx, input.Email, input.Password)
 	if err != nil {
 		logger.Event(ctx, logger.INFO, "signup failed", err)
 		return apperr.NewApplicationError(apperr.ErrBadRequest, "sign up failed", err)
@@ -111,7 +117,13 @@ func (a *authUsecase) SignUp(ctx context.Context, input input.SignUp) error {
 		return apperr.NewApplicationError(apperr.ErrBadRequest, "invalid input", err)
 	}
 
-	if err := a.ar.Create(acc); err != nil {
+	err = a.tx.Do(ctx, func(ctx context.Context) error {
+		if err := a.ar.Create(ctx, acc); err != nil {
+			return err
+		}
+		return a.or.Save(ctx, acc.PullEvents()...)
+	})
+	if err != nil {
 		logger.Event(ctx, logger.INFO, "account create failed", err)
 		return apperr.NewApplicationError(apperr.ErrBadRequest, "sign up failed", err)
 	}
@@ -119,18 +131,19 @@ func (a *authUsecase) SignUp(ctx context.Context, input input.SignUp) error {
 	return nil
 }
 
-func (a *authUsecase) SignOut(ctx context.Context, token string) error {
-	if err := a.cs.SignOut(token); err != nil {
+func (a *authUsecase) SignOut(ctx context.Context, email, token string) error {
+	if err := a.cs.SignOut(ctx, token); err != nil {
 		logger.Event(ctx, logger.ERROR, "SignOut failed", err)
 		return err
 	}
 
+	a.recordAccountEvent(ctx, email, model.AuditActionSignOut)
 	return nil
 }
 
 func (a *authUsecase) VerifyToken(ctx context.Context, tokenStr string) (bool, error) {
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		return a.lookupKey(token)
+		return a.lookupKey(ctx, token)
 	})
 
 	if err != nil {
@@ -158,8 +171,8 @@ func (a *authUsecase) VerifyToken(ctx context.Context, tokenStr string) (bool, e
 	return true, nil
 }
 
-func (a *authUsecase) lookupKey(token *jwt.Token) (interface{}, error) {
-	jwks, err := a.cs.GetJSONWebKeys()
+func (a *authUsecase) lookupKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	jwks, err := a.cs.GetJSONWebKeys(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +187,21 @@ func (a *authUsecase) lookupKey(token *jwt.Token) (interface{}, error) {
 	return nil, errors.New("key not found")
 }
 
-func NewAuthUsecase(cs service.CognitoService, ar repository.AccountRepository) AuthUsecase {
-	return &authUsecase{cs, ar}
+func (a *authUsecase) recordAccountEvent(ctx context.Context, email string, action model.AuditAction) {
+	acc, err := a.ar.FindByEmail(ctx, email)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return
+	}
+
+	a.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     action,
+		TargetType: model.AuditTargetAccount,
+		TargetID:   acc.ID.String(),
+	})
+}
+
+func NewAuthUsecase(cs service.CognitoService, ar repository.AccountRepository, or repository.OutboxRepository, tx repository.Transaction, audit AuditUsecase) AuthUsecase {
+	return &authUsecase{cs, ar, or, tx, audit}
 }
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+
+	"github.com/cockroachdb/errors"
+)
+
+type BreakUsecase interface {
+	Create(ctx context.Context, email string, kind string, minutes float64) error
+}
+
+type breakUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	tx       repository.Transaction
+	recorder SessionRecorder
+}
+
+func (b *breakUsecase) Create(ctx context.Context, email string, kind string, minutes float64) error {
+	acc, err := b.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return err
+	}
+
+	sessionKind, err := model.NewSessionKind(kind)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	settings, err := findSettings(ctx, b.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return err
+	}
+
+	if err := settings.ValidateSessionMinutes(sessionKind, minutes); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "設定した休憩時間を超えています", err)
+	}
+
+	record, err := model.NewBreak(model.GenerateBreakID(), acc.ID, sessionKind, minutes)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = b.tx.Do(ctx, func(ctx context.Context) error {
+		return b.recorder.RecordBreak(ctx, record, settings.LongBreakInterval)
+	})
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "create break failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func NewBreakUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, tx repository.Transaction, recorder SessionRecorder) BreakUsecase {
+	return &breakUsecase{ar, sr, tx, recorder}
+}
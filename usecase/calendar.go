@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+// calendarFeedLimit はフィードに載せる直近の集中セッションの件数
+const calendarFeedLimit = 1000
+
+type CalendarUsecase interface {
+	// Issue はフィードのトークンを発行し直す。以前のトークンは使えなくなる
+	Issue(ctx context.Context, email string) (string, error)
+	Revoke(ctx context.Context, email string) error
+	// Feed はログインを伴わないため、トークンが無効な場合は存在しないものとして扱う
+	Feed(ctx context.Context, token string) ([]output.CalendarEvent, error)
+}
+
+type calendarUsecase struct {
+	ar    repository.AccountRepository
+	cfr   repository.CalendarFeedRepository
+	tr    repository.TimeRepository
+	tkr   repository.TaskRepository
+	audit AuditUsecase
+}
+
+func (c *calendarUsecase) Issue(ctx context.Context, email string) (string, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	feed, token, err := model.NewCalendarFeed(acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "generate calendar feed token failed", err)
+		return "", err
+	}
+
+	if err := c.cfr.Save(ctx, feed); err != nil {
+		logger.Event(ctx, logger.ERROR, "save calendar feed failed", err)
+		return "", err
+	}
+
+	c.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionIssueCalendarFeed,
+		TargetType: model.AuditTargetCalendarFeed,
+		TargetID:   acc.ID.String(),
+	})
+
+	return token, nil
+}
+
+func (c *calendarUsecase) Revoke(ctx context.Context, email string) error {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if err := c.cfr.DeleteByAccountID(ctx, acc.ID); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete calendar feed failed", err)
+		return err
+	}
+
+	c.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionRevokeCalendarFeed,
+		TargetType: model.AuditTargetCalendarFeed,
+		TargetID:   acc.ID.String(),
+	})
+
+	return nil
+}
+
+func (c *calendarUsecase) Feed(ctx context.Context, token string) ([]output.CalendarEvent, error) {
+	if token == "" {
+		err := errors.New("calendar feed token is empty")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrNotFound, "カレンダーが見つかりません", err)
+	}
+
+	feed, err := c.cfr.FindByTokenHash(ctx, model.HashCalendarFeedToken(token))
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "calendar feed not found", err)
+			return nil, apperr.NewApplicationError(apperr.ErrNotFound, "カレンダーが見つかりません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find calendar feed failed", err)
+		return nil, err
+	}
+
+	times, err := c.tr.Find(ctx, feed.AccountID, repository.TimeFilter{Limit: calendarFeedLimit})
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find times failed", err)
+		return nil, err
+	}
+
+	tasks, err := c.tkr.FindByAccountID(ctx, feed.AccountID, nil)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find tasks failed", err)
+		return nil, err
+	}
+	titles := make(map[model.TaskID]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+
+	res := make([]output.CalendarEvent, 0, len(times))
+	for _, t := range times {
+		var title string
+		if t.TaskID != nil {
+			title = titles[*t.TaskID]
+		}
+
+		ev := model.NewCalendarEvent(t, title)
+		res = append(res, output.CalendarEvent{
+			ID:        ev.ID.String(),
+			Start:     ev.Start,
+			End:       ev.End,
+			TaskTitle: ev.TaskTitle,
+			Note:      t.Note,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *calendarUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := c.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewCalendarUsecase(ar repository.AccountRepository, cfr repository.CalendarFeedRepository, tr repository.TimeRepository, tkr repository.TaskRepository, audit AuditUsecase) CalendarUsecase {
+	return &calendarUsecase{ar, cfr, tr, tkr, audit}
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type CharacterUsecase interface {
+	Classes(ctx context.Context) []output.CharacterClass
+	Get(ctx context.Context, email string) (output.Character, error)
+	// ChooseClass はクラスが未選択の場合のみ実行できる
+	ChooseClass(ctx context.Context, email string, class string) (output.Character, error)
+	// Skills はスキルツリー全体を自分の習得状況とあわせて返す
+	Skills(ctx context.Context, email string) ([]output.Skill, error)
+	LearnSkill(ctx context.Context, email string, key string) (output.Character, error)
+}
+
+type characterUsecase struct {
+	ar  repository.AccountRepository
+	chr repository.CharacterRepository
+	tx  repository.Transaction
+}
+
+func (c *characterUsecase) Classes(ctx context.Context) []output.CharacterClass {
+	res := make([]output.CharacterClass, 0, len(model.Classes))
+	for _, def := range model.Classes {
+		res = append(res, output.CharacterClass{
+			Class:       string(def.Class),
+			Name:        def.Name,
+			Description: def.Description,
+			Growth:      toStatsOutput(def.Growth),
+		})
+	}
+
+	return res
+}
+
+func (c *characterUsecase) Get(ctx context.Context, email string) (output.Character, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return output.Character{}, err
+	}
+
+	char, err := c.findCharacter(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find character failed", err)
+		return output.Character{}, err
+	}
+
+	return toCharacterOutput(char), nil
+}
+
+func (c *characterUsecase) ChooseClass(ctx context.Context, email string, class string) (output.Character, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return output.Character{}, err
+	}
+
+	if _, err := model.FindClass(model.CharacterClass(class)); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Character{}, apperr.NewApplicationError(apperr.ErrBadRequest, "クラスが正しくありません", err)
+	}
+
+	var char model.Character
+	err = c.tx.Do(ctx, func(ctx context.Context) error {
+		char, err = c.chr.FindByAccountIDForUpdate(ctx, acc.ID)
+		if err != nil {
+			if !errors.Is(err, apperr.ErrDataNotFound) {
+				return err
+			}
+			char = model.NewCharacter(acc.ID)
+		}
+
+		if err := char.ChooseClass(model.CharacterClass(class)); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "クラスは既に選択されています", err)
+		}
+		return c.chr.Save(ctx, char)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.Character{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "choose class failed", err)
+		return output.Character{}, err
+	}
+
+	return toCharacterOutput(char), nil
+}
+
+func (c *characterUsecase) Skills(ctx context.Context, email string) ([]output.Skill, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	char, err := c.findCharacter(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find character failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Skill, 0, len(model.SkillTree))
+	for _, s := range model.SkillTree {
+		res = append(res, output.Skill{
+			Key:         s.Key,
+			Name:        s.Name,
+			Description: s.Description,
+			Cost:        s.Cost,
+			Requires:    append([]string{}, s.Requires...),
+			Class:       string(s.Class),
+			Learned:     char.HasSkill(s.Key),
+			Learnable:   char.CanLearn(s) == nil,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *characterUsecase) LearnSkill(ctx context.Context, email string, key string) (output.Character, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return output.Character{}, err
+	}
+
+	if _, err := model.FindSkill(key); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Character{}, apperr.NewApplicationError(apperr.ErrNotFound, "スキルが見つかりません", err)
+	}
+
+	var char model.Character
+	err = c.tx.Do(ctx, func(ctx context.Context) error {
+		char, err = c.chr.FindByAccountIDForUpdate(ctx, acc.ID)
+		if err != nil {
+			if !errors.Is(err, apperr.ErrDataNotFound) {
+				return err
+			}
+			char = model.NewCharacter(acc.ID)
+		}
+
+		s, err := char.Learn(key)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "スキルを習得できません", err)
+		}
+		return c.chr.AddSkill(ctx, acc.ID, s.Key)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.Character{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "learn skill failed", err)
+		return output.Character{}, err
+	}
+
+	return toCharacterOutput(char), nil
+}
+
+// findCharacter はまだ報酬を得ていないアカウントには初期状態のキャラクターを返す
+func (c *characterUsecase) findCharacter(ctx context.Context, accID model.AccountID) (model.Character, error) {
+	char, err := c.chr.FindByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return model.NewCharacter(accID), nil
+		}
+		return model.Character{}, err
+	}
+	return char, nil
+}
+
+func (c *characterUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := c.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toCharacterOutput(c model.Character) output.Character {
+	return output.Character{
+		Level:        c.Level,
+		Exp:          c.Exp,
+		NextLevelExp: model.RequiredExp(c.Level + 1),
+		Gold:         c.Gold,
+		HP:           c.HP,
+		MaxHP:        c.MaxHP(),
+		KnockedOut:   c.KnockedOut,
+		Class:        string(c.Class),
+		Stats:        toStatsOutput(c.Stats()),
+		SkillPoints:  c.SkillPoints(),
+		Skills:       append([]string{}, c.Skills...),
+	}
+}
+
+func toStatsOutput(s model.Stats) output.Stats {
+	return output.Stats{
+		Strength:  s.Strength,
+		Intellect: s.Intellect,
+		Wisdom:    s.Wisdom,
+	}
+}
+
+func NewCharacterUsecase(ar repository.AccountRepository, chr repository.CharacterRepository, tx repository.Transaction) CharacterUsecase {
+	return &characterUsecase{ar, chr, tx}
+}
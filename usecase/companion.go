@@ -0,0 +1,393 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CompanionReviser は記録の修正を仲間の育成に反映する
+type CompanionReviser interface {
+	// Retrain は記録の修正や削除に合わせて、その記録で育てた仲間の経験値を増減させる。記録の修正と同じトランザクション内で呼び出す
+	Retrain(ctx context.Context, record model.Time) error
+}
+
+type CompanionUsecase interface {
+	CompanionReviser
+	Species(ctx context.Context) []output.CompanionSpecies
+	List(ctx context.Context, email string) ([]output.Companion, error)
+	// Activate は連れて歩く仲間を切り替える。同時に連れて歩けるのは1体のみ
+	Activate(ctx context.Context, email string, id string) (output.Companion, error)
+	Rename(ctx context.Context, email string, id string, in input.RenameCompanion) (output.Companion, error)
+	// Train はFocusSessionCompletedの購読者として、連れて歩いている仲間を育て、
+	// 累計の集中時間が条件に達した種族を仲間にする
+	Train(ctx context.Context, ev model.DomainEvent) error
+	// Hatch はLootDroppedの購読者として、ドロップした卵から仲間を孵す
+	Hatch(ctx context.Context, ev model.DomainEvent) error
+}
+
+type companionUsecase struct {
+	ar  repository.AccountRepository
+	cr  repository.CompanionRepository
+	ctr repository.CompanionTrainingRepository
+	chr repository.CharacterRepository
+	str repository.StatisticsRepository
+	tr  repository.TimeRepository
+	or  repository.OutboxRepository
+	tx  repository.Transaction
+}
+
+func (c *companionUsecase) Species(ctx context.Context) []output.CompanionSpecies {
+	res := make([]output.CompanionSpecies, 0, len(model.CompanionSpeciesList))
+	for _, s := range model.CompanionSpeciesList {
+		stages := make([]output.CompanionStage, 0, len(s.Stages))
+		for _, st := range s.Stages {
+			stages = append(stages, output.CompanionStage{
+				Name:        st.Name,
+				MinExp:      st.MinExp,
+				Description: st.Description,
+			})
+		}
+
+		res = append(res, output.CompanionSpecies{
+			Key:              s.Key,
+			Name:             s.Name,
+			Egg:              s.Egg,
+			MilestoneMinutes: s.MilestoneMinutes,
+			Stages:           stages,
+		})
+	}
+
+	return res
+}
+
+func (c *companionUsecase) List(ctx context.Context, email string) ([]output.Companion, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	companions, err := c.cr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find companions failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Companion, 0, len(companions))
+	for _, comp := range companions {
+		res = append(res, toCompanionOutput(comp))
+	}
+
+	return res, nil
+}
+
+func (c *companionUsecase) Activate(ctx context.Context, email string, id string) (output.Companion, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return output.Companion{}, err
+	}
+
+	comp, err := c.findCompanion(ctx, acc.ID, id)
+	if err != nil {
+		return output.Companion{}, err
+	}
+
+	err = c.tx.Do(ctx, func(ctx context.Context) error {
+		return c.cr.Activate(ctx, acc.ID, comp.ID)
+	})
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "activate companion failed", err)
+		return output.Companion{}, err
+	}
+
+	comp.Active = true
+	return toCompanionOutput(comp), nil
+}
+
+func (c *companionUsecase) Rename(ctx context.Context, email string, id string, in input.RenameCompanion) (output.Companion, error) {
+	acc, err := c.findAccount(ctx, email)
+	if err != nil {
+		return output.Companion{}, err
+	}
+
+	comp, err := c.findCompanion(ctx, acc.ID, id)
+	if err != nil {
+		return output.Companion{}, err
+	}
+
+	if err := comp.Rename(in.Nickname); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Companion{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := c.cr.Update(ctx, comp); err != nil {
+		logger.Event(ctx, logger.ERROR, "update companion failed", err)
+		return output.Companion{}, err
+	}
+
+	return toCompanionOutput(comp), nil
+}
+
+func (c *companionUsecase) Train(ctx context.Context, ev model.DomainEvent) error {
+	completed, ok := ev.(model.FocusSessionCompleted)
+	if !ok {
+		return nil
+	}
+
+	return c.tx.Do(ctx, func(ctx context.Context) error {
+		// 記録の修正と同じく記録、仲間の順にロックし、削除された記録では育てず修正後の集中時間で育てる
+		record, err := c.tr.FindByIDForUpdate(ctx, completed.TimeID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := c.feed(ctx, record); err != nil {
+			return err
+		}
+		return c.reachMilestones(ctx, completed.AccountID)
+	})
+}
+
+// feed は気絶中の集中では育てない
+func (c *companionUsecase) feed(ctx context.Context, record model.Time) error {
+	char, err := c.chr.FindByAccountID(ctx, record.AccountID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		return err
+	}
+	if char.KnockedOut {
+		return nil
+	}
+
+	comp, err := c.cr.FindActiveForUpdate(ctx, record.AccountID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	created, err := c.ctr.Create(ctx, model.NewCompanionTraining(comp.ID, record.ID, record.FocusTime))
+	if err != nil || !created {
+		return err
+	}
+
+	if err := comp.Train(record.FocusTime, time.Now()); err != nil {
+		return err
+	}
+	if err := c.cr.Update(ctx, comp); err != nil {
+		return err
+	}
+
+	return c.or.Save(ctx, comp.PullEvents()...)
+}
+
+func (c *companionUsecase) Retrain(ctx context.Context, record model.Time) error {
+	training, err := c.ctr.FindByTimeID(ctx, record.ID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	comp, err := c.cr.FindByIDForUpdate(ctx, training.CompanionID)
+	if err != nil {
+		return err
+	}
+
+	minutes := record.FocusTime
+	if record.DeletedAt != nil {
+		minutes = 0
+	}
+	if err := comp.Retrain(&training, minutes, time.Now()); err != nil {
+		return err
+	}
+	if err := c.cr.Update(ctx, comp); err != nil {
+		return err
+	}
+
+	if training.Minutes > 0 {
+		err = c.ctr.Update(ctx, training)
+	} else {
+		err = c.ctr.Delete(ctx, training.TimeID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.or.Save(ctx, comp.PullEvents()...)
+}
+
+func (c *companionUsecase) reachMilestones(ctx context.Context, accID model.AccountID) error {
+	owned, err := c.cr.FindByAccountID(ctx, accID)
+	if err != nil {
+		return err
+	}
+
+	var total *float64
+	for _, s := range model.CompanionSpeciesList {
+		if s.MilestoneMinutes == 0 || ownsSpecies(owned, s.Key) {
+			continue
+		}
+
+		if total == nil {
+			minutes, err := c.str.FocusMinutes(ctx, accID, time.Time{}, time.Now().Add(24*time.Hour))
+			if err != nil {
+				return err
+			}
+			total = &minutes
+		}
+		if *total < float64(s.MilestoneMinutes) {
+			continue
+		}
+
+		if owned, err = c.obtain(ctx, accID, s, owned); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *companionUsecase) Hatch(ctx context.Context, ev model.DomainEvent) error {
+	dropped, ok := ev.(model.LootDropped)
+	if !ok {
+		return nil
+	}
+
+	species, ok := model.FindCompanionSpeciesByEgg(dropped.Item)
+	if !ok {
+		return nil
+	}
+
+	return c.tx.Do(ctx, func(ctx context.Context) error {
+		owned, err := c.cr.FindByAccountID(ctx, dropped.AccountID)
+		if err != nil {
+			return err
+		}
+		// 同じ種族の卵が再びドロップした場合はアイテムとして持っておく
+		if ownsSpecies(owned, species.Key) {
+			return nil
+		}
+
+		_, err = c.obtain(ctx, dropped.AccountID, species, owned)
+		return err
+	})
+}
+
+// obtain は連れて歩いている仲間がいなければそのまま連れて歩き、仲間に加えたownedを返す
+func (c *companionUsecase) obtain(ctx context.Context, accID model.AccountID, species model.CompanionSpecies, owned []model.Companion) ([]model.Companion, error) {
+	comp := model.NewCompanion(model.GenerateCompanionID(), accID, species, time.Now())
+	created, err := c.cr.Create(ctx, comp)
+	if err != nil || !created {
+		return owned, err
+	}
+
+	if !hasActiveCompanion(owned) {
+		if err := c.cr.Activate(ctx, accID, comp.ID); err != nil {
+			return nil, err
+		}
+		comp.Active = true
+	}
+
+	if err := c.or.Save(ctx, comp.PullEvents()...); err != nil {
+		return nil, err
+	}
+	return append(owned, comp), nil
+}
+
+func ownsSpecies(owned []model.Companion, species string) bool {
+	for _, comp := range owned {
+		if comp.Species == species {
+			return true
+		}
+	}
+	return false
+}
+
+func hasActiveCompanion(owned []model.Companion) bool {
+	for _, comp := range owned {
+		if comp.Active {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *companionUsecase) findCompanion(ctx context.Context, accID model.AccountID, id string) (model.Companion, error) {
+	companionID, err := model.NewCompanionID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.Companion{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	comp, err := c.cr.FindByID(ctx, companionID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.ERROR, "find companion failed", err)
+		return model.Companion{}, err
+	}
+
+	if err != nil || comp.AccountID != accID {
+		err := errors.Newf("companion not found: %s", id)
+		logger.Event(ctx, logger.INFO, "companion not found", err)
+		return model.Companion{}, apperr.NewApplicationError(apperr.ErrNotFound, "仲間が見つかりません", err)
+	}
+
+	return comp, nil
+}
+
+func (c *companionUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := c.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toCompanionOutput(c model.Companion) output.Companion {
+	res := output.Companion{
+		ID:         c.ID.String(),
+		Species:    c.Species,
+		Nickname:   c.Nickname,
+		Exp:        c.Exp,
+		Stage:      c.Stage,
+		Active:     c.Active,
+		ObtainedAt: c.ObtainedAt,
+	}
+
+	species, err := model.FindCompanionSpecies(c.Species)
+	if err != nil {
+		return res
+	}
+	res.SpeciesName = species.Name
+	if c.Stage < len(species.Stages) {
+		res.StageName = species.Stages[c.Stage].Name
+		res.Bonus = species.Stages[c.Stage].Description
+	}
+	if c.Stage+1 < len(species.Stages) {
+		next := species.Stages[c.Stage+1].MinExp
+		res.NextStageExp = &next
+	}
+
+	return res
+}
+
+func NewCompanionUsecase(ar repository.AccountRepository, cr repository.CompanionRepository, ctr repository.CompanionTrainingRepository, chr repository.CharacterRepository, str repository.StatisticsRepository, tr repository.TimeRepository, or repository.OutboxRepository, tx repository.Transaction) CompanionUsecase {
+	return &companionUsecase{ar, cr, ctr, chr, str, tr, or, tx}
+}
@@ -0,0 +1,609 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// maxFocusRoomCodeAttempts 回続けてコードが重複した場合は作成に失敗したものとする
+const maxFocusRoomCodeAttempts = 5
+
+// FocusRoomSubscriber は部屋ごとに状態の変更を購読する
+type FocusRoomSubscriber interface {
+	// Subscribe は変更を知らせるチャネルと購読を解除する関数を返す。変更の内容は購読者が読み直す
+	Subscribe(roomID model.FocusRoomID) (<-chan struct{}, func())
+}
+
+type FocusRoomUsecase interface {
+	// Create はplannedSecondsが0の場合は集中の設定時間で部屋を作成し、作成者をホストとして参加させる。
+	// 完了の報酬を稼ぐための短い部屋は作れず、部屋の時間はドロップを抽選する集中の最低限の長さ以上とする。
+	// 同じ時間を重ねて記録しないよう、他の部屋に参加中かタイマーの実行中は作成できない
+	Create(ctx context.Context, email string, plannedSeconds int) (output.FocusRoom, error)
+	// Join は開始前の部屋にコードで参加する。Createと同じく他の部屋やタイマーと同時には参加できず、
+	// 部屋の時間が自分の集中の設定時間を超える場合も参加できない
+	Join(ctx context.Context, email string, code string) (output.FocusRoom, error)
+	Get(ctx context.Context, email string, id string) (output.FocusRoom, error)
+	// Start, Pause, Resume はホストのみ実行できる
+	Start(ctx context.Context, email string, id string) (output.FocusRoom, error)
+	Pause(ctx context.Context, email string, id string) (output.FocusRoom, error)
+	Resume(ctx context.Context, email string, id string) (output.FocusRoom, error)
+	// Complete はタイマーを最後まで終えた参加者の集中時間を記録し、完了ボーナスを付与する
+	Complete(ctx context.Context, email string, id string) (output.FocusRoom, error)
+	// Leave はホストが抜けた場合は他の参加者に引き継ぎ、誰もいなくなれば部屋を閉じる。
+	// タイマーの実行中に最後まで終えずに抜けた場合は集中を中断したものとしてダメージを受ける
+	Leave(ctx context.Context, email string, id string) error
+	// Subscribe は現在の状態と、以降の状態変更を参加している間ctxが終了するまで返す
+	Subscribe(ctx context.Context, email string, id string) (<-chan output.FocusRoom, error)
+}
+
+type focusRoomUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	frr      repository.FocusRoomRepository
+	fpr      repository.FocusRoomParticipantRepository
+	tsr      repository.TimerSessionRepository
+	tx       repository.Transaction
+	recorder SessionRecorder
+	rewarder Rewarder
+	hub      FocusRoomSubscriber
+}
+
+func (f *focusRoomUsecase) Create(ctx context.Context, email string, plannedSeconds int) (output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	settings, err := findSettings(ctx, f.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.FocusRoom{}, err
+	}
+
+	if plannedSeconds == 0 {
+		plannedSeconds = settings.Minutes(model.SessionFocus) * 60
+	}
+	if err := settings.ValidateSessionMinutes(model.SessionFocus, float64(plannedSeconds)/60); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.FocusRoom{}, apperr.NewApplicationError(apperr.ErrBadRequest, "設定した時間を超えています", err)
+	}
+
+	now := time.Now()
+	var room model.FocusRoom
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		if err := f.vacate(ctx, acc.ID, now); err != nil {
+			return err
+		}
+
+		for range maxFocusRoomCodeAttempts {
+			room, err = model.NewFocusRoom(model.GenerateFocusRoomID(), acc.ID, plannedSeconds, now)
+			if err != nil {
+				logger.Event(ctx, logger.INFO, err.Error(), err)
+				return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+			}
+
+			created, err := f.frr.Create(ctx, room)
+			if err != nil {
+				return err
+			}
+			if created {
+				return f.seat(ctx, room.Host())
+			}
+		}
+
+		return errors.Newf("focus room code collided %d times", maxFocusRoomCodeAttempts)
+	})
+	if err != nil {
+		return output.FocusRoom{}, f.handleTxError(ctx, err)
+	}
+
+	return f.detail(ctx, room, now)
+}
+
+func (f *focusRoomUsecase) Join(ctx context.Context, email string, code string) (output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	settings, err := findSettings(ctx, f.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.FocusRoom{}, err
+	}
+
+	now := time.Now()
+	var room model.FocusRoom
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		// 定員を超えないよう、同じ部屋への参加を直列化する
+		room, err = f.frr.FindByCodeForUpdate(ctx, model.NormalizeFocusRoomCode(code))
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				logger.Event(ctx, logger.INFO, "focus room not found", err)
+				return apperr.NewApplicationError(apperr.ErrNotFound, "部屋が見つかりません", err)
+			}
+			return err
+		}
+
+		// 参加済みであればそのまま部屋の状態を返す
+		_, err := f.fpr.Find(ctx, room.ID, acc.ID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		// 他の人の長い部屋に参加して、設定より長い集中を記録できないようにする
+		if err := settings.ValidateSessionMinutes(model.SessionFocus, float64(room.PlannedSeconds)/60); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "部屋の時間が設定した集中時間を超えています", err)
+		}
+
+		if err := f.vacate(ctx, acc.ID, now); err != nil {
+			return err
+		}
+
+		participants, err := f.fpr.Count(ctx, room.ID)
+		if err != nil {
+			return err
+		}
+
+		p, err := room.Join(acc.ID, participants, now)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "この部屋には参加できません", err)
+		}
+
+		if err := f.seat(ctx, p); err != nil {
+			return err
+		}
+		return f.frr.Notify(ctx, room.ID)
+	})
+	if err != nil {
+		return output.FocusRoom{}, f.handleTxError(ctx, err)
+	}
+
+	return f.detail(ctx, room, now)
+}
+
+func (f *focusRoomUsecase) Get(ctx context.Context, email string, id string) (output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	room, err := f.findRoom(ctx, id, acc.ID)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	return f.detail(ctx, room, time.Now())
+}
+
+func (f *focusRoomUsecase) Start(ctx context.Context, email string, id string) (output.FocusRoom, error) {
+	return f.operate(ctx, email, id, func(room *model.FocusRoom, accID model.AccountID, now time.Time) error {
+		return room.Start(accID, now)
+	})
+}
+
+func (f *focusRoomUsecase) Pause(ctx context.Context, email string, id string) (output.FocusRoom, error) {
+	return f.operate(ctx, email, id, func(room *model.FocusRoom, accID model.AccountID, now time.Time) error {
+		return room.Pause(accID, now)
+	})
+}
+
+func (f *focusRoomUsecase) Resume(ctx context.Context, email string, id string) (output.FocusRoom, error) {
+	return f.operate(ctx, email, id, func(room *model.FocusRoom, accID model.AccountID, now time.Time) error {
+		return room.Resume(accID, now)
+	})
+}
+
+// operate はホストとして部屋のタイマーをfnで操作して保存し、参加者に通知する
+func (f *focusRoomUsecase) operate(ctx context.Context, email string, id string, fn func(room *model.FocusRoom, accID model.AccountID, now time.Time) error) (output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	now := time.Now()
+	var room model.FocusRoom
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		room, _, err = f.lockRoom(ctx, id, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		if room.HostID != acc.ID {
+			err := errors.New("only the host can operate the timer")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "部屋のホストのみ操作できます", err)
+		}
+
+		if err := fn(&room, acc.ID, now); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "タイマーの状態を変更できません", err)
+		}
+
+		if err := f.frr.Update(ctx, room); err != nil {
+			return err
+		}
+		return f.frr.Notify(ctx, room.ID)
+	})
+	if err != nil {
+		return output.FocusRoom{}, f.handleTxError(ctx, err)
+	}
+
+	return f.detail(ctx, room, now)
+}
+
+func (f *focusRoomUsecase) Complete(ctx context.Context, email string, id string) (output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FocusRoom{}, err
+	}
+
+	now := time.Now()
+	var room model.FocusRoom
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		var p model.FocusRoomParticipant
+		room, p, err = f.lockRoom(ctx, id, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		minutes, err := room.Complete(&p, now)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "部屋のタイマーを完了できません", err)
+		}
+
+		completed, err := f.fpr.Complete(ctx, room.ID, acc.ID, *p.CompletedAt)
+		if err != nil {
+			return err
+		}
+		if !completed {
+			err := errors.New("participant has already completed")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "部屋のタイマーを完了できません", err)
+		}
+
+		record, err := model.NewTime(model.GenerateTimeID(), minutes, acc.ID, nil)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "部屋のタイマーを完了できません", err)
+		}
+		if err := f.recorder.RecordFocus(ctx, record); err != nil {
+			return err
+		}
+
+		participants, err := f.fpr.Count(ctx, room.ID)
+		if err != nil {
+			return err
+		}
+		if err := f.rewarder.Grant(ctx, acc.ID, model.NewFocusRoomBonus(participants), model.NewRewardContext(model.RewardSourceFocusRoom)); err != nil {
+			return err
+		}
+
+		return f.frr.Notify(ctx, room.ID)
+	})
+	if err != nil {
+		return output.FocusRoom{}, f.handleTxError(ctx, err)
+	}
+
+	return f.detail(ctx, room, now)
+}
+
+func (f *focusRoomUsecase) Leave(ctx context.Context, email string, id string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		room, me, err := f.lockRoom(ctx, id, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if room.Abandons(me, now) {
+			if err := f.rewarder.Damage(ctx, acc.ID, model.AbandonDamage()); err != nil {
+				return err
+			}
+		}
+
+		if err := f.fpr.Delete(ctx, room.ID, acc.ID); err != nil {
+			return err
+		}
+
+		remaining, err := f.fpr.FindByRoomID(ctx, room.ID)
+		if err != nil {
+			return err
+		}
+
+		room.Leave(acc.ID, remaining, now)
+		if err := f.frr.Update(ctx, room); err != nil {
+			return err
+		}
+		return f.frr.Notify(ctx, room.ID)
+	})
+	if err != nil {
+		return f.handleTxError(ctx, err)
+	}
+
+	return nil
+}
+
+func (f *focusRoomUsecase) Subscribe(ctx context.Context, email string, id string) (<-chan output.FocusRoom, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	roomID, err := model.NewFocusRoomID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrNotFound, "部屋が見つかりません", err)
+	}
+
+	// 購読開始から現在の状態の取得までの間の変更を取りこぼさないよう、先に購読する
+	changes, unsubscribe := f.hub.Subscribe(roomID)
+
+	room, err := f.findRoom(ctx, id, acc.ID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	current, err := f.detail(ctx, room, time.Now())
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	res := make(chan output.FocusRoom, 1)
+	res <- current
+
+	go func() {
+		defer close(res)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changes:
+				// 抜けた場合や閉じられた場合は配信を終える
+				room, err := f.findRoom(ctx, id, acc.ID)
+				if err != nil {
+					return
+				}
+
+				current, err := f.detail(ctx, room, time.Now())
+				if err != nil {
+					return
+				}
+
+				select {
+				case res <- current:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+// vacate は同じ時間を重ねて記録しないよう、まだ終わっていない他の部屋に参加中かタイマーの実行中であれば断る。
+// 予定時間を過ぎたか閉じられた部屋を最後まで終えずに残している場合は、その参加を外す
+func (f *focusRoomUsecase) vacate(ctx context.Context, accID model.AccountID, now time.Time) error {
+	p, ongoing, err := findUncompletedFocusRoom(ctx, f.frr, f.fpr, accID, now)
+	if err != nil {
+		return err
+	}
+	if ongoing {
+		err := errors.Newf("already in focus room: %s", p.RoomID)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrConflict, "他の部屋に参加しています", err)
+	}
+	if p != nil {
+		if err := f.leaveFinished(ctx, *p, now); err != nil {
+			return err
+		}
+	}
+
+	_, err = f.tsr.FindActiveByAccountID(ctx, accID)
+	if err == nil {
+		err := errors.New("timer is active")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrConflict, "タイマーの実行中は部屋に参加できません", err)
+	}
+	if !errors.Is(err, apperr.ErrDataNotFound) {
+		return err
+	}
+	return nil
+}
+
+// leaveFinished は終わった部屋からLeaveと同じく抜ける。ダメージは与えない
+func (f *focusRoomUsecase) leaveFinished(ctx context.Context, p model.FocusRoomParticipant, now time.Time) error {
+	room, err := f.frr.FindByIDForUpdate(ctx, p.RoomID)
+	if err != nil {
+		return err
+	}
+
+	if err := f.fpr.Delete(ctx, room.ID, p.AccountID); err != nil {
+		return err
+	}
+
+	remaining, err := f.fpr.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+
+	room.Leave(p.AccountID, remaining, now)
+	if err := f.frr.Update(ctx, room); err != nil {
+		return err
+	}
+	return f.frr.Notify(ctx, room.ID)
+}
+
+// seat は同時に他の部屋への参加が作られた場合に断る
+func (f *focusRoomUsecase) seat(ctx context.Context, p model.FocusRoomParticipant) error {
+	created, err := f.fpr.Create(ctx, p)
+	if err != nil {
+		return err
+	}
+	if !created {
+		err := errors.New("already in another focus room")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrConflict, "他の部屋に参加しています", err)
+	}
+	return nil
+}
+
+// findUncompletedFocusRoom は最後まで終えていない参加と、その部屋がまだ終わっていないかを返す。
+// 参加していなければnilを返す
+func findUncompletedFocusRoom(ctx context.Context, frr repository.FocusRoomRepository, fpr repository.FocusRoomParticipantRepository, accID model.AccountID, now time.Time) (*model.FocusRoomParticipant, bool, error) {
+	p, err := fpr.FindUncompletedByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	room, err := frr.FindByID(ctx, p.RoomID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch room.StatusAt(now) {
+	case model.FocusRoomFinished, model.FocusRoomClosed:
+		return &p, false, nil
+	}
+	return &p, true, nil
+}
+
+// findRoom は参加している部屋のみを返し、参加していない部屋は存在しないものとして扱う
+func (f *focusRoomUsecase) findRoom(ctx context.Context, id string, accID model.AccountID) (model.FocusRoom, error) {
+	roomID, err := model.NewFocusRoomID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.FocusRoom{}, apperr.NewApplicationError(apperr.ErrNotFound, "部屋が見つかりません", err)
+	}
+
+	if _, err := f.fpr.Find(ctx, roomID, accID); err != nil {
+		return model.FocusRoom{}, f.notFound(ctx, err)
+	}
+
+	room, err := f.frr.FindByID(ctx, roomID)
+	if err != nil {
+		return model.FocusRoom{}, f.notFound(ctx, err)
+	}
+
+	return room, nil
+}
+
+// lockRoom はトランザクション内で参加している部屋をロックし、部屋と参加者を返す
+func (f *focusRoomUsecase) lockRoom(ctx context.Context, id string, accID model.AccountID) (model.FocusRoom, model.FocusRoomParticipant, error) {
+	roomID, err := model.NewFocusRoomID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.FocusRoom{}, model.FocusRoomParticipant{}, apperr.NewApplicationError(apperr.ErrNotFound, "部屋が見つかりません", err)
+	}
+
+	room, err := f.frr.FindByIDForUpdate(ctx, roomID)
+	if err != nil {
+		return model.FocusRoom{}, model.FocusRoomParticipant{}, f.notFound(ctx, err)
+	}
+
+	p, err := f.fpr.Find(ctx, roomID, accID)
+	if err != nil {
+		return model.FocusRoom{}, model.FocusRoomParticipant{}, f.notFound(ctx, err)
+	}
+
+	return room, p, nil
+}
+
+func (f *focusRoomUsecase) notFound(ctx context.Context, err error) error {
+	if errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.INFO, "focus room not found", err)
+		return apperr.NewApplicationError(apperr.ErrNotFound, "部屋が見つかりません", err)
+	}
+	logger.Event(ctx, logger.ERROR, "find focus room failed", err)
+	return err
+}
+
+func (f *focusRoomUsecase) detail(ctx context.Context, room model.FocusRoom, now time.Time) (output.FocusRoom, error) {
+	participants, err := f.fpr.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find focus room participants failed", err)
+		return output.FocusRoom{}, err
+	}
+
+	res := output.FocusRoom{
+		ID:               room.ID.String(),
+		Code:             room.Code,
+		HostID:           room.HostID.String(),
+		Status:           string(room.StatusAt(now)),
+		PlannedSeconds:   room.PlannedSeconds,
+		ElapsedSeconds:   int(room.Elapsed(now).Seconds()),
+		RemainingSeconds: int(room.Remaining(now).Seconds()),
+		StartedAt:        room.StartedAt,
+		Participants:     make([]output.FocusRoomParticipant, 0, len(participants)),
+		ServerTime:       now,
+	}
+	for _, p := range participants {
+		acc, err := f.ar.FindByID(ctx, p.AccountID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find focus room participant account failed", err)
+			return output.FocusRoom{}, err
+		}
+
+		res.Participants = append(res.Participants, output.FocusRoomParticipant{
+			AccountID:   acc.ID.String(),
+			Name:        acc.Name,
+			Image:       acc.Image,
+			JoinedAt:    p.JoinedAt,
+			CompletedAt: p.CompletedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (f *focusRoomUsecase) handleTxError(ctx context.Context, err error) error {
+	if appErr, ok := err.(*apperr.ApplicationError); ok {
+		return appErr
+	}
+	logger.Event(ctx, logger.ERROR, "focus room update failed", err)
+	return err
+}
+
+func (f *focusRoomUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := f.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewFocusRoomUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, frr repository.FocusRoomRepository, fpr repository.FocusRoomParticipantRepository, tsr repository.TimerSessionRepository, tx repository.Transaction, recorder SessionRecorder, rewarder Rewarder, hub FocusRoomSubscriber) FocusRoomUsecase {
+	return &focusRoomUsecase{ar, sr, frr, fpr, tsr, tx, recorder, rewarder, hub}
+}
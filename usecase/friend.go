@@ -0,0 +1,584 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// streakLookbackDays を超える連続日数は数えない
+const streakLookbackDays = 366
+
+type FriendUsecase interface {
+	// SendRequest は宛先のアカウントの有無やブロックの有無に関わらず同じ結果を返す。
+	// 未登録のメールアドレスへの申請は、そのアドレスでアカウントが登録されたときに届く
+	SendRequest(ctx context.Context, email string, addresseeEmail string) error
+	// Link はAccountCreatedの購読者として、登録前に届いていた申請を新しいアカウントで受け取れるようにする
+	Link(ctx context.Context, ev model.DomainEvent) error
+	Requests(ctx context.Context, email string) (output.FriendRequests, error)
+	Accept(ctx context.Context, email string, id string) error
+	Decline(ctx context.Context, email string, id string) error
+	// Cancel は自分が送った申請を取り消す
+	Cancel(ctx context.Context, email string, id string) error
+	// List はフレンドのプライバシー設定で公開されている情報のみを返す
+	List(ctx context.Context, email string) ([]output.Friend, error)
+	Remove(ctx context.Context, email string, friendID string) error
+	Block(ctx context.Context, email string, accountID string) error
+	Unblock(ctx context.Context, email string, accountID string) error
+	Blocks(ctx context.Context, email string) ([]output.BlockedAccount, error)
+	GetPrivacy(ctx context.Context, email string) (output.Privacy, error)
+	UpdatePrivacy(ctx context.Context, email string, in input.Privacy) (output.Privacy, error)
+}
+
+type friendUsecase struct {
+	ar  repository.AccountRepository
+	sr  repository.SettingsRepository
+	chr repository.CharacterRepository
+	frr repository.FriendRequestRepository
+	fr  repository.FriendshipRepository
+	blr repository.BlockRepository
+	pr  repository.PrivacyRepository
+	str repository.StatisticsRepository
+	tx  repository.Transaction
+}
+
+func (f *friendUsecase) SendRequest(ctx context.Context, email string, addresseeEmail string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if _, err := model.NewFriendRequest(model.GenerateFriendRequestID(), acc, addresseeEmail, nil); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		var addresseeID *model.AccountID
+		addressee, err := f.ar.FindByEmail(ctx, addresseeEmail)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+		if err == nil {
+			blocked, err := f.blr.ExistsBetween(ctx, acc.ID, addressee.ID)
+			if err != nil {
+				return err
+			}
+			// ブロックしている・されている相手には申請を届けない
+			if !blocked {
+				friends, err := f.fr.Exists(ctx, acc.ID, addressee.ID)
+				if err != nil || friends {
+					return err
+				}
+
+				// 相手から既に申請が届いていれば、承認したものとして扱う
+				reverse, err := f.frr.FindPendingBetween(ctx, addressee.ID, acc.ID)
+				if err == nil {
+					return f.accept(ctx, acc.ID, reverse)
+				}
+				if !errors.Is(err, apperr.ErrDataNotFound) {
+					return err
+				}
+
+				addresseeID = &addressee.ID
+			}
+		}
+
+		req, err := model.NewFriendRequest(model.GenerateFriendRequestID(), acc, addresseeEmail, addresseeID)
+		if err != nil {
+			return err
+		}
+		return f.frr.Create(ctx, req)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "send friend request failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) Link(ctx context.Context, ev model.DomainEvent) error {
+	created, ok := ev.(model.AccountCreated)
+	if !ok {
+		return nil
+	}
+
+	return f.frr.LinkAddressee(ctx, created.Email, created.AccountID)
+}
+
+func (f *friendUsecase) Requests(ctx context.Context, email string) (output.FriendRequests, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.FriendRequests{}, err
+	}
+
+	incoming, err := f.frr.FindPendingByAddressee(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find incoming friend requests failed", err)
+		return output.FriendRequests{}, err
+	}
+
+	outgoing, err := f.frr.FindByRequester(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find outgoing friend requests failed", err)
+		return output.FriendRequests{}, err
+	}
+
+	res := output.FriendRequests{
+		Incoming: make([]output.IncomingFriendRequest, 0, len(incoming)),
+		Outgoing: make([]output.OutgoingFriendRequest, 0, len(outgoing)),
+	}
+	for _, r := range incoming {
+		requester, err := f.ar.FindByID(ctx, r.RequesterID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find requester failed", err)
+			return output.FriendRequests{}, err
+		}
+
+		res.Incoming = append(res.Incoming, output.IncomingFriendRequest{
+			ID:          r.ID.String(),
+			RequesterID: requester.ID.String(),
+			Name:        requester.Name,
+			Image:       requester.Image,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+	for _, r := range outgoing {
+		// 断られた申請も保留中として見せる
+		res.Outgoing = append(res.Outgoing, output.OutgoingFriendRequest{
+			ID:        r.ID.String(),
+			Email:     r.AddresseeEmail,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (f *friendUsecase) Accept(ctx context.Context, email string, id string) error {
+	acc, req, err := f.findIncomingRequest(ctx, email, id)
+	if err != nil {
+		return err
+	}
+
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		return f.accept(ctx, acc.ID, req)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "accept friend request failed", err)
+		return err
+	}
+
+	return nil
+}
+
+// accept はトランザクション内で呼び出すこと
+func (f *friendUsecase) accept(ctx context.Context, accID model.AccountID, req model.FriendRequest) error {
+	count, err := f.fr.Count(ctx, accID)
+	if err != nil {
+		return err
+	}
+	requesterCount, err := f.fr.Count(ctx, req.RequesterID)
+	if err != nil {
+		return err
+	}
+
+	friendship, err := req.Accept(accID, max(count, requesterCount))
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "フレンドの上限に達しています", err)
+	}
+
+	if err := f.frr.DeleteBetween(ctx, req.RequesterID, accID); err != nil {
+		return err
+	}
+	return f.fr.Create(ctx, friendship)
+}
+
+func (f *friendUsecase) Decline(ctx context.Context, email string, id string) error {
+	acc, req, err := f.findIncomingRequest(ctx, email, id)
+	if err != nil {
+		return err
+	}
+
+	if err := req.Decline(acc.ID); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrNotFound, "フレンド申請が見つかりません", err)
+	}
+
+	if err := f.frr.Update(ctx, req); err != nil {
+		logger.Event(ctx, logger.ERROR, "decline friend request failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) Cancel(ctx context.Context, email string, id string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	req, err := f.findRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+	if req.RequesterID != acc.ID {
+		err := errors.New("friend request is not sent by the account")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrNotFound, "フレンド申請が見つかりません", err)
+	}
+
+	if err := f.frr.Delete(ctx, req.ID); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete friend request failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) List(ctx context.Context, email string) ([]output.Friend, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	friendships, err := f.fr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find friends failed", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]output.Friend, 0, len(friendships))
+	for _, fs := range friendships {
+		friend, err := f.profile(ctx, fs.FriendID, now)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "build friend profile failed", err)
+			return nil, err
+		}
+		friend.Since = fs.CreatedAt
+		res = append(res, friend)
+	}
+
+	return res, nil
+}
+
+// profile はフレンドのプライバシー設定に従い、公開されていない項目をnilにする
+func (f *friendUsecase) profile(ctx context.Context, friendID model.AccountID, now time.Time) (output.Friend, error) {
+	friend, err := f.ar.FindByID(ctx, friendID)
+	if err != nil {
+		return output.Friend{}, err
+	}
+
+	privacy, err := findPrivacy(ctx, f.pr, friendID)
+	if err != nil {
+		return output.Friend{}, err
+	}
+
+	res := output.Friend{
+		AccountID: friend.ID.String(),
+		Name:      friend.Name,
+		Image:     friend.Image,
+	}
+
+	if privacy.ShareLevel {
+		level := model.NewCharacter(friendID).Level
+		c, err := f.chr.FindByAccountID(ctx, friendID)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return output.Friend{}, err
+		}
+		if err == nil {
+			level = c.Level
+		}
+		res.Level = &level
+	}
+
+	if !privacy.ShareStreak && !privacy.ShareWeeklyFocus {
+		return res, nil
+	}
+
+	settings, err := findSettings(ctx, f.sr, friendID)
+	if err != nil {
+		return output.Friend{}, err
+	}
+	loc := settings.Location()
+	today, tomorrow := model.GoalDaily.Range(now, loc)
+
+	if privacy.ShareStreak {
+		streak, err := findStreak(ctx, f.str, friendID, today, tomorrow, loc)
+		if err != nil {
+			return output.Friend{}, err
+		}
+		res.Streak = &streak
+	}
+
+	if privacy.ShareWeeklyFocus {
+		start, end := model.GoalWeekly.Range(now, loc)
+		minutes, err := f.str.FocusMinutes(ctx, friendID, start, end)
+		if err != nil {
+			return output.Friend{}, err
+		}
+		res.WeeklyFocusMinutes = &minutes
+	}
+
+	return res, nil
+}
+
+func (f *friendUsecase) Remove(ctx context.Context, email string, friendID string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	id, err := model.NewAccountID(friendID)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := f.fr.Delete(ctx, acc.ID, id); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete friendship failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) Block(ctx context.Context, email string, accountID string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	target, err := f.findTarget(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	block, err := model.NewBlock(acc.ID, target.ID)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	// ブロックした相手とはフレンドを解除し、保留中の申請も取り消す
+	err = f.tx.Do(ctx, func(ctx context.Context) error {
+		if err := f.blr.Create(ctx, block); err != nil {
+			return err
+		}
+		if err := f.fr.Delete(ctx, acc.ID, target.ID); err != nil {
+			return err
+		}
+		return f.frr.DeleteBetween(ctx, acc.ID, target.ID)
+	})
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "block account failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) Unblock(ctx context.Context, email string, accountID string) error {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	id, err := model.NewAccountID(accountID)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := f.blr.Delete(ctx, acc.ID, id); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete block failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *friendUsecase) Blocks(ctx context.Context, email string) ([]output.BlockedAccount, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := f.blr.FindByBlockerID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find blocks failed", err)
+		return nil, err
+	}
+
+	res := make([]output.BlockedAccount, 0, len(blocks))
+	for _, b := range blocks {
+		blocked, err := f.ar.FindByID(ctx, b.BlockedID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find blocked account failed", err)
+			return nil, err
+		}
+
+		res = append(res, output.BlockedAccount{
+			AccountID: blocked.ID.String(),
+			Name:      blocked.Name,
+			Image:     blocked.Image,
+			BlockedAt: b.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (f *friendUsecase) GetPrivacy(ctx context.Context, email string) (output.Privacy, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.Privacy{}, err
+	}
+
+	privacy, err := findPrivacy(ctx, f.pr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find privacy failed", err)
+		return output.Privacy{}, err
+	}
+
+	return toPrivacyOutput(privacy), nil
+}
+
+func (f *friendUsecase) UpdatePrivacy(ctx context.Context, email string, in input.Privacy) (output.Privacy, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return output.Privacy{}, err
+	}
+
+	privacy := model.NewPrivacy(acc.ID, in.ShareLevel, in.ShareStreak, in.ShareWeeklyFocus)
+	if err := f.pr.Save(ctx, privacy); err != nil {
+		logger.Event(ctx, logger.ERROR, "save privacy failed", err)
+		return output.Privacy{}, err
+	}
+
+	return toPrivacyOutput(privacy), nil
+}
+
+func (f *friendUsecase) findIncomingRequest(ctx context.Context, email string, id string) (model.Account, model.FriendRequest, error) {
+	acc, err := f.findAccount(ctx, email)
+	if err != nil {
+		return model.Account{}, model.FriendRequest{}, err
+	}
+
+	req, err := f.findRequest(ctx, id)
+	if err != nil {
+		return model.Account{}, model.FriendRequest{}, err
+	}
+	// 自分宛てでない申請は存在しないものとして扱う
+	if !req.IsAddressedTo(acc.ID) {
+		err := errors.New("friend request is not addressed to the account")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.Account{}, model.FriendRequest{}, apperr.NewApplicationError(apperr.ErrNotFound, "フレンド申請が見つかりません", err)
+	}
+
+	return acc, req, nil
+}
+
+func (f *friendUsecase) findRequest(ctx context.Context, id string) (model.FriendRequest, error) {
+	reqID, err := model.NewFriendRequestID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.FriendRequest{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	req, err := f.frr.FindByID(ctx, reqID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "friend request not found", err)
+			return model.FriendRequest{}, apperr.NewApplicationError(apperr.ErrNotFound, "フレンド申請が見つかりません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find friend request failed", err)
+		return model.FriendRequest{}, err
+	}
+
+	return req, nil
+}
+
+func (f *friendUsecase) findTarget(ctx context.Context, accountID string) (model.Account, error) {
+	id, err := model.NewAccountID(accountID)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	target, err := f.ar.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "target account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrNotFound, "アカウントが見つかりません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find target account failed", err)
+		return model.Account{}, err
+	}
+
+	return target, nil
+}
+
+func (f *friendUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := f.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// findPrivacy は設定が保存されていない場合に既定値を返す
+// findStreak はtodayまで連続して集中した日数を返す。today, tomorrowはloc基準の日付の範囲
+func findStreak(ctx context.Context, str repository.StatisticsRepository, accID model.AccountID, today, tomorrow time.Time, loc *time.Location) (int, error) {
+	stats, err := str.DailyFocus(ctx, accID, today.AddDate(0, 0, -streakLookbackDays), tomorrow, loc)
+	if err != nil {
+		return 0, err
+	}
+	return model.Streak(stats, today), nil
+}
+
+func findPrivacy(ctx context.Context, pr repository.PrivacyRepository, accID model.AccountID) (model.Privacy, error) {
+	privacy, err := pr.FindByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return model.DefaultPrivacy(accID), nil
+		}
+		return model.Privacy{}, err
+	}
+	return privacy, nil
+}
+
+func toPrivacyOutput(p model.Privacy) output.Privacy {
+	return output.Privacy{
+		ShareLevel:       p.ShareLevel,
+		ShareStreak:      p.ShareStreak,
+		ShareWeeklyFocus: p.ShareWeeklyFocus,
+	}
+}
+
+func NewFriendUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, chr repository.CharacterRepository, frr repository.FriendRequestRepository, fr repository.FriendshipRepository, blr repository.BlockRepository, pr repository.PrivacyRepository, str repository.StatisticsRepository, tx repository.Transaction) FriendUsecase {
+	return &friendUsecase{ar, sr, chr, frr, fr, blr, pr, str, tx}
+}
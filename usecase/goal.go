@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const maxGoalHistoryLimit = 200
+
+// GoalAssessor は記録の修正に合わせて目標の達成を判定し直す
+type GoalAssessor interface {
+	// Reassess はatを含む期間の目標を判定し直し、集中時間が目標を下回った場合は達成記録と報酬を取り消す。
+	// 記録の修正と同じトランザクション内で呼び出す
+	Reassess(ctx context.Context, accID model.AccountID, at time.Time) error
+}
+
+type GoalUsecase interface {
+	GoalAssessor
+	// List は設定済みの目標について、現在の期間の進捗を返す
+	List(ctx context.Context, email string) ([]output.GoalStatus, error)
+	Set(ctx context.Context, email string, period string, targetMinutes int) error
+	Delete(ctx context.Context, email string, period string) error
+	History(ctx context.Context, email string, in input.GoalHistory) ([]output.GoalAchievement, error)
+	// Evaluate は集中時間が記録された期間の目標を判定し、初めて達成した場合に報酬を付与する。
+	// FocusSessionCompletedの購読者として登録する
+	Evaluate(ctx context.Context, ev model.DomainEvent) error
+	// PenalizeMisses は利用者のタイムゾーンで前日を終えた日次目標を判定し、未達成であればダメージを与える。
+	// 定期実行し、判定は前日分のみ行う
+	PenalizeMisses(ctx context.Context) error
+}
+
+type goalUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	gr       repository.GoalRepository
+	gar      repository.GoalAchievementRepository
+	gmr      repository.GoalMissRepository
+	str      repository.StatisticsRepository
+	or       repository.OutboxRepository
+	tx       repository.Transaction
+	rewarder Rewarder
+}
+
+func (g *goalUsecase) List(ctx context.Context, email string) ([]output.GoalStatus, error) {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := findSettings(ctx, g.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return nil, err
+	}
+
+	goals, err := g.gr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find goals failed", err)
+		return nil, err
+	}
+
+	byPeriod := make(map[model.GoalPeriod]model.Goal, len(goals))
+	for _, goal := range goals {
+		byPeriod[goal.Period] = goal
+	}
+
+	now := time.Now()
+	res := make([]output.GoalStatus, 0, len(goals))
+	for _, period := range model.GoalPeriods {
+		goal, ok := byPeriod[period]
+		if !ok {
+			continue
+		}
+
+		progress, err := g.progress(ctx, goal, now, settings.Location())
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "aggregate goal progress failed", err)
+			return nil, err
+		}
+
+		res = append(res, output.GoalStatus{
+			Period:        string(period),
+			TargetMinutes: goal.TargetMinutes,
+			FocusMinutes:  progress.FocusMinutes,
+			Achieved:      progress.Achieved(),
+			Start:         progress.Start,
+			End:           progress.End,
+		})
+	}
+
+	return res, nil
+}
+
+func (g *goalUsecase) Set(ctx context.Context, email string, period string, targetMinutes int) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	p, err := model.NewGoalPeriod(period)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "目標の期間が正しくありません", err)
+	}
+
+	goal, err := model.NewGoal(acc.ID, p, targetMinutes)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := g.gr.Save(ctx, goal); err != nil {
+		logger.Event(ctx, logger.ERROR, "save goal failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *goalUsecase) Delete(ctx context.Context, email string, period string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	p, err := model.NewGoalPeriod(period)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "目標の期間が正しくありません", err)
+	}
+
+	if err := g.gr.Delete(ctx, acc.ID, p); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete goal failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *goalUsecase) History(ctx context.Context, email string, in input.GoalHistory) ([]output.GoalAchievement, error) {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.GoalAchievementFilter{Limit: in.Limit, Offset: in.Offset}
+	if in.Period != "" {
+		p, err := model.NewGoalPeriod(in.Period)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "目標の期間が正しくありません", err)
+		}
+		filter.Period = p
+	}
+	if in.Limit < 0 || in.Limit > maxGoalHistoryLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	achievements, err := g.gar.Find(ctx, acc.ID, filter)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find goal achievements failed", err)
+		return nil, err
+	}
+
+	res := make([]output.GoalAchievement, 0, len(achievements))
+	for _, a := range achievements {
+		res = append(res, output.GoalAchievement{
+			Period:        string(a.Period),
+			PeriodStart:   a.PeriodStart.Format(dateLayout),
+			TargetMinutes: a.TargetMinutes,
+			FocusMinutes:  a.FocusMinutes,
+			AchievedAt:    a.AchievedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (g *goalUsecase) Evaluate(ctx context.Context, ev model.DomainEvent) error {
+	completed, ok := ev.(model.FocusSessionCompleted)
+	if !ok {
+		return nil
+	}
+
+	goals, err := g.gr.FindByAccountID(ctx, completed.AccountID)
+	if err != nil || len(goals) == 0 {
+		return err
+	}
+
+	settings, err := findSettings(ctx, g.sr, completed.AccountID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, goal := range goals {
+		// オフライン中の記録も含め、集中した日時が属する期間で判定する
+		progress, err := g.progress(ctx, goal, completed.ExecutionDate, settings.Location())
+		if err != nil {
+			return err
+		}
+		if !progress.Achieved() {
+			continue
+		}
+
+		achievement, err := progress.Achieve(now)
+		if err != nil {
+			return err
+		}
+
+		err = g.tx.Do(ctx, func(ctx context.Context) error {
+			created, err := g.gar.Create(ctx, achievement)
+			if err != nil || !created {
+				return err
+			}
+
+			if err := g.or.Save(ctx, achievement.PullEvents()...); err != nil {
+				return err
+			}
+			return g.rewarder.Grant(ctx, goal.AccountID, model.NewGoalReward(goal.Period), model.NewGoalRewardContext(achievement))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *goalUsecase) Reassess(ctx context.Context, accID model.AccountID, at time.Time) error {
+	goals, err := g.gr.FindByAccountID(ctx, accID)
+	if err != nil || len(goals) == 0 {
+		return err
+	}
+
+	settings, err := findSettings(ctx, g.sr, accID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, goal := range goals {
+		progress, err := g.progress(ctx, goal, at, settings.Location())
+		if err != nil {
+			return err
+		}
+		if progress.Achieved() {
+			continue
+		}
+
+		achievement, err := g.gar.FindByPeriodStart(ctx, accID, goal.Period, progress.Start)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if err := achievement.Revoke(progress, now); err != nil {
+			return err
+		}
+
+		deleted, err := g.gar.Delete(ctx, achievement)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			continue
+		}
+
+		if err := g.or.Save(ctx, achievement.PullEvents()...); err != nil {
+			return err
+		}
+		if err := g.rewarder.Adjust(ctx, accID, model.Reward{}, model.NewGoalRewardContext(achievement)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *goalUsecase) PenalizeMisses(ctx context.Context) error {
+	now := time.Now()
+
+	// 複数のプロセスで実行されても、判定は1つのプロセスだけが行う
+	err := g.tx.Do(ctx, func(ctx context.Context) error {
+		locked, err := g.gmr.TryLock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		missed, err := g.gmr.FindMissedDaily(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, progress := range missed {
+			if !progress.Goal.Covers(progress.Start) {
+				continue
+			}
+
+			miss, err := progress.Miss(now)
+			if err != nil {
+				return err
+			}
+
+			created, err := g.gmr.Create(ctx, miss)
+			if err != nil {
+				return err
+			}
+			if !created {
+				continue
+			}
+			if err := g.rewarder.Damage(ctx, miss.AccountID, model.MissedDailyGoalDamage()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "penalize missed goals failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *goalUsecase) progress(ctx context.Context, goal model.Goal, at time.Time, loc *time.Location) (model.GoalProgress, error) {
+	start, end := goal.Period.Range(at, loc)
+	minutes, err := g.str.FocusMinutes(ctx, goal.AccountID, start, end)
+	if err != nil {
+		return model.GoalProgress{}, err
+	}
+
+	return model.GoalProgress{Goal: goal, Start: start, End: end, FocusMinutes: minutes}, nil
+}
+
+func (g *goalUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := g.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewGoalUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, gr repository.GoalRepository, gar repository.GoalAchievementRepository, gmr repository.GoalMissRepository, str repository.StatisticsRepository, or repository.OutboxRepository, tx repository.Transaction, rewarder Rewarder) GoalUsecase {
+	return &goalUsecase{ar, sr, gr, gar, gmr, str, or, tx, rewarder}
+}
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultGuildSearchLimit = 20
+	maxGuildSearchLimit     = 100
+)
+
+type GuildUsecase interface {
+	Create(ctx context.Context, email string, in input.Guild) (output.Guild, error)
+	Search(ctx context.Context, email string, in input.GuildSearch) ([]output.GuildSummary, error)
+	// Mine は所属しているギルドとメンバーの一覧を返す
+	Mine(ctx context.Context, email string) (output.Guild, error)
+	// Update はリーダーと副リーダーのみ実行できる
+	Update(ctx context.Context, email string, in input.Guild) (output.Guild, error)
+	Join(ctx context.Context, email string, guildID string) error
+	// Leave はリーダーが最後の1人であればギルドを解散する
+	Leave(ctx context.Context, email string) error
+	// ChangeRole はリーダーのみ実行できる。leaderを指定するとリーダーを譲る
+	ChangeRole(ctx context.Context, email string, accountID string, role string) error
+	Kick(ctx context.Context, email string, accountID string) error
+	// Disband はリーダーのみ実行できる
+	Disband(ctx context.Context, email string) error
+}
+
+type guildUsecase struct {
+	ar  repository.AccountRepository
+	gr  repository.GuildRepository
+	gmr repository.GuildMembershipRepository
+	tx  repository.Transaction
+}
+
+func (g *guildUsecase) Create(ctx context.Context, email string, in input.Guild) (output.Guild, error) {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return output.Guild{}, err
+	}
+
+	guild, err := model.NewGuild(model.GenerateGuildID(), in.Name, in.Description)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Guild{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		if err := g.ensureNotMember(ctx, acc.ID); err != nil {
+			return err
+		}
+
+		if err := g.gr.Create(ctx, guild); err != nil {
+			return err
+		}
+		return g.gmr.Create(ctx, guild.Found(acc.ID))
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.Guild{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "create guild failed", err)
+		return output.Guild{}, err
+	}
+
+	return g.detail(ctx, guild, guild.Found(acc.ID))
+}
+
+func (g *guildUsecase) Search(ctx context.Context, email string, in input.GuildSearch) ([]output.GuildSummary, error) {
+	if _, err := g.findAccount(ctx, email); err != nil {
+		return nil, err
+	}
+
+	if in.Limit < 0 || in.Limit > maxGuildSearchLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultGuildSearchLimit
+	}
+
+	guilds, err := g.gr.Search(ctx, in.Query, limit, in.Offset)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "search guilds failed", err)
+		return nil, err
+	}
+
+	res := make([]output.GuildSummary, 0, len(guilds))
+	for _, guild := range guilds {
+		res = append(res, output.GuildSummary{
+			ID:          guild.ID.String(),
+			Name:        guild.Name,
+			Description: guild.Description,
+			Members:     guild.Members,
+			CreatedAt:   guild.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (g *guildUsecase) Mine(ctx context.Context, email string) (output.Guild, error) {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return output.Guild{}, err
+	}
+
+	me, err := findGuildMembership(ctx, g.gmr, acc.ID)
+	if err != nil {
+		return output.Guild{}, err
+	}
+
+	guild, err := g.gr.FindByID(ctx, me.GuildID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find guild failed", err)
+		return output.Guild{}, err
+	}
+
+	return g.detail(ctx, guild, me)
+}
+
+func (g *guildUsecase) Update(ctx context.Context, email string, in input.Guild) (output.Guild, error) {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return output.Guild{}, err
+	}
+
+	var (
+		guild model.Guild
+		me    model.GuildMembership
+	)
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		guild, me, err = lockGuildMembership(ctx, g.gr, g.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		if !me.CanManage() {
+			err := errors.New("only the leader or officers can update the guild")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "ギルドの操作権限がありません", err)
+		}
+
+		if err := guild.Update(in.Name, in.Description); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+		}
+		return g.gr.Update(ctx, guild)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.Guild{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "update guild failed", err)
+		return output.Guild{}, err
+	}
+
+	return g.detail(ctx, guild, me)
+}
+
+func (g *guildUsecase) Join(ctx context.Context, email string, guildID string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	id, err := model.NewGuildID(guildID)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrNotFound, "ギルドが見つかりません", err)
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		if err := g.ensureNotMember(ctx, acc.ID); err != nil {
+			return err
+		}
+
+		// メンバー数の上限を超えないよう、同じギルドへの加入を直列化する
+		guild, err := g.gr.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				logger.Event(ctx, logger.INFO, "guild not found", err)
+				return apperr.NewApplicationError(apperr.ErrNotFound, "ギルドが見つかりません", err)
+			}
+			return err
+		}
+
+		members, err := g.gmr.Count(ctx, guild.ID)
+		if err != nil {
+			return err
+		}
+
+		m, err := guild.Join(acc.ID, members)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "ギルドのメンバーが上限に達しています", err)
+		}
+		return g.gmr.Create(ctx, m)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "join guild failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) Leave(ctx context.Context, email string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		guild, me, err := lockGuildMembership(ctx, g.gr, g.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		members, err := g.gmr.Count(ctx, guild.ID)
+		if err != nil {
+			return err
+		}
+
+		disband, err := me.Leave(members)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "リーダーを他のメンバーに譲ってから脱退してください", err)
+		}
+		if disband {
+			return g.gr.Delete(ctx, guild.ID)
+		}
+		return g.gmr.Delete(ctx, guild.ID, acc.ID)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "leave guild failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) ChangeRole(ctx context.Context, email string, accountID string, role string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	r, err := model.NewGuildRole(role)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		_, me, err := lockGuildMembership(ctx, g.gr, g.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		target, err := g.findMember(ctx, me.GuildID, accountID)
+		if err != nil {
+			return err
+		}
+
+		if err := me.Assign(&target, r); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "ギルドの操作権限がありません", err)
+		}
+
+		if err := g.gmr.Update(ctx, target); err != nil {
+			return err
+		}
+		return g.gmr.Update(ctx, me)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "change guild role failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) Kick(ctx context.Context, email string, accountID string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		_, me, err := lockGuildMembership(ctx, g.gr, g.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		target, err := g.findMember(ctx, me.GuildID, accountID)
+		if err != nil {
+			return err
+		}
+
+		if err := me.Kick(target); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "ギルドの操作権限がありません", err)
+		}
+		return g.gmr.Delete(ctx, target.GuildID, target.AccountID)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "kick guild member failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) Disband(ctx context.Context, email string) error {
+	acc, err := g.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	err = g.tx.Do(ctx, func(ctx context.Context) error {
+		guild, me, err := lockGuildMembership(ctx, g.gr, g.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		if !me.IsLeader() {
+			err := errors.New("only the leader can disband the guild")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "ギルドの操作権限がありません", err)
+		}
+		return g.gr.Delete(ctx, guild.ID)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "disband guild failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) detail(ctx context.Context, guild model.Guild, me model.GuildMembership) (output.Guild, error) {
+	memberships, err := g.gmr.FindByGuildID(ctx, guild.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find guild members failed", err)
+		return output.Guild{}, err
+	}
+
+	members := make([]output.GuildMember, 0, len(memberships))
+	for _, m := range memberships {
+		acc, err := g.ar.FindByID(ctx, m.AccountID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find guild member account failed", err)
+			return output.Guild{}, err
+		}
+
+		members = append(members, output.GuildMember{
+			AccountID: acc.ID.String(),
+			Name:      acc.Name,
+			Image:     acc.Image,
+			Role:      string(m.Role),
+			JoinedAt:  m.JoinedAt,
+		})
+	}
+
+	return output.Guild{
+		ID:          guild.ID.String(),
+		Name:        guild.Name,
+		Description: guild.Description,
+		Role:        string(me.Role),
+		Members:     members,
+		CreatedAt:   guild.CreatedAt,
+	}, nil
+}
+
+// ensureNotMember はトランザクション内で呼び出すこと
+func (g *guildUsecase) ensureNotMember(ctx context.Context, accID model.AccountID) error {
+	_, err := g.gmr.FindByAccountID(ctx, accID)
+	if err == nil {
+		err := errors.New("account already belongs to a guild")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrConflict, "既にギルドに所属しています", err)
+	}
+	if !errors.Is(err, apperr.ErrDataNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (g *guildUsecase) findMember(ctx context.Context, guildID model.GuildID, accountID string) (model.GuildMembership, error) {
+	m, err := g.gmr.FindByAccountID(ctx, model.AccountID(accountID))
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		return model.GuildMembership{}, err
+	}
+	if err != nil || m.GuildID != guildID {
+		err := errors.New("guild member not found")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.GuildMembership{}, apperr.NewApplicationError(apperr.ErrNotFound, "ギルドのメンバーが見つかりません", err)
+	}
+
+	return m, nil
+}
+
+func (g *guildUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := g.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// findGuildMembership は所属していない場合にNotFoundとして扱う
+func findGuildMembership(ctx context.Context, gmr repository.GuildMembershipRepository, accID model.AccountID) (model.GuildMembership, error) {
+	m, err := gmr.FindByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "guild membership not found", err)
+			return model.GuildMembership{}, apperr.NewApplicationError(apperr.ErrNotFound, "ギルドに所属していません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find guild membership failed", err)
+		return model.GuildMembership{}, err
+	}
+
+	return m, nil
+}
+
+// lockGuildMembership は所属するギルドをロックし、ロック後の所属を返す。
+// 役割の変更や脱退と競合しないよう、ギルドを変更する操作はトランザクション内でこれを最初に呼び出す
+func lockGuildMembership(ctx context.Context, gr repository.GuildRepository, gmr repository.GuildMembershipRepository, accID model.AccountID) (model.Guild, model.GuildMembership, error) {
+	m, err := findGuildMembership(ctx, gmr, accID)
+	if err != nil {
+		return model.Guild{}, model.GuildMembership{}, err
+	}
+
+	guild, err := gr.FindByIDForUpdate(ctx, m.GuildID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "guild not found", err)
+			return model.Guild{}, model.GuildMembership{}, apperr.NewApplicationError(apperr.ErrNotFound, "ギルドに所属していません", err)
+		}
+		return model.Guild{}, model.GuildMembership{}, err
+	}
+
+	// ロックを待つ間に役割が変わったり脱退させられている可能性があるため読み直す
+	m, err = findGuildMembership(ctx, gmr, accID)
+	if err != nil {
+		return model.Guild{}, model.GuildMembership{}, err
+	}
+	if m.GuildID != guild.ID {
+		err := errors.New("guild membership changed")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return model.Guild{}, model.GuildMembership{}, apperr.NewApplicationError(apperr.ErrConflict, "ギルドの所属が変更されました", err)
+	}
+
+	return guild, m, nil
+}
+
+func NewGuildUsecase(ar repository.AccountRepository, gr repository.GuildRepository, gmr repository.GuildMembershipRepository, tx repository.Transaction) GuildUsecase {
+	return &guildUsecase{ar, gr, gmr, tx}
+}
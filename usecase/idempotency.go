@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type IdempotencyUsecase interface {
+	// Begin は初回のリクエストであればキーを予約してnilを返し、再送であれば保存したレスポンスを返す
+	Begin(ctx context.Context, scope, key, requestHash string) (*output.IdempotentResponse, error)
+	Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error
+	// Release は処理に失敗したリクエストを再送できるよう予約を取り消す
+	Release(ctx context.Context, scope, key string) error
+	Purge(ctx context.Context) (int, error)
+}
+
+type idempotencyUsecase struct {
+	ir  repository.IdempotencyRepository
+	ttl time.Duration
+}
+
+func (i *idempotencyUsecase) Begin(ctx context.Context, scope, key, requestHash string) (*output.IdempotentResponse, error) {
+	record, err := model.NewIdempotencyRecord(scope, key, requestHash, time.Now(), i.ttl)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "Idempotency-Keyが正しくありません", err)
+	}
+
+	reserved, err := i.ir.Reserve(ctx, record)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "reserve idempotency key failed", err)
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	found, err := i.ir.Find(ctx, scope, key)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find idempotency key failed", err)
+		return nil, err
+	}
+
+	if found.RequestHash != requestHash {
+		err := errors.Newf("idempotency key reused with different request: %s", key)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "Idempotency-Keyが別のリクエストで使用されています", err)
+	}
+
+	if !found.Completed() {
+		err := errors.Newf("idempotency key in progress: %s", key)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrConflict, "同じリクエストを処理中です", err)
+	}
+
+	return &output.IdempotentResponse{
+		StatusCode: found.StatusCode,
+		Body:       found.Body,
+	}, nil
+}
+
+func (i *idempotencyUsecase) Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error {
+	record := model.RecreateIdempotencyRecord(scope, key, "", 0, nil, nil, time.Time{})
+	record.Complete(statusCode, body, time.Now())
+
+	if err := i.ir.Complete(ctx, record); err != nil {
+		logger.Event(ctx, logger.ERROR, "complete idempotency key failed", err)
+		return err
+	}
+	return nil
+}
+
+func (i *idempotencyUsecase) Release(ctx context.Context, scope, key string) error {
+	if err := i.ir.Delete(ctx, scope, key); err != nil {
+		logger.Event(ctx, logger.ERROR, "release idempotency key failed", err)
+		return err
+	}
+	return nil
+}
+
+func (i *idempotencyUsecase) Purge(ctx context.Context) (int, error) {
+	n, err := i.ir.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "purge idempotency keys failed", err)
+		return 0, err
+	}
+	return n, nil
+}
+
+func NewIdempotencyUsecase(ir repository.IdempotencyRepository, ttl time.Duration) IdempotencyUsecase {
+	return &idempotencyUsecase{ir, ttl}
+}
@@ -0,0 +1,26 @@
+package input
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type AuditEvent struct {
+	ActorID    model.AccountID
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   string
+	Diff       model.AuditDiff
+}
+
+type AuditSearch struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,5 @@
+package input
+
+type RenameCompanion struct {
+	Nickname string
+}
@@ -0,0 +1,7 @@
+package input
+
+type Privacy struct {
+	ShareLevel       bool
+	ShareStreak      bool
+	ShareWeeklyFocus bool
+}
@@ -0,0 +1,8 @@
+package input
+
+type GoalHistory struct {
+	// Period が空の場合は全ての期間を対象とする
+	Period string
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,18 @@
+package input
+
+type Guild struct {
+	Name        string
+	Description string
+}
+
+type GuildSearch struct {
+	// Query が空の場合は全てのギルドを対象とする
+	Query  string
+	Limit  int
+	Offset int
+}
+
+type RaidHistory struct {
+	Limit  int
+	Offset int
+}
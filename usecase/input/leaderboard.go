@@ -0,0 +1,9 @@
+package input
+
+type Leaderboard struct {
+	Metric string
+	Period string
+	// Scope が空の場合は全体のランキング
+	Scope string
+	Limit int
+}
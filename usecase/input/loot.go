@@ -0,0 +1,6 @@
+package input
+
+type LootDrops struct {
+	Limit  int
+	Offset int
+}
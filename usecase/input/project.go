@@ -0,0 +1,13 @@
+package input
+
+type Project struct {
+	Name string
+}
+
+type Task struct {
+	// ProjectID が空の場合はプロジェクトに属さないタスクとする
+	ProjectID          string
+	Title              string
+	EstimatedPomodoros int
+	Completed          bool
+}
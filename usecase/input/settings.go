@@ -0,0 +1,13 @@
+package input
+
+type Settings struct {
+	FocusMinutes      int
+	ShortBreakMinutes int
+	LongBreakMinutes  int
+	LongBreakInterval int
+	AutoStartBreaks   bool
+	AutoStartFocus    bool
+	NotifySound       bool
+	NotifyDesktop     bool
+	Timezone          string
+}
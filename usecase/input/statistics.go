@@ -0,0 +1,14 @@
+package input
+
+type StatisticsPeriod struct {
+	// From, To はTimezone基準の日付(YYYY-MM-DD)で、両端を含む
+	From     string
+	To       string
+	Timezone string
+}
+
+type Heatmap struct {
+	// Year が空の場合はTimezone基準の今年
+	Year     string
+	Timezone string
+}
@@ -0,0 +1,19 @@
+package input
+
+import "time"
+
+type Sync struct {
+	Token    string
+	Sessions []SyncSession
+}
+
+// SyncSession はオフライン中に端末で記録されたセッション。IDは端末で生成したUUID
+type SyncSession struct {
+	ID      string
+	Kind    string
+	Minutes float64
+	TaskID  string
+	Note    string
+	Tags    []string
+	EndedAt time.Time
+}
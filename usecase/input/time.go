@@ -0,0 +1,15 @@
+package input
+
+type Time struct {
+	FocusTime float64
+	// TaskID が空の場合はタスクに紐付けない
+	TaskID string
+	Note   string
+	Tags   []string
+}
+
+type TimeSearch struct {
+	Tag    string
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,6 @@
+package input
+
+type Webhook struct {
+	URL    string
+	Events []string
+}
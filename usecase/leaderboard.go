@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 100
+	// leaderboardSettlePeriod の間は、期間が切り替わる直前の記録を反映するため直前の期間も集計し直す
+	leaderboardSettlePeriod = 24 * time.Hour
+)
+
+type LeaderboardUsecase interface {
+	Get(ctx context.Context, email string, in input.Leaderboard) (output.Leaderboard, error)
+	// Refresh は定期的に呼び出し、全てのランキングのスナップショットを更新する
+	Refresh(ctx context.Context) error
+}
+
+type leaderboardUsecase struct {
+	ar  repository.AccountRepository
+	fr  repository.FriendshipRepository
+	lbr repository.LeaderboardRepository
+}
+
+func (l *leaderboardUsecase) Get(ctx context.Context, email string, in input.Leaderboard) (output.Leaderboard, error) {
+	acc, err := l.findAccount(ctx, email)
+	if err != nil {
+		return output.Leaderboard{}, err
+	}
+
+	board, err := model.NewLeaderboard(in.Metric, in.Period)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Leaderboard{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	scope := model.LeaderboardGlobal
+	if in.Scope != "" {
+		if scope, err = model.NewLeaderboardScope(in.Scope); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return output.Leaderboard{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+		}
+	}
+
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit < 0 || limit > maxLeaderboardLimit {
+		err := errors.Newf("invalid limit: %d", in.Limit)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Leaderboard{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	start, _ := board.Range(time.Now())
+	var entries []model.LeaderboardEntry
+	var me *model.LeaderboardEntry
+	if scope == model.LeaderboardFriends {
+		entries, me, err = l.friends(ctx, acc.ID, board, start)
+	} else {
+		entries, me, err = l.global(ctx, acc.ID, board, start, limit)
+	}
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find leaderboard failed", err)
+		return output.Leaderboard{}, err
+	}
+
+	res := output.Leaderboard{
+		Metric:  string(board.Metric),
+		Period:  string(board.Period),
+		Scope:   string(scope),
+		Entries: make([]output.LeaderboardEntry, 0, min(len(entries), limit)),
+	}
+	if board.Period != model.LeaderboardAllTime {
+		res.PeriodStart = start.Format(dateLayout)
+	}
+	for _, e := range entries[:min(len(entries), limit)] {
+		res.Entries = append(res.Entries, toLeaderboardEntryOutput(e))
+	}
+	if me != nil {
+		entry := toLeaderboardEntryOutput(*me)
+		res.Me = &entry
+	}
+
+	return res, nil
+}
+
+// global は上位と自分の順位を返す。自分の順位は一覧に含まれていなくても主キーで引く
+func (l *leaderboardUsecase) global(ctx context.Context, accID model.AccountID, board model.Leaderboard, start time.Time, limit int) ([]model.LeaderboardEntry, *model.LeaderboardEntry, error) {
+	entries, err := l.lbr.FindTop(ctx, board, start, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mine, err := l.lbr.FindByAccountIDs(ctx, board, start, []model.AccountID{accID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(mine) == 0 {
+		return entries, nil, nil
+	}
+
+	return entries, &mine[0], nil
+}
+
+// friends は自分とフレンドの中で順位を付け直して返す
+func (l *leaderboardUsecase) friends(ctx context.Context, accID model.AccountID, board model.Leaderboard, start time.Time) ([]model.LeaderboardEntry, *model.LeaderboardEntry, error) {
+	friendships, err := l.fr.FindByAccountID(ctx, accID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]model.AccountID, 0, len(friendships)+1)
+	ids = append(ids, accID)
+	for _, f := range friendships {
+		ids = append(ids, f.FriendID)
+	}
+
+	found, err := l.lbr.FindByAccountIDs(ctx, board, start, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := model.RankAmong(found)
+	for i := range entries {
+		if entries[i].AccountID == accID {
+			return entries, &entries[i], nil
+		}
+	}
+
+	return entries, nil, nil
+}
+
+func (l *leaderboardUsecase) Refresh(ctx context.Context) error {
+	now := time.Now()
+
+	for _, board := range model.Leaderboards {
+		start, end := board.Range(now)
+		if err := l.lbr.Refresh(ctx, board, start, end); err != nil {
+			logger.Event(ctx, logger.ERROR, "refresh leaderboard failed", err)
+			return err
+		}
+
+		if prev, ok := board.Previous(now); ok && now.Sub(start) < leaderboardSettlePeriod {
+			start, end := board.Range(prev)
+			if err := l.lbr.Refresh(ctx, board, start, end); err != nil {
+				logger.Event(ctx, logger.ERROR, "refresh previous leaderboard failed", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *leaderboardUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := l.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toLeaderboardEntryOutput(e model.LeaderboardEntry) output.LeaderboardEntry {
+	return output.LeaderboardEntry{
+		Rank:      e.Rank,
+		AccountID: e.AccountID.String(),
+		Name:      e.Name,
+		Image:     e.Image,
+		Score:     e.Score,
+	}
+}
+
+func NewLeaderboardUsecase(ar repository.AccountRepository, fr repository.FriendshipRepository, lbr repository.LeaderboardRepository) LeaderboardUsecase {
+	return &leaderboardUsecase{ar, fr, lbr}
+}
@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"strconv"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultLootDropLimit = 50
+	maxLootDropLimit     = 200
+)
+
+// Looter は報酬の付与にあわせたドロップの抽選を各ユースケースで共通化する。
+// Rewarderと同じトランザクション内で呼び出すこと
+type Looter interface {
+	// Roll は報酬の種類に対応するテーブルごとに1回抽選し、ドロップを報酬の元と紐づける。
+	// 対応するテーブルがない場合や、最低限の長さに満たない集中では何もしない
+	Roll(ctx context.Context, accID model.AccountID, rc model.RewardContext) error
+	// Void はsourceIDの報酬にあわせて抽選したドロップを取り消し、その抽選がなかったものとして天井カウンターを戻す
+	Void(ctx context.Context, accID model.AccountID, sourceID string) error
+}
+
+type LootUsecase interface {
+	Looter
+	// Load はデータファイルからテーブルを読み込み、抽選に使うテーブルを差し替える
+	Load(ctx context.Context) error
+	// Reload は管理者がデータファイルの変更を反映する
+	Reload(ctx context.Context, email string) ([]output.LootTable, error)
+	Tables(ctx context.Context) []output.LootTable
+	Drops(ctx context.Context, email string, in input.LootDrops) ([]output.LootDrop, error)
+	Inventory(ctx context.Context, email string) ([]output.InventoryItem, error)
+	// Verify は保存されたシードと抽選時のテーブルで抽選をやり直し、同じ結果になるかを確かめる
+	Verify(ctx context.Context, email string, id string) (output.LootVerification, error)
+}
+
+type lootUsecase struct {
+	ar    repository.AccountRepository
+	ltr   repository.LootTableRepository
+	lvr   repository.LootTableVersionRepository
+	lpr   repository.LootPityRepository
+	ldr   repository.LootDropRepository
+	or    repository.OutboxRepository
+	audit AuditUsecase
+
+	mu     sync.RWMutex
+	tables []model.LootTable
+}
+
+func (l *lootUsecase) Load(ctx context.Context) error {
+	_, err := l.load(ctx)
+	return err
+}
+
+func (l *lootUsecase) load(ctx context.Context) (model.AuditDiff, error) {
+	tables, err := l.ltr.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 抽選結果から参照できるよう、使い始める前にスナップショットを保存する
+	for _, t := range tables {
+		if err := l.lvr.Save(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	diff := model.AuditDiff{}
+	versions := make(map[string]string, len(l.tables))
+	for _, t := range l.tables {
+		versions[t.Key] = t.Version
+	}
+	for _, t := range tables {
+		diff.Add(t.Key, versions[t.Key], t.Version)
+		delete(versions, t.Key)
+	}
+	for key, version := range versions {
+		diff.Add(key, version, "")
+	}
+
+	l.tables = tables
+	return diff, nil
+}
+
+func (l *lootUsecase) Reload(ctx context.Context, email string) ([]output.LootTable, error) {
+	acc, err := l.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := l.load(ctx)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "load loot tables failed", err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "ドロップテーブルを読み込めませんでした", err)
+	}
+
+	l.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionReloadLootTables,
+		TargetType: model.AuditTargetLootTable,
+		Diff:       diff,
+	})
+
+	return l.Tables(ctx), nil
+}
+
+func (l *lootUsecase) Tables(ctx context.Context) []output.LootTable {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	res := make([]output.LootTable, 0, len(l.tables))
+	for _, t := range l.tables {
+		rates := t.Rates()
+		entries := make([]output.LootEntry, 0, len(t.Entries))
+		for _, e := range t.Entries {
+			entries = append(entries, output.LootEntry{
+				Item:   e.Item,
+				Name:   e.Name,
+				Rarity: string(e.Rarity),
+				Weight: e.Weight,
+				Rate:   rates[e.Item],
+			})
+		}
+
+		pity := make([]output.LootPityRule, 0, len(t.Pity))
+		for _, p := range t.Pity {
+			pity = append(pity, output.LootPityRule{
+				Rarity: string(p.Rarity),
+				After:  p.After,
+			})
+		}
+
+		res = append(res, output.LootTable{
+			Key:     t.Key,
+			Name:    t.Name,
+			Source:  string(t.Source),
+			Version: t.Version,
+			Entries: entries,
+			Pity:    pity,
+		})
+	}
+
+	return res
+}
+
+func (l *lootUsecase) Roll(ctx context.Context, accID model.AccountID, rc model.RewardContext) error {
+	if !rc.Lootable() {
+		return nil
+	}
+
+	for _, t := range l.tablesFor(rc.Source) {
+		pity, err := l.lpr.FindForUpdate(ctx, accID, t.Key)
+		if err != nil {
+			if !errors.Is(err, apperr.ErrDataNotFound) {
+				return err
+			}
+			pity = model.NewLootPity(accID, t.Key)
+		}
+
+		seed, err := model.GenerateLootSeed()
+		if err != nil {
+			return err
+		}
+
+		entry, next := t.Roll(pity, seed)
+		drop := model.NewLootDrop(model.GenerateLootDropID(), t, pity, seed, entry, rc.SourceID)
+		if err := l.ldr.Create(ctx, drop); err != nil {
+			return err
+		}
+		if err := l.lpr.Save(ctx, next); err != nil {
+			return err
+		}
+		if err := l.or.Save(ctx, drop.PullEvents()...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *lootUsecase) Void(ctx context.Context, accID model.AccountID, sourceID string) error {
+	drops, err := l.ldr.FindBySourceID(ctx, accID, sourceID)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range drops {
+		// 同じテーブルの抽選と競合しないよう、天井カウンターを先にロックする
+		if _, err := l.lpr.FindForUpdate(ctx, accID, d.TableKey); err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		later, err := l.ldr.FindLater(ctx, d)
+		if err != nil {
+			return err
+		}
+		if err := l.ldr.Delete(ctx, d.ID); err != nil {
+			return err
+		}
+
+		// 読み込み直して削除されたテーブルの天井カウンターはもう使わないため戻さない
+		t, ok := l.table(d.TableKey)
+		if !ok {
+			continue
+		}
+		if err := l.lpr.Save(ctx, t.Revert(d, later)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *lootUsecase) table(key string) (model.LootTable, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, t := range l.tables {
+		if t.Key == key {
+			return t, true
+		}
+	}
+	return model.LootTable{}, false
+}
+
+func (l *lootUsecase) tablesFor(source model.RewardSource) []model.LootTable {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var res []model.LootTable
+	for _, t := range l.tables {
+		if t.Source == source {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func (l *lootUsecase) Drops(ctx context.Context, email string, in input.LootDrops) ([]output.LootDrop, error) {
+	acc, err := l.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Limit < 0 || in.Limit > maxLootDropLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultLootDropLimit
+	}
+
+	drops, err := l.ldr.FindByAccountID(ctx, acc.ID, limit, in.Offset)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find loot drops failed", err)
+		return nil, err
+	}
+
+	res := make([]output.LootDrop, 0, len(drops))
+	for _, d := range drops {
+		res = append(res, toLootDropOutput(d))
+	}
+
+	return res, nil
+}
+
+func (l *lootUsecase) Inventory(ctx context.Context, email string) ([]output.InventoryItem, error) {
+	acc, err := l.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.ldr.Inventory(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find inventory failed", err)
+		return nil, err
+	}
+
+	res := make([]output.InventoryItem, 0, len(items))
+	for _, i := range items {
+		res = append(res, output.InventoryItem{
+			Item:   i.Item,
+			Name:   i.Name,
+			Rarity: string(i.Rarity),
+			Count:  i.Count,
+		})
+	}
+
+	return res, nil
+}
+
+func (l *lootUsecase) Verify(ctx context.Context, email string, id string) (output.LootVerification, error) {
+	if _, err := l.findAccount(ctx, email); err != nil {
+		return output.LootVerification{}, err
+	}
+
+	dropID, err := model.NewLootDropID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.LootVerification{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	drop, err := l.ldr.FindByID(ctx, dropID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "loot drop not found", err)
+			return output.LootVerification{}, apperr.NewApplicationError(apperr.ErrNotFound, "ドロップが見つかりません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find loot drop failed", err)
+		return output.LootVerification{}, err
+	}
+
+	table, err := l.lvr.Find(ctx, drop.TableKey, drop.TableVersion)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find loot table version failed", err)
+		return output.LootVerification{}, err
+	}
+
+	replayed, err := drop.Replay(table)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "replay loot drop failed", err)
+		return output.LootVerification{}, err
+	}
+
+	return output.LootVerification{
+		Drop:         toLootDropOutput(drop),
+		ReplayedItem: replayed.Item,
+		Match:        replayed.Item == drop.Item,
+	}, nil
+}
+
+func (l *lootUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := l.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toLootDropOutput(d model.LootDrop) output.LootDrop {
+	pity := make(map[string]int, len(d.PityBefore))
+	for r, n := range d.PityBefore {
+		pity[string(r)] = n
+	}
+
+	return output.LootDrop{
+		ID:           d.ID.String(),
+		TableKey:     d.TableKey,
+		TableVersion: d.TableVersion,
+		Seed:         strconv.FormatUint(d.Seed, 10),
+		PityBefore:   pity,
+		Item:         d.Item,
+		ItemName:     d.ItemName,
+		Rarity:       string(d.Rarity),
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func NewLootUsecase(ar repository.AccountRepository, ltr repository.LootTableRepository, lvr repository.LootTableVersionRepository, lpr repository.LootPityRepository, ldr repository.LootDropRepository, or repository.OutboxRepository, audit AuditUsecase) LootUsecase {
+	return &lootUsecase{ar: ar, ltr: ltr, lvr: lvr, lpr: lpr, ldr: ldr, or: or, audit: audit}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/logger"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	outboxBatchSize = 100
+	outboxLease     = time.Minute
+)
+
+// EventHandler は同じイベントが複数回配信されても問題ないよう冪等に実装すること
+type EventHandler func(ctx context.Context, ev model.DomainEvent) error
+
+type OutboxUsecase interface {
+	Subscribe(name model.EventName, h EventHandler)
+	Dispatch(ctx context.Context) (int, error)
+}
+
+type outboxUsecase struct {
+	or          repository.OutboxRepository
+	subscribers map[model.EventName][]EventHandler
+}
+
+// Subscribe は起動時にのみ呼び出す
+func (o *outboxUsecase) Subscribe(name model.EventName, h EventHandler) {
+	o.subscribers[name] = append(o.subscribers[name], h)
+}
+
+// Dispatch は配信待ちのイベントを購読者に配信し、成功した件数を返す。
+// 失敗したイベントはバックオフ後に全購読者へ再配信される(at-least-once)
+func (o *outboxUsecase) Dispatch(ctx context.Context) (int, error) {
+	msgs, err := o.or.Claim(ctx, outboxBatchSize, outboxLease)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "outbox claim failed", err)
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, msg := range msgs {
+		if err := o.dispatch(ctx, msg); err != nil {
+			logger.Event(ctx, logger.WARN, "outbox dispatch failed", err)
+
+			msg.Fail(err, time.Now())
+			if msg.IsDead() {
+				logger.Event(ctx, logger.ERROR, "outbox message exceeded max attempts", err)
+			}
+			if err := o.or.MarkFailed(ctx, msg); err != nil {
+				logger.Event(ctx, logger.ERROR, "outbox mark failed failed", err)
+			}
+			continue
+		}
+
+		if err := o.or.MarkDispatched(ctx, msg.ID); err != nil {
+			logger.Event(ctx, logger.ERROR, "outbox mark dispatched failed", err)
+			continue
+		}
+		dispatched++
+	}
+
+	return dispatched, nil
+}
+
+func (o *outboxUsecase) dispatch(ctx context.Context, msg model.OutboxMessage) error {
+	ev, err := msg.Event()
+	if err != nil {
+		return err
+	}
+
+	for _, h := range o.subscribers[msg.EventName] {
+		if err := h(ctx, ev); err != nil {
+			return errors.Wrapf(err, "handle %s (%s)", msg.EventName, msg.ID)
+		}
+	}
+
+	return nil
+}
+
+func NewOutboxUsecase(or repository.OutboxRepository) OutboxUsecase {
+	return &outboxUsecase{
+		or:          or,
+		subscribers: map[model.EventName][]EventHandler{},
+	}
+}
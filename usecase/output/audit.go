@@ -0,0 +1,18 @@
+package output
+
+import (
+	"pomodoro-rpg-api/domain/model"
+	"time"
+)
+
+type AuditEvent struct {
+	ID         string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	IP         string
+	Diff       model.AuditDiff
+	CreatedAt  time.Time
+}
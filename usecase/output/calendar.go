@@ -0,0 +1,11 @@
+package output
+
+import "time"
+
+type CalendarEvent struct {
+	ID        string
+	Start     time.Time
+	End       time.Time
+	TaskTitle string
+	Note      string
+}
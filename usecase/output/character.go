@@ -0,0 +1,44 @@
+package output
+
+type Character struct {
+	Level int
+	Exp   int
+	// NextLevelExp は次のレベルに必要な累計経験値
+	NextLevelExp int
+	Gold         int
+	HP           int
+	MaxHP        int
+	// KnockedOut の間は報酬を受け取れない
+	KnockedOut bool
+	// Class は未選択の場合は空
+	Class       string
+	Stats       Stats
+	SkillPoints int
+	Skills      []string
+}
+
+type Stats struct {
+	Strength  int
+	Intellect int
+	Wisdom    int
+}
+
+type CharacterClass struct {
+	Class       string
+	Name        string
+	Description string
+	// Growth はレベルが1上がるごとに増える能力値
+	Growth Stats
+}
+
+type Skill struct {
+	Key         string
+	Name        string
+	Description string
+	Cost        int
+	Requires    []string
+	// Class が空のスキルはどのクラスでも習得できる
+	Class     string
+	Learned   bool
+	Learnable bool
+}
@@ -0,0 +1,35 @@
+package output
+
+import "time"
+
+type CompanionSpecies struct {
+	Key  string
+	Name string
+	// Egg はドロップで孵す種族のみ設定する
+	Egg string
+	// MilestoneMinutes は累計の集中時間で仲間になる種族のみ設定する
+	MilestoneMinutes int
+	Stages           []CompanionStage
+}
+
+type CompanionStage struct {
+	Name        string
+	MinExp      int
+	Description string
+}
+
+type Companion struct {
+	ID          string
+	Species     string
+	SpeciesName string
+	Nickname    string
+	Exp         int
+	Stage       int
+	StageName   string
+	// Bonus は現在の段階で得られるボーナスの説明
+	Bonus string
+	// NextStageExp は最後の段階まで進化している場合はnil
+	NextStageExp *int
+	Active       bool
+	ObtainedAt   time.Time
+}
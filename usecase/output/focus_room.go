@@ -0,0 +1,24 @@
+package output
+
+import "time"
+
+type FocusRoom struct {
+	ID               string
+	Code             string
+	HostID           string
+	Status           string
+	PlannedSeconds   int
+	ElapsedSeconds   int
+	RemainingSeconds int
+	StartedAt        *time.Time
+	Participants     []FocusRoomParticipant
+	ServerTime       time.Time
+}
+
+type FocusRoomParticipant struct {
+	AccountID   string
+	Name        string
+	Image       string
+	JoinedAt    time.Time
+	CompletedAt *time.Time
+}
@@ -0,0 +1,46 @@
+package output
+
+import "time"
+
+type FriendRequests struct {
+	Incoming []IncomingFriendRequest
+	Outgoing []OutgoingFriendRequest
+}
+
+type IncomingFriendRequest struct {
+	ID          string
+	RequesterID string
+	Name        string
+	Image       string
+	CreatedAt   time.Time
+}
+
+type OutgoingFriendRequest struct {
+	ID        string
+	Email     string
+	CreatedAt time.Time
+}
+
+// Friend の各項目はフレンドが公開していない場合にnilとなる
+type Friend struct {
+	AccountID          string
+	Name               string
+	Image              string
+	Level              *int
+	Streak             *int
+	WeeklyFocusMinutes *float64
+	Since              time.Time
+}
+
+type BlockedAccount struct {
+	AccountID string
+	Name      string
+	Image     string
+	BlockedAt time.Time
+}
+
+type Privacy struct {
+	ShareLevel       bool
+	ShareStreak      bool
+	ShareWeeklyFocus bool
+}
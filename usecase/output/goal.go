@@ -0,0 +1,20 @@
+package output
+
+import "time"
+
+type GoalStatus struct {
+	Period        string
+	TargetMinutes int
+	FocusMinutes  float64
+	Achieved      bool
+	Start         time.Time
+	End           time.Time
+}
+
+type GoalAchievement struct {
+	Period        string
+	PeriodStart   string
+	TargetMinutes int
+	FocusMinutes  float64
+	AchievedAt    time.Time
+}
@@ -0,0 +1,65 @@
+package output
+
+import "time"
+
+type GuildSummary struct {
+	ID          string
+	Name        string
+	Description string
+	Members     int
+	CreatedAt   time.Time
+}
+
+type Guild struct {
+	ID          string
+	Name        string
+	Description string
+	// Role は自分の役割
+	Role      string
+	Members   []GuildMember
+	CreatedAt time.Time
+}
+
+type GuildMember struct {
+	AccountID string
+	Name      string
+	Image     string
+	Role      string
+	JoinedAt  time.Time
+}
+
+type RaidBoss struct {
+	Key            string
+	Name           string
+	HP             int
+	TimeLimitHours int
+	RewardExp      int
+	RewardGold     int
+	// Season はシーズン限定のボスのみ設定する
+	Season string
+}
+
+type Raid struct {
+	ID         string
+	Boss       string
+	BossName   string
+	MaxHP      int
+	HP         float64
+	Status     string
+	RewardExp  int
+	RewardGold int
+	StartedAt  time.Time
+	EndsAt     time.Time
+	FinishedAt *time.Time
+	// Contributors は履歴の一覧ではnilとなる
+	Contributors []RaidContributor
+}
+
+type RaidContributor struct {
+	AccountID string
+	Name      string
+	Image     string
+	Minutes   float64
+	// Share は与えたダメージ全体に占める割合
+	Share float64
+}
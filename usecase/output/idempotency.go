@@ -0,0 +1,6 @@
+package output
+
+type IdempotentResponse struct {
+	StatusCode int
+	Body       []byte
+}
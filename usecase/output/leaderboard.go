@@ -0,0 +1,20 @@
+package output
+
+type Leaderboard struct {
+	Metric string
+	Period string
+	Scope  string
+	// PeriodStart は週・月のランキングの開始日(UTC)。全期間の場合は空
+	PeriodStart string
+	Entries     []LeaderboardEntry
+	// Me はランキングに載っていない場合にnilとなる
+	Me *LeaderboardEntry
+}
+
+type LeaderboardEntry struct {
+	Rank      int
+	AccountID string
+	Name      string
+	Image     string
+	Score     float64
+}
@@ -0,0 +1,52 @@
+package output
+
+import "time"
+
+type LootTable struct {
+	Key     string
+	Name    string
+	Source  string
+	Version string
+	Entries []LootEntry
+	Pity    []LootPityRule
+}
+
+type LootEntry struct {
+	Item   string
+	Name   string
+	Rarity string
+	Weight int
+	// Rate は天井を考慮しないドロップ率
+	Rate float64
+}
+
+type LootPityRule struct {
+	Rarity string
+	After  int
+}
+
+type LootDrop struct {
+	ID           string
+	TableKey     string
+	TableVersion string
+	// Seed はJSONの数値で精度が落ちないよう文字列にする
+	Seed       string
+	PityBefore map[string]int
+	Item       string
+	ItemName   string
+	Rarity     string
+	CreatedAt  time.Time
+}
+
+type InventoryItem struct {
+	Item   string
+	Name   string
+	Rarity string
+	Count  int
+}
+
+type LootVerification struct {
+	Drop         LootDrop
+	ReplayedItem string
+	Match        bool
+}
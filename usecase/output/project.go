@@ -0,0 +1,35 @@
+package output
+
+import "time"
+
+type Project struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
+
+type Task struct {
+	ID                 string
+	ProjectID          *string
+	Title              string
+	EstimatedPomodoros int
+	Completed          bool
+	CreatedAt          time.Time
+}
+
+type TaskTotal struct {
+	TaskID             string
+	ProjectID          *string
+	Title              string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
+
+type ProjectTotal struct {
+	ProjectID          string
+	Name               string
+	EstimatedPomodoros int
+	ActualPomodoros    int
+	FocusMinutes       float64
+}
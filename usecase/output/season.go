@@ -0,0 +1,65 @@
+package output
+
+import "time"
+
+type Season struct {
+	Key         string
+	Name        string
+	Status      string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	ClaimEndsAt time.Time
+	PremiumCost int
+	Tiers       []SeasonTier
+	Quests      []SeasonQuest
+	Bosses      []RaidBoss
+}
+
+type SeasonTier struct {
+	Tier    int
+	Exp     int
+	Free    SeasonReward
+	Premium SeasonReward
+}
+
+type SeasonReward struct {
+	Exp  int
+	Gold int
+}
+
+type SeasonQuest struct {
+	Key    string
+	Name   string
+	Kind   string
+	Target int
+	Exp    int
+}
+
+type SeasonProgress struct {
+	Season Season
+	Exp    int
+	// Tier は1段階目に届いていなければ0
+	Tier int
+	// NextTierExp は最後の段階まで到達している場合はnil
+	NextTierExp *int
+	Premium     bool
+	Quests      []SeasonQuestProgress
+	Claimed     []SeasonClaim
+	// Claimable は今受け取れる報酬。受け取り期限を過ぎると空になる
+	Claimable []SeasonClaim
+}
+
+type SeasonQuestProgress struct {
+	Key       string
+	Progress  int
+	Completed bool
+}
+
+type SeasonClaim struct {
+	Tier  int
+	Track string
+	Exp   int
+	Gold  int
+	// ClaimedAt は未受け取りの報酬ではnil
+	ClaimedAt *time.Time
+}
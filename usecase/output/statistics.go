@@ -0,0 +1,21 @@
+package output
+
+type DailyStat struct {
+	Date         string
+	FocusMinutes float64
+	BreakMinutes float64
+	FocusRatio   float64
+}
+
+type Cycle struct {
+	FocusCount        int
+	CompletedCycles   int
+	LongBreakInterval int
+	NextKind          string
+}
+
+type HeatmapDay struct {
+	Date         string
+	FocusMinutes float64
+	Level        int
+}
@@ -0,0 +1,25 @@
+package output
+
+import "time"
+
+type Sync struct {
+	Token          string
+	HasMore        bool
+	Results        []SyncResult
+	Times          []Time
+	DeletedTimeIDs []string
+	Breaks         []Break
+}
+
+type SyncResult struct {
+	ID     string
+	Status string
+	Reason string
+}
+
+type Break struct {
+	ID            string
+	Kind          string
+	Minutes       float64
+	ExecutionDate time.Time
+}
@@ -0,0 +1,18 @@
+package output
+
+import "time"
+
+type Time struct {
+	ID            string
+	FocusTime     float64
+	TaskID        *string
+	Note          string
+	Tags          []string
+	ExecutionDate time.Time
+}
+
+type TagTotal struct {
+	Name            string
+	ActualPomodoros int
+	FocusMinutes    float64
+}
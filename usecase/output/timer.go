@@ -0,0 +1,14 @@
+package output
+
+import "time"
+
+type Timer struct {
+	ID               string
+	Kind             string
+	Status           string
+	PlannedSeconds   int
+	ElapsedSeconds   int
+	RemainingSeconds int
+	StartedAt        *time.Time
+	ServerTime       time.Time
+}
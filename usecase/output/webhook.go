@@ -0,0 +1,22 @@
+package output
+
+import "time"
+
+type Webhook struct {
+	ID        string
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID           string
+	Event        string
+	Status       string
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	DeliveredAt  *time.Time
+	CreatedAt    time.Time
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type ProjectUsecase interface {
+	Create(ctx context.Context, email string, in input.Project) (output.Project, error)
+	List(ctx context.Context, email string) ([]output.Project, error)
+	Update(ctx context.Context, email, id string, in input.Project) (output.Project, error)
+	Delete(ctx context.Context, email, id string) error
+}
+
+type projectUsecase struct {
+	ar repository.AccountRepository
+	pr repository.ProjectRepository
+}
+
+func (p *projectUsecase) Create(ctx context.Context, email string, in input.Project) (output.Project, error) {
+	acc, err := p.findAccount(ctx, email)
+	if err != nil {
+		return output.Project{}, err
+	}
+
+	project, err := model.NewProject(model.GenerateProjectID(), acc.ID, in.Name)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Project{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := p.pr.Save(ctx, project); err != nil {
+		logger.Event(ctx, logger.ERROR, "create project failed", err)
+		return output.Project{}, err
+	}
+
+	return toProjectOutput(project), nil
+}
+
+func (p *projectUsecase) List(ctx context.Context, email string) ([]output.Project, error) {
+	acc, err := p.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := p.pr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find projects failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Project, 0, len(projects))
+	for _, project := range projects {
+		res = append(res, toProjectOutput(project))
+	}
+
+	return res, nil
+}
+
+func (p *projectUsecase) Update(ctx context.Context, email, id string, in input.Project) (output.Project, error) {
+	acc, err := p.findAccount(ctx, email)
+	if err != nil {
+		return output.Project{}, err
+	}
+
+	project, err := findOwnProject(ctx, p.pr, acc.ID, id)
+	if err != nil {
+		return output.Project{}, err
+	}
+
+	if err := project.Rename(in.Name); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Project{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := p.pr.Save(ctx, project); err != nil {
+		logger.Event(ctx, logger.ERROR, "update project failed", err)
+		return output.Project{}, err
+	}
+
+	return toProjectOutput(project), nil
+}
+
+func (p *projectUsecase) Delete(ctx context.Context, email, id string) error {
+	acc, err := p.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	project, err := findOwnProject(ctx, p.pr, acc.ID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := p.pr.Delete(ctx, project.ID); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete project failed", err)
+		return err
+	}
+
+	return nil
+}
+
+// findOwnProject は他のアカウントのプロジェクトを存在しないものとして扱う
+func findOwnProject(ctx context.Context, pr repository.ProjectRepository, accID model.AccountID, id string) (model.Project, error) {
+	projectID, err := model.NewProjectID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid project id", err)
+		return model.Project{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	project, err := pr.FindByID(ctx, projectID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.ERROR, "find project failed", err)
+		return model.Project{}, err
+	}
+
+	if err != nil || project.AccountID != accID {
+		err := errors.Newf("project not found: %s", id)
+		logger.Event(ctx, logger.INFO, "project not found", err)
+		return model.Project{}, apperr.NewApplicationError(apperr.ErrNotFound, "プロジェクトが見つかりません", err)
+	}
+
+	return project, nil
+}
+
+func (p *projectUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := p.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toProjectOutput(p model.Project) output.Project {
+	return output.Project{
+		ID:        p.ID.String(),
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func NewProjectUsecase(ar repository.AccountRepository, pr repository.ProjectRepository) ProjectUsecase {
+	return &projectUsecase{ar, pr}
+}
@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultRaidHistoryLimit = 20
+	maxRaidHistoryLimit     = 100
+)
+
+// RaidReviser は記録の修正をレイドへの貢献に反映する
+type RaidReviser interface {
+	// Withdraw は記録の修正や削除に合わせて、その記録による貢献のダメージを減らす。
+	// 勝利したレイドは報酬を分配済みのため、貢献した記録は減らせない。記録の修正と同じトランザクション内で呼び出す
+	Withdraw(ctx context.Context, record model.Time) error
+}
+
+type RaidUsecase interface {
+	RaidReviser
+	Bosses(ctx context.Context) []output.RaidBoss
+	// Start はリーダーと副リーダーのみ実行できる。制限時間を過ぎたレイドは敗北として終了させる
+	Start(ctx context.Context, email string, boss string) (output.Raid, error)
+	// Current は最後に開始したレイドと貢献の内訳を返す
+	Current(ctx context.Context, email string) (output.Raid, error)
+	Get(ctx context.Context, email string, id string) (output.Raid, error)
+	History(ctx context.Context, email string, in input.RaidHistory) ([]output.Raid, error)
+	// Attack は集中記録を所属するギルドの挑戦中のレイドへのダメージとして反映し、
+	// HPを削りきった場合は貢献したメンバーへ同じトランザクションで報酬を付与する。
+	// FocusSessionCompletedの購読者として登録する
+	Attack(ctx context.Context, ev model.DomainEvent) error
+}
+
+type raidUsecase struct {
+	ar       repository.AccountRepository
+	gr       repository.GuildRepository
+	gmr      repository.GuildMembershipRepository
+	rr       repository.RaidRepository
+	rcr      repository.RaidContributionRepository
+	tr       repository.TimeRepository
+	tx       repository.Transaction
+	rewarder Rewarder
+	seasons  SeasonCalendar
+}
+
+// Bosses は常設のボスに続けて、開催中のシーズン限定のボスを返す
+func (r *raidUsecase) Bosses(ctx context.Context) []output.RaidBoss {
+	res := make([]output.RaidBoss, 0, len(model.RaidBosses))
+	for _, b := range model.RaidBosses {
+		res = append(res, toRaidBossOutput(b, ""))
+	}
+	for _, s := range r.seasons.ActiveAt(time.Now()) {
+		for _, b := range s.Bosses {
+			res = append(res, toRaidBossOutput(b, s.Key))
+		}
+	}
+
+	return res
+}
+
+// findBoss はシーズン限定のボスは開催中のみ返す
+func (r *raidUsecase) findBoss(key string, now time.Time) (model.RaidBoss, error) {
+	if b, err := model.FindRaidBoss(key); err == nil {
+		return b, nil
+	}
+	for _, s := range r.seasons.ActiveAt(now) {
+		for _, b := range s.Bosses {
+			if b.Key == key {
+				return b, nil
+			}
+		}
+	}
+
+	return model.RaidBoss{}, errors.Newf("unknown raid boss: %s", key)
+}
+
+// bossName はデータから削除されたボスはキーをそのまま名前として返す
+func (r *raidUsecase) bossName(key string) string {
+	if b, err := model.FindRaidBoss(key); err == nil {
+		return b.Name
+	}
+	if b, ok := r.seasons.FindBoss(key); ok {
+		return b.Name
+	}
+	return key
+}
+
+func (r *raidUsecase) Start(ctx context.Context, email string, boss string) (output.Raid, error) {
+	acc, err := r.findAccount(ctx, email)
+	if err != nil {
+		return output.Raid{}, err
+	}
+
+	now := time.Now()
+	b, err := r.findBoss(boss, now)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Raid{}, apperr.NewApplicationError(apperr.ErrBadRequest, "ボスが正しくありません", err)
+	}
+
+	var raid model.Raid
+	err = r.tx.Do(ctx, func(ctx context.Context) error {
+		guild, me, err := lockGuildMembership(ctx, r.gr, r.gmr, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		if !me.CanManage() {
+			err := errors.New("only the leader or officers can start a raid")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrForbidden, "ギルドの操作権限がありません", err)
+		}
+
+		current, err := r.rr.FindActiveByGuildIDForUpdate(ctx, guild.ID)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+		if err == nil {
+			if current.StatusAt(now) == model.RaidActive {
+				err := errors.New("raid is already in progress")
+				logger.Event(ctx, logger.INFO, err.Error(), err)
+				return apperr.NewApplicationError(apperr.ErrConflict, "挑戦中のレイドがあります", err)
+			}
+
+			if err := current.Expire(now); err != nil {
+				return err
+			}
+			if err := r.rr.Update(ctx, current); err != nil {
+				return err
+			}
+		}
+
+		raid = model.NewRaid(model.GenerateRaidID(), guild.ID, b, now)
+		return r.rr.Create(ctx, raid)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.Raid{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "start raid failed", err)
+		return output.Raid{}, err
+	}
+
+	return r.toRaidOutput(raid, now, []output.RaidContributor{}), nil
+}
+
+func (r *raidUsecase) Current(ctx context.Context, email string) (output.Raid, error) {
+	acc, err := r.findAccount(ctx, email)
+	if err != nil {
+		return output.Raid{}, err
+	}
+
+	me, err := findGuildMembership(ctx, r.gmr, acc.ID)
+	if err != nil {
+		return output.Raid{}, err
+	}
+
+	raid, err := r.rr.FindLatestByGuildID(ctx, me.GuildID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "raid not found", err)
+			return output.Raid{}, apperr.NewApplicationError(apperr.ErrNotFound, "レイドが見つかりません", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find latest raid failed", err)
+		return output.Raid{}, err
+	}
+
+	return r.detail(ctx, raid)
+}
+
+func (r *raidUsecase) Get(ctx context.Context, email string, id string) (output.Raid, error) {
+	acc, err := r.findAccount(ctx, email)
+	if err != nil {
+		return output.Raid{}, err
+	}
+
+	me, err := findGuildMembership(ctx, r.gmr, acc.ID)
+	if err != nil {
+		return output.Raid{}, err
+	}
+
+	raidID, err := model.NewRaidID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Raid{}, apperr.NewApplicationError(apperr.ErrNotFound, "レイドが見つかりません", err)
+	}
+
+	raid, err := r.rr.FindByID(ctx, raidID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.ERROR, "find raid failed", err)
+		return output.Raid{}, err
+	}
+	// 他のギルドのレイドは存在しないものとして扱う
+	if err != nil || raid.GuildID != me.GuildID {
+		err := errors.New("raid not found")
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Raid{}, apperr.NewApplicationError(apperr.ErrNotFound, "レイドが見つかりません", err)
+	}
+
+	return r.detail(ctx, raid)
+}
+
+func (r *raidUsecase) History(ctx context.Context, email string, in input.RaidHistory) ([]output.Raid, error) {
+	acc, err := r.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := findGuildMembership(ctx, r.gmr, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Limit < 0 || in.Limit > maxRaidHistoryLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultRaidHistoryLimit
+	}
+
+	raids, err := r.rr.FindByGuildID(ctx, me.GuildID, limit, in.Offset)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find raids failed", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]output.Raid, 0, len(raids))
+	for _, raid := range raids {
+		res = append(res, r.toRaidOutput(raid, now, nil))
+	}
+
+	return res, nil
+}
+
+func (r *raidUsecase) Attack(ctx context.Context, ev model.DomainEvent) error {
+	completed, ok := ev.(model.FocusSessionCompleted)
+	if !ok {
+		return nil
+	}
+
+	m, err := r.gmr.FindByAccountID(ctx, completed.AccountID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+	// 加入前に集中した記録は数えない
+	if completed.ExecutionDate.Before(m.JoinedAt) {
+		return nil
+	}
+
+	return r.tx.Do(ctx, func(ctx context.Context) error {
+		// 記録の修正と同じく記録、レイドの順にロックし、削除された記録は数えず修正後の集中時間で数える
+		record, err := r.tr.FindByIDForUpdate(ctx, completed.TimeID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		raid, err := r.rr.FindActiveByGuildIDForUpdate(ctx, m.GuildID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				return nil
+			}
+			return err
+		}
+		if !raid.Accepts(completed.ExecutionDate) {
+			return nil
+		}
+
+		c := model.NewRaidContribution(raid.ID, completed.AccountID, record.ID, record.FocusTime, record.ExecutionDate)
+		created, err := r.rcr.Create(ctx, c)
+		if err != nil || !created {
+			return err
+		}
+
+		won, err := raid.Hit(c, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := r.rr.Update(ctx, raid); err != nil {
+			return err
+		}
+		if !won {
+			return nil
+		}
+
+		return r.distribute(ctx, raid)
+	})
+}
+
+func (r *raidUsecase) Withdraw(ctx context.Context, record model.Time) error {
+	contributions, err := r.rcr.FindByTimeID(ctx, record.ID)
+	if err != nil {
+		return err
+	}
+
+	minutes := record.FocusTime
+	if record.DeletedAt != nil {
+		minutes = 0
+	}
+
+	for _, c := range contributions {
+		raid, err := r.rr.FindByIDForUpdate(ctx, c.RaidID)
+		if err != nil {
+			return err
+		}
+		if raid.Status == model.RaidVictory && minutes < c.Minutes {
+			err := errors.Newf("time %s contributed to won raid %s", record.ID, raid.ID)
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "勝利したレイドに貢献した記録は減らせません", err)
+		}
+
+		if err := raid.Withdraw(&c, minutes); err != nil {
+			return err
+		}
+		if err := r.rr.Update(ctx, raid); err != nil {
+			return err
+		}
+
+		if c.Minutes > 0 {
+			err = r.rcr.Update(ctx, c)
+		} else {
+			err = r.rcr.Delete(ctx, c)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// distribute は勝利したレイドに貢献し、現在もギルドに所属しているメンバーに報酬を付与する。
+// トランザクション内で呼び出すこと
+func (r *raidUsecase) distribute(ctx context.Context, raid model.Raid) error {
+	contributors, err := r.rcr.Contributors(ctx, raid.ID)
+	if err != nil {
+		return err
+	}
+
+	members, err := r.gmr.FindByGuildID(ctx, raid.GuildID)
+	if err != nil {
+		return err
+	}
+	belongs := make(map[model.AccountID]bool, len(members))
+	for _, m := range members {
+		belongs[m.AccountID] = true
+	}
+
+	for _, c := range contributors {
+		if !belongs[c.AccountID] {
+			continue
+		}
+		if err := r.rewarder.Grant(ctx, c.AccountID, raid.Reward, model.NewRewardContext(model.RewardSourceRaid)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *raidUsecase) detail(ctx context.Context, raid model.Raid) (output.Raid, error) {
+	contributors, err := r.rcr.Contributors(ctx, raid.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find raid contributors failed", err)
+		return output.Raid{}, err
+	}
+
+	res := make([]output.RaidContributor, 0, len(contributors))
+	for _, c := range contributors {
+		acc, err := r.ar.FindByID(ctx, c.AccountID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find raid contributor account failed", err)
+			return output.Raid{}, err
+		}
+
+		res = append(res, output.RaidContributor{
+			AccountID: acc.ID.String(),
+			Name:      acc.Name,
+			Image:     acc.Image,
+			Minutes:   c.Minutes,
+			Share:     c.Minutes / raid.Damage,
+		})
+	}
+
+	return r.toRaidOutput(raid, time.Now(), res), nil
+}
+
+func (r *raidUsecase) toRaidOutput(raid model.Raid, now time.Time, contributors []output.RaidContributor) output.Raid {
+	return output.Raid{
+		ID:           raid.ID.String(),
+		Boss:         raid.Boss,
+		BossName:     r.bossName(raid.Boss),
+		MaxHP:        raid.MaxHP,
+		HP:           raid.HP(),
+		Status:       string(raid.StatusAt(now)),
+		RewardExp:    raid.Reward.Exp,
+		RewardGold:   raid.Reward.Gold,
+		StartedAt:    raid.StartedAt,
+		EndsAt:       raid.EndsAt,
+		FinishedAt:   raid.FinishedAt,
+		Contributors: contributors,
+	}
+}
+
+func toRaidBossOutput(b model.RaidBoss, season string) output.RaidBoss {
+	return output.RaidBoss{
+		Key:            b.Key,
+		Name:           b.Name,
+		HP:             b.HP,
+		TimeLimitHours: int(b.TimeLimit / time.Hour),
+		RewardExp:      b.Reward.Exp,
+		RewardGold:     b.Reward.Gold,
+		Season:         season,
+	}
+}
+
+func (r *raidUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := r.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewRaidUsecase(ar repository.AccountRepository, gr repository.GuildRepository, gmr repository.GuildMembershipRepository, rr repository.RaidRepository, rcr repository.RaidContributionRepository, tr repository.TimeRepository, tx repository.Transaction, rewarder Rewarder, seasons SeasonCalendar) RaidUsecase {
+	return &raidUsecase{ar, gr, gmr, rr, rcr, tr, tx, rewarder, seasons}
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Rewarder はキャラクターへの報酬付与を各ユースケースで共通化する。
+// 状態変更と同じトランザクション内で呼び出すこと
+type Rewarder interface {
+	// Grant は習得済みのスキルと連れて歩いている仲間の効果をrcの状況に応じて報酬に反映してから付与し、
+	// rc.Sourceに対応するドロップテーブルを抽選する。気絶中は何も付与しない
+	Grant(ctx context.Context, accID model.AccountID, reward model.Reward, rc model.RewardContext) error
+	// Adjust は記録の修正や目標の達成の取り消しで、rc.SourceIDに付与済みの報酬をGrantと同じ効果を反映したrewardまで減らす。
+	// 記録を削除した場合などはrewardをゼロ値とし、付与済みの報酬をそのまま取り消す。
+	// 付与後に習得したスキルや連れ替えた仲間で増えないよう、付与済みの報酬を超えて増額はしない
+	Adjust(ctx context.Context, accID model.AccountID, reward model.Reward, rc model.RewardContext) error
+	// Damage は集中の中断や目標の未達成によるダメージを与える
+	Damage(ctx context.Context, accID model.AccountID, damage int) error
+	Heal(ctx context.Context, accID model.AccountID, hp int) error
+	// Spend はゴールドを消費する。足りない場合は何も消費せずにエラーを返すため、事前に残高を確認すること
+	Spend(ctx context.Context, accID model.AccountID, gold int) error
+}
+
+type rewarder struct {
+	cr     repository.CharacterRepository
+	or     repository.OutboxRepository
+	lr     repository.RewardLedgerRepository
+	sr     repository.SettingsRepository
+	str    repository.StatisticsRepository
+	cmr    repository.CompanionRepository
+	looter Looter
+}
+
+func (r *rewarder) Grant(ctx context.Context, accID model.AccountID, reward model.Reward, rc model.RewardContext) error {
+	knockedOut := false
+	err := r.update(ctx, accID, rc.SourceID, func(c *model.Character) error {
+		if c.KnockedOut {
+			knockedOut = true
+			return nil
+		}
+
+		reward, err := r.apply(ctx, c, reward, rc)
+		if err != nil {
+			return err
+		}
+		c.GainExp(reward.Exp)
+		c.GainGold(reward.Gold)
+		return nil
+	})
+	if err != nil || knockedOut {
+		return err
+	}
+
+	return r.looter.Roll(ctx, accID, rc)
+}
+
+func (r *rewarder) Adjust(ctx context.Context, accID model.AccountID, reward model.Reward, rc model.RewardContext) error {
+	if rc.SourceID == "" {
+		return errors.New("source id is required to adjust reward")
+	}
+
+	return r.update(ctx, accID, rc.SourceID, func(c *model.Character) error {
+		granted, err := r.lr.SumBySourceID(ctx, accID, rc.SourceID)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		if reward != (model.Reward{}) {
+			if reward, err = r.apply(ctx, c, reward, rc); err != nil {
+				return err
+			}
+		}
+
+		delta := reward.Sub(granted)
+		delta = model.Reward{Exp: min(delta.Exp, 0), Gold: min(delta.Gold, 0)}
+		c.GainExp(delta.Exp)
+		c.LoseExp(-delta.Exp)
+		c.GainGold(delta.Gold)
+		c.LoseGold(-delta.Gold)
+		return nil
+	})
+}
+
+// apply は習得済みのスキルと連れて歩いている仲間の効果を報酬に反映する
+func (r *rewarder) apply(ctx context.Context, c *model.Character, reward model.Reward, rc model.RewardContext) (model.Reward, error) {
+	rules := c.RewardRules()
+	companion, err := r.cmr.FindActive(ctx, c.AccountID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		return model.Reward{}, err
+	}
+	rules = append(rules, companion.RewardRules()...)
+
+	if model.NeedsStreak(rules) {
+		streak, err := r.streak(ctx, c.AccountID)
+		if err != nil {
+			return model.Reward{}, err
+		}
+		rc.StreakDays = streak
+	}
+
+	return model.ApplyRewardRules(reward, rules, rc), nil
+}
+
+func (r *rewarder) Damage(ctx context.Context, accID model.AccountID, damage int) error {
+	return r.update(ctx, accID, "", func(c *model.Character) error {
+		c.TakeDamage(damage, time.Now())
+		return nil
+	})
+}
+
+func (r *rewarder) Heal(ctx context.Context, accID model.AccountID, hp int) error {
+	return r.update(ctx, accID, "", func(c *model.Character) error {
+		c.Heal(hp, time.Now())
+		return nil
+	})
+}
+
+func (r *rewarder) Spend(ctx context.Context, accID model.AccountID, gold int) error {
+	return r.update(ctx, accID, "", func(c *model.Character) error {
+		return c.SpendGold(gold)
+	})
+}
+
+// streak は同じトランザクション内で記録された集中も含めて数える
+func (r *rewarder) streak(ctx context.Context, accID model.AccountID) (int, error) {
+	settings, err := findSettings(ctx, r.sr, accID)
+	if err != nil {
+		return 0, err
+	}
+	loc := settings.Location()
+	today, tomorrow := model.GoalDaily.Range(time.Now(), loc)
+	return findStreak(ctx, r.str, accID, today, tomorrow, loc)
+}
+
+// update はsourceIDを指定した場合、その報酬に対する増減として台帳に残す
+func (r *rewarder) update(ctx context.Context, accID model.AccountID, sourceID string, fn func(c *model.Character) error) error {
+	c, err := r.cr.FindByAccountIDForUpdate(ctx, accID)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+		c = model.NewCharacter(accID)
+	}
+
+	exp, gold := c.Exp, c.Gold
+	if err := fn(&c); err != nil {
+		return err
+	}
+
+	if err := r.cr.Save(ctx, c); err != nil {
+		return err
+	}
+
+	// 経験値やゴールドの下限で切り捨てられた分は含めず、実際の増減を記録する
+	if entry := model.NewRewardEntry(accID, sourceID, c.Exp-exp, c.Gold-gold); !entry.IsZero() {
+		if err := r.lr.Create(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	return r.or.Save(ctx, c.PullEvents()...)
+}
+
+func NewRewarder(cr repository.CharacterRepository, or repository.OutboxRepository, lr repository.RewardLedgerRepository, sr repository.SettingsRepository, str repository.StatisticsRepository, cmr repository.CompanionRepository, looter Looter) Rewarder {
+	return &rewarder{cr, or, lr, sr, str, cmr, looter}
+}
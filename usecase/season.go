@@ -0,0 +1,493 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// SeasonCalendar は読み込み済みのシーズンを他のユースケースに提供する
+type SeasonCalendar interface {
+	// ActiveAt はatに開催中のシーズンを返す
+	ActiveAt(at time.Time) []model.Season
+	// FindBoss は終了したシーズンも含めてkeyのシーズン限定のボスを探す
+	FindBoss(key string) (model.RaidBoss, bool)
+}
+
+type SeasonUsecase interface {
+	SeasonCalendar
+	// Load はデータファイルからシーズンを読み込み、差し替える
+	Load(ctx context.Context) error
+	// Reload は管理者がデータファイルの変更を反映する
+	Reload(ctx context.Context, email string) ([]output.Season, error)
+	List(ctx context.Context) []output.Season
+	Get(ctx context.Context, email string, key string) (output.SeasonProgress, error)
+	// PurchasePremium は開催期間中のみ、ゴールドを消費してプレミアムパスを購入する
+	PurchasePremium(ctx context.Context, email string, key string) (output.SeasonProgress, error)
+	// Claim は到達済みで未受け取りの報酬をまとめて受け取る。終了後も受け取り期限までは受け取れる
+	Claim(ctx context.Context, email string, key string) ([]output.SeasonClaim, error)
+	// Record はFocusSessionCompletedとGoalAchievedの購読者として、
+	// 開催中のシーズンの活動として記録する
+	Record(ctx context.Context, ev model.DomainEvent) error
+}
+
+type seasonUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SeasonRepository
+	sar      repository.SeasonActivityRepository
+	spr      repository.SeasonPassRepository
+	scr      repository.SeasonClaimRepository
+	chr      repository.CharacterRepository
+	tx       repository.Transaction
+	rewarder Rewarder
+	audit    AuditUsecase
+
+	mu      sync.RWMutex
+	seasons []model.Season
+}
+
+func (s *seasonUsecase) Load(ctx context.Context) error {
+	_, err := s.load(ctx)
+	return err
+}
+
+func (s *seasonUsecase) load(ctx context.Context) (model.AuditDiff, error) {
+	seasons, err := s.sr.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	diff := model.AuditDiff{}
+	versions := make(map[string]string, len(s.seasons))
+	for _, season := range s.seasons {
+		versions[season.Key] = season.Version
+	}
+	for _, season := range seasons {
+		diff.Add(season.Key, versions[season.Key], season.Version)
+		delete(versions, season.Key)
+	}
+	for key, version := range versions {
+		diff.Add(key, version, "")
+	}
+
+	s.seasons = seasons
+	return diff, nil
+}
+
+func (s *seasonUsecase) Reload(ctx context.Context, email string) ([]output.Season, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.load(ctx)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "load seasons failed", err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "シーズンを読み込めませんでした", err)
+	}
+
+	s.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionReloadSeasons,
+		TargetType: model.AuditTargetSeason,
+		Diff:       diff,
+	})
+
+	return s.List(ctx), nil
+}
+
+func (s *seasonUsecase) List(ctx context.Context) []output.Season {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	res := make([]output.Season, 0, len(s.seasons))
+	for _, season := range s.seasons {
+		res = append(res, toSeasonOutput(season, now))
+	}
+
+	return res
+}
+
+func (s *seasonUsecase) ActiveAt(at time.Time) []model.Season {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var res []model.Season
+	for _, season := range s.seasons {
+		if season.Accepts(at) {
+			res = append(res, season)
+		}
+	}
+	return res
+}
+
+func (s *seasonUsecase) FindBoss(key string) (model.RaidBoss, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, season := range s.seasons {
+		for _, b := range season.Bosses {
+			if b.Key == key {
+				return b, true
+			}
+		}
+	}
+	return model.RaidBoss{}, false
+}
+
+func (s *seasonUsecase) Get(ctx context.Context, email string, key string) (output.SeasonProgress, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.SeasonProgress{}, err
+	}
+
+	season, err := s.findSeason(ctx, key)
+	if err != nil {
+		return output.SeasonProgress{}, err
+	}
+
+	res, err := s.progress(ctx, season, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find season progress failed", err)
+		return output.SeasonProgress{}, err
+	}
+
+	return res, nil
+}
+
+func (s *seasonUsecase) PurchasePremium(ctx context.Context, email string, key string) (output.SeasonProgress, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.SeasonProgress{}, err
+	}
+
+	season, err := s.findSeason(ctx, key)
+	if err != nil {
+		return output.SeasonProgress{}, err
+	}
+
+	now := time.Now()
+	if season.StatusAt(now) != model.SeasonActive {
+		err := errors.Newf("season %s is not active", season.Key)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.SeasonProgress{}, apperr.NewApplicationError(apperr.ErrConflict, "シーズンの開催期間外です", err)
+	}
+
+	var gold int
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		char, err := s.chr.FindByAccountIDForUpdate(ctx, acc.ID)
+		if err != nil {
+			if !errors.Is(err, apperr.ErrDataNotFound) {
+				return err
+			}
+			char = model.NewCharacter(acc.ID)
+		}
+		gold = char.Gold
+
+		created, err := s.spr.Create(ctx, model.NewSeasonPass(season, acc.ID, now))
+		if err != nil {
+			return err
+		}
+		if !created {
+			err := errors.New("premium pass has already been purchased")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "プレミアムパスは購入済みです", err)
+		}
+
+		if char.Gold < season.PremiumCost {
+			err := errors.Newf("insufficient gold: %d < %d", char.Gold, season.PremiumCost)
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "ゴールドが足りません", err)
+		}
+		if season.PremiumCost == 0 {
+			return nil
+		}
+		return s.rewarder.Spend(ctx, acc.ID, season.PremiumCost)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return output.SeasonProgress{}, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "purchase premium pass failed", err)
+		return output.SeasonProgress{}, err
+	}
+
+	diff := model.AuditDiff{}
+	diff.Add("premium", false, true)
+	diff.Add("gold", gold, gold-season.PremiumCost)
+	s.audit.Record(ctx, input.AuditEvent{
+		ActorID:    acc.ID,
+		Action:     model.AuditActionPurchaseSeasonPass,
+		TargetType: model.AuditTargetSeason,
+		TargetID:   season.Key,
+		Diff:       diff,
+	})
+
+	res, err := s.progress(ctx, season, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find season progress failed", err)
+		return output.SeasonProgress{}, err
+	}
+
+	return res, nil
+}
+
+func (s *seasonUsecase) Claim(ctx context.Context, email string, key string) ([]output.SeasonClaim, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	season, err := s.findSeason(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch season.StatusAt(now) {
+	case model.SeasonUpcoming:
+		err := errors.Newf("season %s has not started", season.Key)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrConflict, "シーズンが開始していません", err)
+	case model.SeasonClosed:
+		err := errors.Newf("claim period of season %s has ended", season.Key)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrConflict, "報酬の受け取り期限を過ぎています", err)
+	}
+
+	var claimed []model.SeasonClaim
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		// 気絶中はRewarderが何も付与しないため、受け取り済みにしないよう先に断る
+		char, err := s.chr.FindByAccountIDForUpdate(ctx, acc.ID)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+		if char.KnockedOut {
+			err := errors.New("knocked out character cannot claim rewards")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "気絶中は報酬を受け取れません", err)
+		}
+
+		tally, premium, already, err := s.standing(ctx, season, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		var total model.Reward
+		for _, c := range season.Unclaimed(acc.ID, season.Exp(tally), premium, already, now) {
+			created, err := s.scr.Create(ctx, c)
+			if err != nil {
+				return err
+			}
+			if !created {
+				continue
+			}
+			claimed = append(claimed, c)
+			total = total.Add(c.Reward)
+		}
+		if total == (model.Reward{}) {
+			return nil
+		}
+
+		return s.rewarder.Grant(ctx, acc.ID, total, model.NewRewardContext(model.RewardSourceSeason))
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return nil, appErr
+		}
+		logger.Event(ctx, logger.ERROR, "claim season rewards failed", err)
+		return nil, err
+	}
+
+	res := make([]output.SeasonClaim, 0, len(claimed))
+	for _, c := range claimed {
+		res = append(res, toSeasonClaimOutput(c, true))
+	}
+
+	return res, nil
+}
+
+func (s *seasonUsecase) Record(ctx context.Context, ev model.DomainEvent) error {
+	switch ev := ev.(type) {
+	case model.FocusSessionCompleted:
+		for _, season := range s.ActiveAt(ev.ExecutionDate) {
+			if _, err := s.sar.Create(ctx, model.NewFocusSeasonActivity(season.Key, ev)); err != nil {
+				return err
+			}
+		}
+	case model.GoalAchieved:
+		for _, season := range s.ActiveAt(ev.At) {
+			if _, err := s.sar.Create(ctx, model.NewGoalSeasonActivity(season.Key, ev)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// standing は開催期間中の活動の累計とプレミアムパスの有無、受け取り済みの報酬を返す
+func (s *seasonUsecase) standing(ctx context.Context, season model.Season, accID model.AccountID) (model.SeasonTally, bool, []model.SeasonClaim, error) {
+	tally, err := s.sar.Tally(ctx, season.Key, accID)
+	if err != nil {
+		return model.SeasonTally{}, false, nil, err
+	}
+
+	premium := true
+	if _, err := s.spr.Find(ctx, season.Key, accID); err != nil {
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return model.SeasonTally{}, false, nil, err
+		}
+		premium = false
+	}
+
+	claimed, err := s.scr.Find(ctx, season.Key, accID)
+	if err != nil {
+		return model.SeasonTally{}, false, nil, err
+	}
+
+	return tally, premium, claimed, nil
+}
+
+func (s *seasonUsecase) progress(ctx context.Context, season model.Season, accID model.AccountID) (output.SeasonProgress, error) {
+	tally, premium, claimed, err := s.standing(ctx, season, accID)
+	if err != nil {
+		return output.SeasonProgress{}, err
+	}
+
+	now := time.Now()
+	exp := season.Exp(tally)
+	tier := season.TierAt(exp)
+	res := output.SeasonProgress{
+		Season:    toSeasonOutput(season, now),
+		Exp:       exp,
+		Tier:      tier,
+		Premium:   premium,
+		Quests:    make([]output.SeasonQuestProgress, 0, len(season.Quests)),
+		Claimed:   make([]output.SeasonClaim, 0, len(claimed)),
+		Claimable: []output.SeasonClaim{},
+	}
+	if tier < len(season.Tiers) {
+		next := season.Tiers[tier].Exp
+		res.NextTierExp = &next
+	}
+
+	for _, q := range season.Quests {
+		res.Quests = append(res.Quests, output.SeasonQuestProgress{
+			Key:       q.Key,
+			Progress:  min(q.Progress(tally), q.Target),
+			Completed: q.Completed(tally),
+		})
+	}
+
+	for _, c := range claimed {
+		res.Claimed = append(res.Claimed, toSeasonClaimOutput(c, true))
+	}
+
+	if status := season.StatusAt(now); status == model.SeasonActive || status == model.SeasonEnded {
+		for _, c := range season.Unclaimed(accID, exp, premium, claimed, now) {
+			res.Claimable = append(res.Claimable, toSeasonClaimOutput(c, false))
+		}
+	}
+
+	return res, nil
+}
+
+func (s *seasonUsecase) findSeason(ctx context.Context, key string) (model.Season, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, season := range s.seasons {
+		if season.Key == key {
+			return season, nil
+		}
+	}
+
+	err := errors.Newf("season not found: %s", key)
+	logger.Event(ctx, logger.INFO, "season not found", err)
+	return model.Season{}, apperr.NewApplicationError(apperr.ErrNotFound, "シーズンが見つかりません", err)
+}
+
+func (s *seasonUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := s.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toSeasonOutput(s model.Season, now time.Time) output.Season {
+	tiers := make([]output.SeasonTier, 0, len(s.Tiers))
+	for _, t := range s.Tiers {
+		tiers = append(tiers, output.SeasonTier{
+			Tier:    t.Tier,
+			Exp:     t.Exp,
+			Free:    output.SeasonReward{Exp: t.Free.Exp, Gold: t.Free.Gold},
+			Premium: output.SeasonReward{Exp: t.Premium.Exp, Gold: t.Premium.Gold},
+		})
+	}
+
+	quests := make([]output.SeasonQuest, 0, len(s.Quests))
+	for _, q := range s.Quests {
+		quests = append(quests, output.SeasonQuest{
+			Key:    q.Key,
+			Name:   q.Name,
+			Kind:   string(q.Kind),
+			Target: q.Target,
+			Exp:    q.Exp,
+		})
+	}
+
+	bosses := make([]output.RaidBoss, 0, len(s.Bosses))
+	for _, b := range s.Bosses {
+		bosses = append(bosses, toRaidBossOutput(b, s.Key))
+	}
+
+	return output.Season{
+		Key:         s.Key,
+		Name:        s.Name,
+		Status:      string(s.StatusAt(now)),
+		StartsAt:    s.StartsAt,
+		EndsAt:      s.EndsAt,
+		ClaimEndsAt: s.ClaimEndsAt,
+		PremiumCost: s.PremiumCost,
+		Tiers:       tiers,
+		Quests:      quests,
+		Bosses:      bosses,
+	}
+}
+
+func toSeasonClaimOutput(c model.SeasonClaim, claimed bool) output.SeasonClaim {
+	res := output.SeasonClaim{
+		Tier:  c.Tier,
+		Track: string(c.Track),
+		Exp:   c.Reward.Exp,
+		Gold:  c.Reward.Gold,
+	}
+	if claimed {
+		res.ClaimedAt = &c.ClaimedAt
+	}
+	return res
+}
+
+func NewSeasonUsecase(ar repository.AccountRepository, sr repository.SeasonRepository, sar repository.SeasonActivityRepository, spr repository.SeasonPassRepository, scr repository.SeasonClaimRepository, chr repository.CharacterRepository, tx repository.Transaction, rewarder Rewarder, audit AuditUsecase) SeasonUsecase {
+	return &seasonUsecase{ar: ar, sr: sr, sar: sar, spr: spr, scr: scr, chr: chr, tx: tx, rewarder: rewarder, audit: audit}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+
+	"github.com/cockroachdb/errors"
+)
+
+// SessionRecorder は集中・休憩の記録とそれに伴うサイクルの進行、イベント・報酬を各ユースケースで共通化する。
+// トランザクション内で呼び出すこと
+type SessionRecorder interface {
+	RecordFocus(ctx context.Context, t model.Time) error
+	// RecordBreak はintervalを長い休憩までの集中回数としてサイクルを進める
+	RecordBreak(ctx context.Context, b model.Break, interval int) error
+}
+
+type sessionRecorder struct {
+	tr       repository.TimeRepository
+	br       repository.BreakRepository
+	cr       repository.CycleRepository
+	or       repository.OutboxRepository
+	rewarder Rewarder
+}
+
+func (s *sessionRecorder) RecordFocus(ctx context.Context, t model.Time) error {
+	if err := s.tr.Create(ctx, t); err != nil {
+		return err
+	}
+
+	if err := s.or.Save(ctx, t.PullEvents()...); err != nil {
+		return err
+	}
+
+	cycle, err := s.findCycle(ctx, t.AccountID)
+	if err != nil {
+		return err
+	}
+	cycle.RecordFocus()
+	if err := s.cr.Save(ctx, cycle); err != nil {
+		return err
+	}
+
+	return s.rewarder.Grant(ctx, t.AccountID, model.NewFocusReward(t), model.NewFocusRewardContext(t))
+}
+
+func (s *sessionRecorder) RecordBreak(ctx context.Context, b model.Break, interval int) error {
+	if err := s.br.Create(ctx, b); err != nil {
+		return err
+	}
+
+	cycle, err := s.findCycle(ctx, b.AccountID)
+	if err != nil {
+		return err
+	}
+	completed := cycle.RecordBreak(b.Kind, interval)
+	if err := s.cr.Save(ctx, cycle); err != nil {
+		return err
+	}
+
+	events := append(b.PullEvents(), cycle.PullEvents()...)
+	if err := s.or.Save(ctx, events...); err != nil {
+		return err
+	}
+
+	// 気絶中でも休憩で回復できるよう、報酬より先にHPを回復する
+	if err := s.rewarder.Heal(ctx, b.AccountID, model.BreakRegen(b)); err != nil {
+		return err
+	}
+
+	reward := model.NewBreakReward(b)
+	if completed {
+		reward = reward.Add(model.NewCycleReward())
+	}
+	return s.rewarder.Grant(ctx, b.AccountID, reward, model.NewBreakRewardContext(b))
+}
+
+func (s *sessionRecorder) findCycle(ctx context.Context, accID model.AccountID) (model.Cycle, error) {
+	cycle, err := s.cr.FindByAccountIDForUpdate(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return model.NewCycle(accID), nil
+		}
+		return model.Cycle{}, err
+	}
+	return cycle, nil
+}
+
+func NewSessionRecorder(tr repository.TimeRepository, br repository.BreakRepository, cr repository.CycleRepository, or repository.OutboxRepository, rewarder Rewarder) SessionRecorder {
+	return &sessionRecorder{tr, br, cr, or, rewarder}
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type SettingsUsecase interface {
+	Get(ctx context.Context, email string) (output.Settings, error)
+	Update(ctx context.Context, email string, input input.Settings) (output.Settings, error)
+}
+
+type settingsUsecase struct {
+	ar repository.AccountRepository
+	sr repository.SettingsRepository
+}
+
+func (s *settingsUsecase) Get(ctx context.Context, email string) (output.Settings, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.Settings{}, err
+	}
+
+	settings, err := findSettings(ctx, s.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.Settings{}, err
+	}
+
+	return toSettingsOutput(settings), nil
+}
+
+func (s *settingsUsecase) Update(ctx context.Context, email string, in input.Settings) (output.Settings, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.Settings{}, err
+	}
+
+	settings, err := model.NewSettings(
+		acc.ID,
+		in.FocusMinutes,
+		in.ShortBreakMinutes,
+		in.LongBreakMinutes,
+		in.LongBreakInterval,
+		in.AutoStartBreaks,
+		in.AutoStartFocus,
+		in.NotifySound,
+		in.NotifyDesktop,
+		in.Timezone,
+	)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Settings{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := s.sr.Save(ctx, settings); err != nil {
+		logger.Event(ctx, logger.ERROR, "save settings failed", err)
+		return output.Settings{}, err
+	}
+
+	return toSettingsOutput(settings), nil
+}
+
+func (s *settingsUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := s.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// findSettings は設定が保存されていない場合に既定値を返す
+func findSettings(ctx context.Context, sr repository.SettingsRepository, accID model.AccountID) (model.Settings, error) {
+	settings, err := sr.FindByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return model.DefaultSettings(accID), nil
+		}
+		return model.Settings{}, err
+	}
+	return settings, nil
+}
+
+func toSettingsOutput(s model.Settings) output.Settings {
+	return output.Settings{
+		FocusMinutes:      s.FocusMinutes,
+		ShortBreakMinutes: s.ShortBreakMinutes,
+		LongBreakMinutes:  s.LongBreakMinutes,
+		LongBreakInterval: s.LongBreakInterval,
+		AutoStartBreaks:   s.AutoStartBreaks,
+		AutoStartFocus:    s.AutoStartFocus,
+		NotifySound:       s.NotifySound,
+		NotifyDesktop:     s.NotifyDesktop,
+		Timezone:          s.Timezone,
+	}
+}
+
+func NewSettingsUsecase(ar repository.AccountRepository, sr repository.SettingsRepository) SettingsUsecase {
+	return &settingsUsecase{ar, sr}
+}
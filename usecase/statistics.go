@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	dateLayout         = "2006-01-02"
+	defaultStatsDays   = 7
+	maxStatsPeriodDays = 366
+	minHeatmapYear     = 2000
+)
+
+// HeatmapCache はアカウントごとにヒートマップの集計結果を保持する
+type HeatmapCache interface {
+	// Get はversion時点で集計された結果があれば返す
+	Get(accID model.AccountID, version time.Time, year int, loc *time.Location) ([]model.HeatmapDay, bool)
+	// Set はversionが変わっていれば、そのアカウントの古い結果を破棄してから保存する
+	Set(accID model.AccountID, version time.Time, year int, loc *time.Location, days []model.HeatmapDay)
+}
+
+type StatisticsUsecase interface {
+	Daily(ctx context.Context, email string, in input.StatisticsPeriod) ([]output.DailyStat, error)
+	Cycle(ctx context.Context, email string) (output.Cycle, error)
+	Tasks(ctx context.Context, email string) ([]output.TaskTotal, error)
+	Projects(ctx context.Context, email string) ([]output.ProjectTotal, error)
+	Tags(ctx context.Context, email string) ([]output.TagTotal, error)
+	// Heatmap は集中時間の最終更新日時が変わるまで、集計結果をアカウントごとにキャッシュする
+	Heatmap(ctx context.Context, email string, in input.Heatmap) ([]output.HeatmapDay, error)
+}
+
+type statisticsUsecase struct {
+	ar  repository.AccountRepository
+	sr  repository.SettingsRepository
+	cr  repository.CycleRepository
+	str repository.StatisticsRepository
+	tr  repository.TimeRepository
+	hc  HeatmapCache
+}
+
+func (s *statisticsUsecase) Daily(ctx context.Context, email string, in input.StatisticsPeriod) ([]output.DailyStat, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, from, to, err := parseStatisticsPeriod(in, time.Now())
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "集計期間が正しくありません", err)
+	}
+
+	stats, err := s.str.Daily(ctx, acc.ID, from, to, loc)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "aggregate daily statistics failed", err)
+		return nil, err
+	}
+
+	res := make([]output.DailyStat, 0, len(stats))
+	for _, st := range stats {
+		res = append(res, output.DailyStat{
+			Date:         st.Date.Format(dateLayout),
+			FocusMinutes: st.FocusMinutes,
+			BreakMinutes: st.BreakMinutes,
+			FocusRatio:   st.FocusRatio(),
+		})
+	}
+
+	return res, nil
+}
+
+func (s *statisticsUsecase) Cycle(ctx context.Context, email string) (output.Cycle, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.Cycle{}, err
+	}
+
+	settings, err := findSettings(ctx, s.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.Cycle{}, err
+	}
+
+	cycle, err := s.cr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.ERROR, "find cycle failed", err)
+			return output.Cycle{}, err
+		}
+		cycle = model.NewCycle(acc.ID)
+	}
+
+	return output.Cycle{
+		FocusCount:        cycle.FocusCount,
+		CompletedCycles:   cycle.CompletedCycles,
+		LongBreakInterval: settings.LongBreakInterval,
+		NextKind:          string(cycle.NextKind(settings.LongBreakInterval)),
+	}, nil
+}
+
+func (s *statisticsUsecase) Tasks(ctx context.Context, email string) ([]output.TaskTotal, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.str.TaskTotals(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "aggregate task totals failed", err)
+		return nil, err
+	}
+
+	res := make([]output.TaskTotal, 0, len(totals))
+	for _, t := range totals {
+		var projectID *string
+		if t.ProjectID != nil {
+			id := t.ProjectID.String()
+			projectID = &id
+		}
+
+		res = append(res, output.TaskTotal{
+			TaskID:             t.TaskID.String(),
+			ProjectID:          projectID,
+			Title:              t.Title,
+			EstimatedPomodoros: t.EstimatedPomodoros,
+			ActualPomodoros:    t.ActualPomodoros,
+			FocusMinutes:       t.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *statisticsUsecase) Projects(ctx context.Context, email string) ([]output.ProjectTotal, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.str.ProjectTotals(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "aggregate project totals failed", err)
+		return nil, err
+	}
+
+	res := make([]output.ProjectTotal, 0, len(totals))
+	for _, p := range totals {
+		res = append(res, output.ProjectTotal{
+			ProjectID:          p.ProjectID.String(),
+			Name:               p.Name,
+			EstimatedPomodoros: p.EstimatedPomodoros,
+			ActualPomodoros:    p.ActualPomodoros,
+			FocusMinutes:       p.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *statisticsUsecase) Tags(ctx context.Context, email string) ([]output.TagTotal, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.str.TagTotals(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "aggregate tag totals failed", err)
+		return nil, err
+	}
+
+	res := make([]output.TagTotal, 0, len(totals))
+	for _, t := range totals {
+		res = append(res, output.TagTotal{
+			Name:            t.Name,
+			ActualPomodoros: t.ActualPomodoros,
+			FocusMinutes:    t.FocusMinutes,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *statisticsUsecase) Heatmap(ctx context.Context, email string, in input.Heatmap) ([]output.HeatmapDay, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, year, err := parseHeatmapYear(in, time.Now())
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "集計する年が正しくありません", err)
+	}
+
+	version, err := s.tr.LatestUpdatedAt(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find latest time update failed", err)
+		return nil, err
+	}
+
+	days, ok := s.hc.Get(acc.ID, version, year, loc)
+	if !ok {
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		stats, err := s.str.DailyFocus(ctx, acc.ID, from, from.AddDate(1, 0, 0), loc)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "aggregate heatmap failed", err)
+			return nil, err
+		}
+
+		days = model.NewHeatmap(year, loc, stats)
+		s.hc.Set(acc.ID, version, year, loc, days)
+	}
+
+	res := make([]output.HeatmapDay, 0, len(days))
+	for _, d := range days {
+		res = append(res, output.HeatmapDay{
+			Date:         d.Date.Format(dateLayout),
+			FocusMinutes: d.FocusMinutes,
+			Level:        d.Level,
+		})
+	}
+
+	return res, nil
+}
+
+// parseTimezone は空の場合にUTCを返す
+func parseTimezone(tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timezone")
+	}
+	return loc, nil
+}
+
+// parseHeatmapYear は年が指定されていなければ今年(指定されたタイムゾーン基準)を返す
+func parseHeatmapYear(in input.Heatmap, now time.Time) (*time.Location, int, error) {
+	loc, err := parseTimezone(in.Timezone)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	year := now.In(loc).Year()
+	if in.Year != "" {
+		y, err := strconv.Atoi(in.Year)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "invalid year")
+		}
+		year = y
+	}
+
+	if year < minHeatmapYear || year > now.In(loc).Year()+1 {
+		return nil, 0, errors.Newf("year must be between %d and %d", minHeatmapYear, now.In(loc).Year()+1)
+	}
+
+	return loc, year, nil
+}
+
+// parseStatisticsPeriod は日付の範囲を指定されたタイムゾーンでの[from, to)に変換する
+func parseStatisticsPeriod(in input.StatisticsPeriod, now time.Time) (*time.Location, time.Time, time.Time, error) {
+	loc, err := parseTimezone(in.Timezone)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	today := now.In(loc)
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+	if in.To != "" {
+		t, err := time.ParseInLocation(dateLayout, in.To, loc)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, errors.Wrap(err, "invalid to")
+		}
+		to = t
+	}
+	to = to.AddDate(0, 0, 1)
+
+	from := to.AddDate(0, 0, -defaultStatsDays)
+	if in.From != "" {
+		f, err := time.ParseInLocation(dateLayout, in.From, loc)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, errors.Wrap(err, "invalid from")
+		}
+		from = f
+	}
+
+	if !from.Before(to) {
+		return nil, time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+	if from.AddDate(0, 0, maxStatsPeriodDays).Before(to) {
+		return nil, time.Time{}, time.Time{}, errors.Newf("period must be %d days or less", maxStatsPeriodDays)
+	}
+
+	return loc, from, to, nil
+}
+
+func (s *statisticsUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := s.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewStatisticsUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, cr repository.CycleRepository, str repository.StatisticsRepository, tr repository.TimeRepository, hc HeatmapCache) StatisticsUsecase {
+	return &statisticsUsecase{ar, sr, cr, str, tr, hc}
+}
@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const syncDeltaLimit = 500
+
+type SyncUsecase interface {
+	// Sync はオフライン中に記録されたセッションを取り込み、前回の同期以降のサーバー側の変更を返す。
+	// 同じIDのセッションは何度送られても一度しか記録せず、既存の記録と時間帯が重なる集中のセッションは拒否する
+	Sync(ctx context.Context, email string, in input.Sync) (output.Sync, error)
+}
+
+type syncUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	tkr      repository.TaskRepository
+	tr       repository.TimeRepository
+	br       repository.BreakRepository
+	tx       repository.Transaction
+	recorder SessionRecorder
+}
+
+func (s *syncUsecase) Sync(ctx context.Context, email string, in input.Sync) (output.Sync, error) {
+	acc, err := s.findAccount(ctx, email)
+	if err != nil {
+		return output.Sync{}, err
+	}
+
+	since, err := model.ParseSyncToken(in.Token)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Sync{}, apperr.NewApplicationError(apperr.ErrBadRequest, "同期トークンが正しくありません", err)
+	}
+
+	if len(in.Sessions) > model.MaxSyncBatchSize {
+		err := errors.Newf("sessions are limited to %d per sync", model.MaxSyncBatchSize)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Sync{}, apperr.NewApplicationError(apperr.ErrBadRequest, "一度に同期できるセッション数を超えています", err)
+	}
+
+	settings, err := findSettings(ctx, s.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.Sync{}, err
+	}
+
+	// サイクルの進行が端末での順序と一致するよう、終了日時の順に取り込む
+	sessions := slices.Clone(in.Sessions)
+	slices.SortStableFunc(sessions, func(a, b input.SyncSession) int {
+		return a.EndedAt.Compare(b.EndedAt)
+	})
+
+	now := time.Now()
+	res := output.Sync{Results: make([]output.SyncResult, 0, len(sessions))}
+	for _, session := range sessions {
+		res.Results = append(res.Results, s.apply(ctx, acc.ID, settings, session, now))
+	}
+
+	if err := s.delta(ctx, acc.ID, since, now, &res); err != nil {
+		logger.Event(ctx, logger.ERROR, "find sync delta failed", err)
+		return output.Sync{}, err
+	}
+
+	return res, nil
+}
+
+// apply は1件のセッションを個別のトランザクションで取り込み、失敗しても他のセッションに影響させない
+func (s *syncUsecase) apply(ctx context.Context, accID model.AccountID, settings model.Settings, session input.SyncSession, now time.Time) output.SyncResult {
+	result := output.SyncResult{ID: session.ID}
+	reject := func(reason string, err error) output.SyncResult {
+		logger.Event(ctx, logger.INFO, "sync session rejected", err)
+		result.Status = string(model.SyncRejected)
+		result.Reason = reason
+		return result
+	}
+
+	kind, err := model.NewSessionKind(session.Kind)
+	if err != nil {
+		return reject("invalid_kind", err)
+	}
+	if err := model.ValidateOfflineSession(session.EndedAt, now); err != nil {
+		return reject("invalid_ended_at", err)
+	}
+	if err := settings.ValidateSessionMinutes(kind, session.Minutes); err != nil {
+		return reject("exceeds_settings", err)
+	}
+
+	var status model.SyncStatus
+	if kind.IsBreak() {
+		status, result.Reason, err = s.applyBreak(ctx, accID, settings, kind, session)
+	} else {
+		status, result.Reason, err = s.applyFocus(ctx, accID, session)
+	}
+	if err != nil {
+		if status == model.SyncRejected {
+			return reject(result.Reason, err)
+		}
+		logger.Event(ctx, logger.ERROR, "sync session failed", err)
+		status = model.SyncFailed
+	}
+
+	result.Status = string(status)
+	return result
+}
+
+func (s *syncUsecase) applyFocus(ctx context.Context, accID model.AccountID, session input.SyncSession) (model.SyncStatus, string, error) {
+	id, err := model.NewTimeID(session.ID)
+	if err != nil {
+		return model.SyncRejected, "invalid_id", err
+	}
+
+	// 端末がオフライン中にタスクが削除されていた場合は、タスクに紐付けずに記録する
+	var reason string
+	var task *model.TaskID
+	if session.TaskID != "" {
+		found, err := s.findTask(ctx, accID, session.TaskID)
+		if err != nil {
+			return model.SyncFailed, "", err
+		}
+		if found != nil {
+			task = &found.ID
+		} else {
+			reason = "task_not_found"
+		}
+	}
+
+	record, err := model.NewTimeAt(id, session.Minutes, accID, task, session.EndedAt)
+	if err != nil {
+		return model.SyncRejected, "invalid_minutes", err
+	}
+	if err := record.Annotate(session.Note, session.Tags); err != nil {
+		return model.SyncRejected, "invalid_annotation", err
+	}
+
+	var status model.SyncStatus
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		owner, err := s.tr.FindAccountIDByID(ctx, id)
+		if err == nil {
+			status, reason = duplicateStatus(owner, accID)
+			return nil
+		}
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		// 同じ時間帯に集中した記録は二重に数えない。同じ同期で送られたセッションも先に取り込んだものと比べる
+		overlapped, err := s.tr.ExistsOverlapping(ctx, record)
+		if err != nil {
+			return err
+		}
+		if overlapped {
+			status, reason = model.SyncRejected, "overlaps_existing"
+			return nil
+		}
+
+		status = model.SyncCreated
+		return s.recorder.RecordFocus(ctx, record)
+	})
+	if err != nil {
+		return model.SyncFailed, "", err
+	}
+	if status == model.SyncRejected {
+		return status, reason, errors.Newf("time %s rejected: %s", id, reason)
+	}
+
+	return status, reason, nil
+}
+
+func (s *syncUsecase) applyBreak(ctx context.Context, accID model.AccountID, settings model.Settings, kind model.SessionKind, session input.SyncSession) (model.SyncStatus, string, error) {
+	id, err := model.NewBreakID(session.ID)
+	if err != nil {
+		return model.SyncRejected, "invalid_id", err
+	}
+
+	record, err := model.NewBreakAt(id, accID, kind, session.Minutes, session.EndedAt)
+	if err != nil {
+		return model.SyncRejected, "invalid_minutes", err
+	}
+
+	var status model.SyncStatus
+	var reason string
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		owner, err := s.br.FindAccountIDByID(ctx, id)
+		if err == nil {
+			status, reason = duplicateStatus(owner, accID)
+			return nil
+		}
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		status = model.SyncCreated
+		return s.recorder.RecordBreak(ctx, record, settings.LongBreakInterval)
+	})
+	if err != nil {
+		return model.SyncFailed, "", err
+	}
+	if status == model.SyncRejected {
+		return status, reason, errors.Newf("break id conflict: %s", id)
+	}
+
+	return status, reason, nil
+}
+
+// duplicateStatus は既に記録済みのIDに対する結果を返す。他のアカウントのIDと衝突した場合は拒否する
+func duplicateStatus(owner, accID model.AccountID) (model.SyncStatus, string) {
+	if owner != accID {
+		return model.SyncRejected, "id_conflict"
+	}
+	return model.SyncDuplicate, ""
+}
+
+// findTask は自分のタスクが見つからない場合にnilを返す
+func (s *syncUsecase) findTask(ctx context.Context, accID model.AccountID, id string) (*model.Task, error) {
+	taskID, err := model.NewTaskID(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	task, err := s.tkr.FindByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if task.AccountID != accID {
+		return nil, nil
+	}
+	return &task, nil
+}
+
+// delta はsince以降のサーバー側の変更を返す。件数が上限に達した場合は続きから取得するためのトークンを返す
+func (s *syncUsecase) delta(ctx context.Context, accID model.AccountID, since model.SyncCursor, now time.Time, res *output.Sync) error {
+	times, err := s.tr.FindChangedSince(ctx, accID, since.Times, syncDeltaLimit)
+	if err != nil {
+		return err
+	}
+	breaks, err := s.br.FindCreatedSince(ctx, accID, since.Breaks, syncDeltaLimit)
+	if err != nil {
+		return err
+	}
+
+	// 記録と休憩はそれぞれ返した最後の位置から続きを取得する
+	next := model.SyncCursor{Times: model.NextSyncPosition(now), Breaks: model.NextSyncPosition(now)}
+	if len(times) == syncDeltaLimit {
+		last := times[len(times)-1]
+		next.Times = model.SyncPosition{At: last.UpdatedAt, ID: last.ID.String()}
+		res.HasMore = true
+	}
+	if len(breaks) == syncDeltaLimit {
+		last := breaks[len(breaks)-1]
+		next.Breaks = model.SyncPosition{At: last.CreatedAt, ID: last.ID.String()}
+		res.HasMore = true
+	}
+	res.Token = next.Encode()
+
+	res.Times = []output.Time{}
+	res.DeletedTimeIDs = []string{}
+	for _, t := range times {
+		if t.DeletedAt != nil {
+			res.DeletedTimeIDs = append(res.DeletedTimeIDs, t.ID.String())
+			continue
+		}
+		res.Times = append(res.Times, toTimeOutput(t))
+	}
+
+	res.Breaks = []output.Break{}
+	for _, b := range breaks {
+		res.Breaks = append(res.Breaks, output.Break{
+			ID:            b.ID.String(),
+			Kind:          string(b.Kind),
+			Minutes:       b.Minutes,
+			ExecutionDate: b.ExecutionDate,
+		})
+	}
+
+	return nil
+}
+
+func (s *syncUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := s.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func NewSyncUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, tkr repository.TaskRepository, tr repository.TimeRepository, br repository.BreakRepository, tx repository.Transaction, recorder SessionRecorder) SyncUsecase {
+	return &syncUsecase{ar, sr, tkr, tr, br, tx, recorder}
+}
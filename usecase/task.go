@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+
+	"github.com/cockroachdb/errors"
+)
+
+type TaskUsecase interface {
+	Create(ctx context.Context, email string, in input.Task) (output.Task, error)
+	// List はprojectIDが空でない場合、そのプロジェクトのタスクに絞り込む
+	List(ctx context.Context, email, projectID string) ([]output.Task, error)
+	Update(ctx context.Context, email, id string, in input.Task) (output.Task, error)
+	Delete(ctx context.Context, email, id string) error
+}
+
+type taskUsecase struct {
+	ar repository.AccountRepository
+	pr repository.ProjectRepository
+	tr repository.TaskRepository
+}
+
+func (t *taskUsecase) Create(ctx context.Context, email string, in input.Task) (output.Task, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return output.Task{}, err
+	}
+
+	projectID, err := t.projectID(ctx, acc.ID, in.ProjectID)
+	if err != nil {
+		return output.Task{}, err
+	}
+
+	task, err := model.NewTask(model.GenerateTaskID(), acc.ID, projectID, in.Title, in.EstimatedPomodoros)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Task{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := t.tr.Save(ctx, task); err != nil {
+		logger.Event(ctx, logger.ERROR, "create task failed", err)
+		return output.Task{}, err
+	}
+
+	return toTaskOutput(task), nil
+}
+
+func (t *taskUsecase) List(ctx context.Context, email, projectID string) ([]output.Task, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := t.projectID(ctx, acc.ID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.tr.FindByAccountID(ctx, acc.ID, id)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find tasks failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Task, 0, len(tasks))
+	for _, task := range tasks {
+		res = append(res, toTaskOutput(task))
+	}
+
+	return res, nil
+}
+
+func (t *taskUsecase) Update(ctx context.Context, email, id string, in input.Task) (output.Task, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return output.Task{}, err
+	}
+
+	task, err := findOwnTask(ctx, t.tr, acc.ID, id)
+	if err != nil {
+		return output.Task{}, err
+	}
+
+	projectID, err := t.projectID(ctx, acc.ID, in.ProjectID)
+	if err != nil {
+		return output.Task{}, err
+	}
+
+	if err := task.Update(projectID, in.Title, in.EstimatedPomodoros, in.Completed); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Task{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := t.tr.Save(ctx, task); err != nil {
+		logger.Event(ctx, logger.ERROR, "update task failed", err)
+		return output.Task{}, err
+	}
+
+	return toTaskOutput(task), nil
+}
+
+func (t *taskUsecase) Delete(ctx context.Context, email, id string) error {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	task, err := findOwnTask(ctx, t.tr, acc.ID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := t.tr.Delete(ctx, task.ID); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete task failed", err)
+		return err
+	}
+
+	return nil
+}
+
+// projectID は指定されたプロジェクトが自分のものであることを確認して返す。空の場合はnilを返す
+func (t *taskUsecase) projectID(ctx context.Context, accID model.AccountID, id string) (*model.ProjectID, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	project, err := findOwnProject(ctx, t.pr, accID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &project.ID, nil
+}
+
+func (t *taskUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := t.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// findOwnTask は他のアカウントのタスクを存在しないものとして扱う
+func findOwnTask(ctx context.Context, tr repository.TaskRepository, accID model.AccountID, id string) (model.Task, error) {
+	taskID, err := model.NewTaskID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid task id", err)
+		return model.Task{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	task, err := tr.FindByID(ctx, taskID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.ERROR, "find task failed", err)
+		return model.Task{}, err
+	}
+
+	if err != nil || task.AccountID != accID {
+		err := errors.Newf("task not found: %s", id)
+		logger.Event(ctx, logger.INFO, "task not found", err)
+		return model.Task{}, apperr.NewApplicationError(apperr.ErrNotFound, "タスクが見つかりません", err)
+	}
+
+	return task, nil
+}
+
+func toTaskOutput(t model.Task) output.Task {
+	var projectID *string
+	if t.ProjectID != nil {
+		id := t.ProjectID.String()
+		projectID = &id
+	}
+
+	return output.Task{
+		ID:                 t.ID.String(),
+		ProjectID:          projectID,
+		Title:              t.Title,
+		EstimatedPomodoros: t.EstimatedPomodoros,
+		Completed:          t.Completed,
+		CreatedAt:          t.CreatedAt,
+	}
+}
+
+func NewTaskUsecase(ar repository.AccountRepository, pr repository.ProjectRepository, tr repository.TaskRepository) TaskUsecase {
+	return &taskUsecase{ar, pr, tr}
+}
@@ -6,38 +6,94 @@ import (
 	"pomodoro-rpg-api/domain/repository"
 	"pomodoro-rpg-api/pkg/apperr"
 	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
 
 	"github.com/cockroachdb/errors"
 )
 
+const (
+	maxTimeSearchLimit = 200
+	tagSuggestLimit    = 10
+)
+
 type TimeUsecase interface {
-	Create(ctx context.Context, email string, focusTime float64) error
+	// Create, Update は同じ時間帯の記録と重なる場合は記録できない
+	Create(ctx context.Context, email string, in input.Time) error
+	List(ctx context.Context, email string, in input.TimeSearch) ([]output.Time, error)
+	// Update, Delete は記録から24時間以内に限り、付与済みの報酬を修正後の内容に合わせて減らし、
+	// 目標を下回った場合は達成を取り消す。仲間の経験値も増減させ、勝利したレイドに貢献した記録は減らせない。
+	// 削除した記録や最低限の長さを下回った記録にあわせて抽選したドロップは取り消す
+	Update(ctx context.Context, email, id string, in input.Time) error
+	Delete(ctx context.Context, email, id string) error
+	// SuggestTags はprefixで始まるタグを利用回数の多い順に返す
+	SuggestTags(ctx context.Context, email, prefix string) ([]string, error)
 }
 
 type timeUsecase struct {
-	ar repository.AccountRepository
-	tr repository.TimeRepository
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	tkr      repository.TaskRepository
+	tr       repository.TimeRepository
+	tgr      repository.TagRepository
+	tx       repository.Transaction
+	recorder SessionRecorder
+	rewarder Rewarder
+	goals    GoalAssessor
+	raids    RaidReviser
+	comps    CompanionReviser
+	looter   Looter
 }
 
-func (t *timeUsecase) Create(ctx context.Context, email string, focusTime float64) error {
-	acc, err := t.ar.FindByEmail(email)
+func (t *timeUsecase) Create(ctx context.Context, email string, in input.Time) error {
+	acc, err := t.findAccount(ctx, email)
 	if err != nil {
-		if errors.Is(err, apperr.ErrDataNotFound) {
-			logger.Event(ctx, logger.INFO, "account not found", err)
-			return apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
-		}
-		logger.Event(ctx, logger.ERROR, "find account failed", err)
 		return err
 	}
 
+	settings, err := findSettings(ctx, t.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return err
+	}
+
+	if err := settings.ValidateFocusMinutes(in.FocusTime); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "設定した集中時間を超えています", err)
+	}
+
+	var task *model.TaskID
+	if in.TaskID != "" {
+		found, err := findOwnTask(ctx, t.tkr, acc.ID, in.TaskID)
+		if err != nil {
+			return err
+		}
+		task = &found.ID
+	}
+
 	id := model.GenerateTimeID()
-	model, err := model.NewTime(id, focusTime, acc.ID)
+	record, err := model.NewTime(id, in.FocusTime, acc.ID, task)
 	if err != nil {
 		logger.Event(ctx, logger.INFO, err.Error(), err)
 		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
 	}
 
-	if err := t.tr.Create(model); err != nil {
+	if err := record.Annotate(in.Note, in.Tags); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = t.tx.Do(ctx, func(ctx context.Context) error {
+		if err := t.rejectOverlap(ctx, record); err != nil {
+			return err
+		}
+		return t.recorder.RecordFocus(ctx, record)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
 		logger.Event(ctx, logger.ERROR, "create failed", err)
 		return err
 	}
@@ -45,6 +101,236 @@ func (t *timeUsecase) Create(ctx context.Context, email string, focusTime float6
 	return nil
 }
 
-func NewTimeUsecase(ar repository.AccountRepository, tr repository.TimeRepository) TimeUsecase {
-	return &timeUsecase{ar, tr}
+func (t *timeUsecase) List(ctx context.Context, email string, in input.TimeSearch) ([]output.Time, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Limit < 0 || in.Limit > maxTimeSearchLimit || in.Offset < 0 {
+		err := errors.Newf("invalid paging: limit=%d offset=%d", in.Limit, in.Offset)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	filter := repository.TimeFilter{
+		Limit:  in.Limit,
+		Offset: in.Offset,
+	}
+	if in.Tag != "" {
+		tag, err := model.NormalizeTagName(in.Tag)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return nil, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+		}
+		filter.Tag = tag
+	}
+
+	times, err := t.tr.Find(ctx, acc.ID, filter)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find times failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Time, 0, len(times))
+	for _, tm := range times {
+		res = append(res, toTimeOutput(tm))
+	}
+
+	return res, nil
+}
+
+func (t *timeUsecase) Update(ctx context.Context, email, id string, in input.Time) error {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	settings, err := findSettings(ctx, t.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return err
+	}
+
+	if err := settings.ValidateFocusMinutes(in.FocusTime); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "設定した集中時間を超えています", err)
+	}
+
+	var task *model.TaskID
+	if in.TaskID != "" {
+		found, err := findOwnTask(ctx, t.tkr, acc.ID, in.TaskID)
+		if err != nil {
+			return err
+		}
+		task = &found.ID
+	}
+
+	return t.correct(ctx, acc.ID, id, func(ctx context.Context, record *model.Time, now time.Time) error {
+		if err := record.Correct(in.FocusTime, task, in.Note, in.Tags, now); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "記録を修正できません", err)
+		}
+		if err := t.rejectOverlap(ctx, *record); err != nil {
+			return err
+		}
+		return t.tr.Update(ctx, *record)
+	})
+}
+
+func (t *timeUsecase) Delete(ctx context.Context, email, id string) error {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return t.correct(ctx, acc.ID, id, func(ctx context.Context, record *model.Time, now time.Time) error {
+		if err := record.Delete(now); err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "記録を削除できません", err)
+		}
+		return t.tr.Delete(ctx, *record)
+	})
+}
+
+// correct は記録をfnで修正し、付与済みの報酬を修正後の報酬まで減らす
+func (t *timeUsecase) correct(ctx context.Context, accID model.AccountID, id string, fn func(ctx context.Context, record *model.Time, now time.Time) error) error {
+	timeID, err := model.NewTimeID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid time id", err)
+		return apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = t.tx.Do(ctx, func(ctx context.Context) error {
+		record, err := t.tr.FindByIDForUpdate(ctx, timeID)
+		if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+		if err != nil || record.AccountID != accID {
+			err := errors.Newf("time not found: %s", id)
+			logger.Event(ctx, logger.INFO, "time not found", err)
+			return apperr.NewApplicationError(apperr.ErrNotFound, "記録が見つかりません", err)
+		}
+
+		focusTime := record.FocusTime
+		if err := fn(ctx, &record, time.Now()); err != nil {
+			return err
+		}
+		// 削除して記録し直してもドロップを抽選し直せないよう、削除した記録や最低限の長さを下回った記録のドロップは取り消す。
+		// 修正で最低限の長さに達しても抽選はし直さない
+		if record.DeletedAt != nil || !model.NewFocusRewardContext(record).Lootable() {
+			if err := t.looter.Void(ctx, accID, model.NewFocusRewardContext(record).SourceID); err != nil {
+				return err
+			}
+		}
+		if err := t.raids.Withdraw(ctx, record); err != nil {
+			return err
+		}
+		if err := t.comps.Retrain(ctx, record); err != nil {
+			return err
+		}
+
+		// メモやタグ、タスクのみの修正では報酬も目標も変わらない
+		if record.DeletedAt == nil && record.FocusTime == focusTime {
+			return nil
+		}
+
+		after := model.Reward{}
+		if record.DeletedAt == nil {
+			after = model.NewFocusReward(record)
+		}
+		if err := t.rewarder.Adjust(ctx, accID, after, model.NewFocusRewardContext(record)); err != nil {
+			return err
+		}
+		return t.goals.Reassess(ctx, accID, record.ExecutionDate)
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperr.ApplicationError); ok {
+			return appErr
+		}
+		logger.Event(ctx, logger.ERROR, "correct time failed", err)
+		return err
+	}
+
+	return nil
+}
+
+// rejectOverlap は同期と同じく、同じ時間帯に集中した記録を二重に数えないよう重なる記録があれば拒否する
+func (t *timeUsecase) rejectOverlap(ctx context.Context, record model.Time) error {
+	overlapped, err := t.tr.ExistsOverlapping(ctx, record)
+	if err != nil {
+		return err
+	}
+	if overlapped {
+		err := errors.Newf("time %s overlaps existing records", record.ID)
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return apperr.NewApplicationError(apperr.ErrConflict, "同じ時間帯の記録が既にあります", err)
+	}
+	return nil
+}
+
+func (t *timeUsecase) SuggestTags(ctx context.Context, email, prefix string) ([]string, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// 入力途中の接頭辞も保存時と同じ規則で正規化して比較する
+	normalized, err := model.NormalizeTagName(prefix)
+	if err != nil {
+		normalized = ""
+	}
+
+	tags, err := t.tgr.Suggest(ctx, acc.ID, normalized, tagSuggestLimit)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "suggest tags failed", err)
+		return nil, err
+	}
+
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		res = append(res, tag.Name)
+	}
+
+	return res, nil
+}
+
+func (t *timeUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := t.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報が取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "find account failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toTimeOutput(t model.Time) output.Time {
+	var taskID *string
+	if t.TaskID != nil {
+		id := t.TaskID.String()
+		taskID = &id
+	}
+
+	tags := t.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return output.Time{
+		ID:            t.ID.String(),
+		FocusTime:     t.FocusTime,
+		TaskID:        taskID,
+		Note:          t.Note,
+		Tags:          tags,
+		ExecutionDate: t.ExecutionDate,
+	}
+}
+
+func NewTimeUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, tkr repository.TaskRepository, tr repository.TimeRepository, tgr repository.TagRepository, tx repository.Transaction, recorder SessionRecorder, rewarder Rewarder, goals GoalAssessor, raids RaidReviser, comps CompanionReviser, looter Looter) TimeUsecase {
+	return &timeUsecase{ar, sr, tkr, tr, tgr, tx, recorder, rewarder, goals, raids, comps, looter}
 }
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// TimerSubscriber はアカウントごとにタイマーの状態の変更を購読する
+type TimerSubscriber interface {
+	// Subscribe は購読用のチャネルと購読を解除する関数を返す
+	Subscribe(accID model.AccountID) (<-chan model.TimerSession, func())
+}
+
+type TimerUsecase interface {
+	Get(ctx context.Context, email string) (output.Timer, error)
+	// Start はkindが空の場合は集中、plannedSecondsが0の場合は種類ごとに設定した長さで開始する。
+	// 同じ時間を重ねて記録しないよう、まだ終わっていない部屋に参加中は開始できない
+	Start(ctx context.Context, email string, kind string, plannedSeconds int) (output.Timer, error)
+	Pause(ctx context.Context, email string) (output.Timer, error)
+	Resume(ctx context.Context, email string) (output.Timer, error)
+	Complete(ctx context.Context, email string) (output.Timer, error)
+	// Abandon はセッションを途中でやめる。集中をやめた場合はダメージを受ける
+	Abandon(ctx context.Context, email string) (output.Timer, error)
+	// Subscribe は現在の状態と、以降の状態変更をctxが終了するまで返す
+	Subscribe(ctx context.Context, email string) (<-chan output.Timer, error)
+}
+
+type timerUsecase struct {
+	ar       repository.AccountRepository
+	sr       repository.SettingsRepository
+	tsr      repository.TimerSessionRepository
+	frr      repository.FocusRoomRepository
+	fpr      repository.FocusRoomParticipantRepository
+	tx       repository.Transaction
+	recorder SessionRecorder
+	rewarder Rewarder
+	hub      TimerSubscriber
+}
+
+func (t *timerUsecase) Get(ctx context.Context, email string) (output.Timer, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return output.Timer{}, err
+	}
+
+	s, err := t.current(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find timer session failed", err)
+		return output.Timer{}, err
+	}
+
+	return toTimerOutput(s, time.Now()), nil
+}
+
+func (t *timerUsecase) Start(ctx context.Context, email string, kind string, plannedSeconds int) (output.Timer, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return output.Timer{}, err
+	}
+
+	sessionKind := model.SessionFocus
+	if kind != "" {
+		sessionKind, err = model.NewSessionKind(kind)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return output.Timer{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+		}
+	}
+
+	settings, err := findSettings(ctx, t.sr, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find settings failed", err)
+		return output.Timer{}, err
+	}
+
+	if plannedSeconds == 0 {
+		plannedSeconds = settings.Minutes(sessionKind) * 60
+	}
+	if err := settings.ValidateSessionMinutes(sessionKind, float64(plannedSeconds)/60); err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Timer{}, apperr.NewApplicationError(apperr.ErrBadRequest, "設定した時間を超えています", err)
+	}
+
+	now := time.Now()
+	s, err := model.StartTimerSession(model.GenerateTimerSessionID(), acc.ID, sessionKind, plannedSeconds, now)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Timer{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	err = t.tx.Do(ctx, func(ctx context.Context) error {
+		_, err := t.tsr.FindActiveByAccountID(ctx, acc.ID)
+		if err == nil {
+			err := errors.New("timer is already active")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrBadRequest, "タイマーは既に開始されています", err)
+		}
+		if !errors.Is(err, apperr.ErrDataNotFound) {
+			return err
+		}
+
+		_, ongoing, err := findUncompletedFocusRoom(ctx, t.frr, t.fpr, acc.ID, now)
+		if err != nil {
+			return err
+		}
+		if ongoing {
+			err := errors.New("focus room is ongoing")
+			logger.Event(ctx, logger.INFO, err.Error(), err)
+			return apperr.NewApplicationError(apperr.ErrConflict, "部屋に参加中はタイマーを開始できません", err)
+		}
+
+		return t.save(ctx, s)
+	})
+	if err != nil {
+		return output.Timer{}, t.handleTxError(ctx, err)
+	}
+
+	return toTimerOutput(s, now), nil
+}
+
+func (t *timerUsecase) Pause(ctx context.Context, email string) (output.Timer, error) {
+	return t.transition(ctx, email, func(ctx context.Context, s *model.TimerSession, now time.Time) error {
+		if err := s.Pause(now); err != nil {
+			return invalidTimerTransition(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (t *timerUsecase) Resume(ctx context.Context, email string) (output.Timer, error) {
+	return t.transition(ctx, email, func(ctx context.Context, s *model.TimerSession, now time.Time) error {
+		if err := s.Resume(now); err != nil {
+			return invalidTimerTransition(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (t *timerUsecase) Complete(ctx context.Context, email string) (output.Timer, error) {
+	return t.transition(ctx, email, func(ctx context.Context, s *model.TimerSession, now time.Time) error {
+		minutes, err := s.Complete(now)
+		if err != nil {
+			return invalidTimerTransition(ctx, err)
+		}
+
+		if !s.Kind.IsBreak() {
+			record, err := model.NewTime(model.GenerateTimeID(), minutes, s.AccountID, nil)
+			if err != nil {
+				return invalidTimerTransition(ctx, err)
+			}
+			return t.recorder.RecordFocus(ctx, record)
+		}
+
+		b, err := model.NewBreak(model.GenerateBreakID(), s.AccountID, s.Kind, minutes)
+		if err != nil {
+			return invalidTimerTransition(ctx, err)
+		}
+
+		settings, err := findSettings(ctx, t.sr, s.AccountID)
+		if err != nil {
+			return err
+		}
+		return t.recorder.RecordBreak(ctx, b, settings.LongBreakInterval)
+	})
+}
+
+func (t *timerUsecase) Abandon(ctx context.Context, email string) (output.Timer, error) {
+	return t.transition(ctx, email, func(ctx context.Context, s *model.TimerSession, now time.Time) error {
+		if err := s.Abandon(now); err != nil {
+			return invalidTimerTransition(ctx, err)
+		}
+
+		if s.Kind.IsBreak() {
+			return nil
+		}
+		return t.rewarder.Damage(ctx, s.AccountID, model.AbandonDamage())
+	})
+}
+
+func (t *timerUsecase) Subscribe(ctx context.Context, email string) (<-chan output.Timer, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// 購読開始から現在の状態の取得までの間の変更を取りこぼさないよう、先に購読する
+	sessions, unsubscribe := t.hub.Subscribe(acc.ID)
+
+	s, err := t.current(ctx, acc.ID)
+	if err != nil {
+		unsubscribe()
+		logger.Event(ctx, logger.ERROR, "find timer session failed", err)
+		return nil, err
+	}
+
+	res := make(chan output.Timer, 1)
+	res <- toTimerOutput(s, time.Now())
+
+	go func() {
+		defer close(res)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case s := <-sessions:
+				select {
+				case res <- toTimerOutput(s, time.Now()):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+// transition は実行中のセッションの状態をfnで変更して保存し、接続中のクライアントに通知する
+func (t *timerUsecase) transition(ctx context.Context, email string, fn func(ctx context.Context, s *model.TimerSession, now time.Time) error) (output.Timer, error) {
+	acc, err := t.findAccount(ctx, email)
+	if err != nil {
+		return output.Timer{}, err
+	}
+
+	now := time.Now()
+	var s model.TimerSession
+
+	err = t.tx.Do(ctx, func(ctx context.Context) error {
+		found, err := t.tsr.FindActiveByAccountID(ctx, acc.ID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrDataNotFound) {
+				logger.Event(ctx, logger.INFO, "timer session not found", err)
+				return apperr.NewApplicationError(apperr.ErrBadRequest, "実行中のタイマーがありません", err)
+			}
+			return err
+		}
+
+		s = found
+		if err := fn(ctx, &s, now); err != nil {
+			return err
+		}
+
+		return t.save(ctx, s)
+	})
+	if err != nil {
+		return output.Timer{}, t.handleTxError(ctx, err)
+	}
+
+	return toTimerOutput(s, now), nil
+}
+
+func (t *timerUsecase) save(ctx context.Context, s model.TimerSession) error {
+	if err := t.tsr.Save(ctx, s); err != nil {
+		return err
+	}
+	return t.tsr.Notify(ctx, s)
+}
+
+func (t *timerUsecase) current(ctx context.Context, accID model.AccountID) (model.TimerSession, error) {
+	s, err := t.tsr.FindActiveByAccountID(ctx, accID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			return model.IdleTimerSession(accID, time.Now()), nil
+		}
+		return model.TimerSession{}, err
+	}
+	return s, nil
+}
+
+func (t *timerUsecase) handleTxError(ctx context.Context, err error) error {
+	if appErr, ok := err.(*apperr.ApplicationError); ok {
+		return appErr
+	}
+	logger.Event(ctx, logger.ERROR, "timer session update failed", err)
+	return err
+}
+
+func invalidTimerTransition(ctx context.Context, err error) error {
+	logger.Event(ctx, logger.INFO, err.Error(), err)
+	return apperr.NewApplicationError(apperr.ErrBadRequest, "タイマーの状態を変更できません", err)
+}
+
+func (t *timerUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := t.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func toTimerOutput(s model.TimerSession, now time.Time) output.Timer {
+	res := output.Timer{
+		ID:               s.ID.String(),
+		Kind:             string(s.Kind),
+		Status:           string(s.Status),
+		PlannedSeconds:   s.PlannedSeconds,
+		ElapsedSeconds:   int(s.Elapsed(now).Seconds()),
+		RemainingSeconds: int(s.Remaining(now).Seconds()),
+		ServerTime:       now,
+	}
+	if s.Status != model.TimerIdle {
+		res.StartedAt = &s.StartedAt
+	}
+	return res
+}
+
+func NewTimerUsecase(ar repository.AccountRepository, sr repository.SettingsRepository, tsr repository.TimerSessionRepository, frr repository.FocusRoomRepository, fpr repository.FocusRoomParticipantRepository, tx repository.Transaction, recorder SessionRecorder, rewarder Rewarder, hub TimerSubscriber) TimerUsecase {
+	return &timerUsecase{ar, sr, tsr, frr, fpr, tx, recorder, rewarder, hub}
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"pomodoro-rpg-api/domain/model"
+	"pomodoro-rpg-api/domain/repository"
+	"pomodoro-rpg-api/infra/service"
+	"pomodoro-rpg-api/pkg/apperr"
+	"pomodoro-rpg-api/pkg/logger"
+	"pomodoro-rpg-api/usecase/input"
+	"pomodoro-rpg-api/usecase/output"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	webhookBatchSize     = 50
+	webhookLease         = 2 * time.Minute
+	webhookDeliveryLimit = 100
+)
+
+type WebhookUsecase interface {
+	Register(ctx context.Context, email string, input input.Webhook) (output.Webhook, error)
+	List(ctx context.Context, email string) ([]output.Webhook, error)
+	Delete(ctx context.Context, email, id string) error
+	Deliveries(ctx context.Context, email, id string) ([]output.WebhookDelivery, error)
+	Enqueue(ctx context.Context, ev model.DomainEvent) error
+	Deliver(ctx context.Context) (int, error)
+}
+
+type webhookUsecase struct {
+	ar     repository.AccountRepository
+	wr     repository.WebhookRepository
+	wdr    repository.WebhookDeliveryRepository
+	sender service.WebhookSender
+}
+
+func (w *webhookUsecase) Register(ctx context.Context, email string, in input.Webhook) (output.Webhook, error) {
+	acc, err := w.findAccount(ctx, email)
+	if err != nil {
+		return output.Webhook{}, err
+	}
+
+	registered, err := w.wr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find webhooks failed", err)
+		return output.Webhook{}, err
+	}
+
+	events := make([]model.EventName, 0, len(in.Events))
+	for _, ev := range in.Events {
+		events = append(events, model.EventName(ev))
+	}
+
+	webhook, err := model.NewWebhook(model.GenerateWebhookID(), acc.ID, in.URL, events, len(registered))
+	if err != nil {
+		logger.Event(ctx, logger.INFO, err.Error(), err)
+		return output.Webhook{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	if err := w.wr.Create(ctx, webhook); err != nil {
+		logger.Event(ctx, logger.ERROR, "create webhook failed", err)
+		return output.Webhook{}, err
+	}
+
+	res := toWebhookOutput(webhook)
+	res.Secret = webhook.Secret
+	return res, nil
+}
+
+func (w *webhookUsecase) List(ctx context.Context, email string) ([]output.Webhook, error) {
+	acc, err := w.findAccount(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := w.wr.FindByAccountID(ctx, acc.ID)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find webhooks failed", err)
+		return nil, err
+	}
+
+	res := make([]output.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		res = append(res, toWebhookOutput(webhook))
+	}
+
+	return res, nil
+}
+
+func (w *webhookUsecase) Delete(ctx context.Context, email, id string) error {
+	webhook, err := w.findOwnWebhook(ctx, email, id)
+	if err != nil {
+		return err
+	}
+
+	if err := w.wr.Delete(ctx, webhook.ID); err != nil {
+		logger.Event(ctx, logger.ERROR, "delete webhook failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookUsecase) Deliveries(ctx context.Context, email, id string) ([]output.WebhookDelivery, error) {
+	webhook, err := w.findOwnWebhook(ctx, email, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := w.wdr.FindByWebhookID(ctx, webhook.ID, webhookDeliveryLimit)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "find webhook deliveries failed", err)
+		return nil, err
+	}
+
+	res := make([]output.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, output.WebhookDelivery{
+			ID:           d.ID,
+			Event:        string(d.EventName),
+			Status:       string(d.Status),
+			Attempts:     d.Attempts,
+			ResponseCode: d.ResponseCode,
+			LastError:    d.LastError,
+			DeliveredAt:  d.DeliveredAt,
+			CreatedAt:    d.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// Enqueue はoutboxの購読者として呼ばれ、イベントを購読しているWebhookへの配信を登録する
+func (w *webhookUsecase) Enqueue(ctx context.Context, ev model.DomainEvent) error {
+	accEv, ok := ev.(model.AccountEvent)
+	if !ok {
+		return nil
+	}
+
+	webhooks, err := w.wr.FindByAccountID(ctx, accEv.OwnerID())
+	if err != nil {
+		return err
+	}
+
+	var deliveries []model.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(ev.EventName()) {
+			continue
+		}
+
+		d, err := model.NewWebhookDelivery(webhook.ID, ev)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return w.wdr.Create(ctx, deliveries...)
+}
+
+// Deliver は配信待ちのWebhookを送信し、成功した件数を返す
+func (w *webhookUsecase) Deliver(ctx context.Context) (int, error) {
+	deliveries, err := w.wdr.Claim(ctx, webhookBatchSize, webhookLease)
+	if err != nil {
+		logger.Event(ctx, logger.ERROR, "webhook claim failed", err)
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		webhook, err := w.wr.FindByID(ctx, d.WebhookID)
+		if err != nil {
+			logger.Event(ctx, logger.ERROR, "find webhook failed", err)
+			continue
+		}
+
+		code, err := w.sender.Send(ctx, webhook.URL, webhook.Secret, d.Payload)
+		if err != nil {
+			logger.Event(ctx, logger.INFO, "webhook delivery failed", err)
+			d.Fail(code, err, time.Now())
+		} else {
+			d.Succeed(code, time.Now())
+			delivered++
+		}
+
+		if err := w.wdr.Update(ctx, d); err != nil {
+			logger.Event(ctx, logger.ERROR, "update webhook delivery failed", err)
+		}
+	}
+
+	return delivered, nil
+}
+
+func (w *webhookUsecase) findAccount(ctx context.Context, email string) (model.Account, error) {
+	acc, err := w.ar.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDataNotFound) {
+			logger.Event(ctx, logger.INFO, "account not found", err)
+			return model.Account{}, apperr.NewApplicationError(apperr.ErrBadRequest, "アカウント情報を取得できませんでした", err)
+		}
+		logger.Event(ctx, logger.ERROR, "account find failed", err)
+		return model.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func (w *webhookUsecase) findOwnWebhook(ctx context.Context, email, id string) (model.Webhook, error) {
+	acc, err := w.findAccount(ctx, email)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	webhookID, err := model.NewWebhookID(id)
+	if err != nil {
+		logger.Event(ctx, logger.INFO, "invalid webhook id", err)
+		return model.Webhook{}, apperr.NewApplicationError(apperr.ErrBadRequest, "入力値が正しくありません", err)
+	}
+
+	webhook, err := w.wr.FindByID(ctx, webhookID)
+	if err != nil && !errors.Is(err, apperr.ErrDataNotFound) {
+		logger.Event(ctx, logger.ERROR, "find webhook failed", err)
+		return model.Webhook{}, err
+	}
+
+	if err != nil || webhook.AccountID != acc.ID {
+		err := errors.Newf("webhook not found: %s", id)
+		logger.Event(ctx, logger.INFO, "webhook not found", err)
+		return model.Webhook{}, apperr.NewApplicationError(apperr.ErrNotFound, "Webhookが見つかりません", err)
+	}
+
+	return webhook, nil
+}
+
+func toWebhookOutput(w model.Webhook) output.Webhook {
+	events := make([]string, 0, len(w.Events))
+	for _, ev := range w.Events {
+		events = append(events, string(ev))
+	}
+
+	return output.Webhook{
+		ID:        w.ID.String(),
+		URL:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func NewWebhookUsecase(ar repository.AccountRepository, wr repository.WebhookRepository, wdr repository.WebhookDeliveryRepository, sender service.WebhookSender) WebhookUsecase {
+	return &webhookUsecase{ar, wr, wdr, sender}
+}